@@ -0,0 +1,224 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fakeservice provides the local HTTP server that the tests of the service packages emulate REST interfaces
+// with. Requests are routed to handlers by method and path pattern. The handlers run one at a time, so that they can
+// share the state of the fake without locking it, and the server records the requests that change the service and
+// counts the requests that overlap.
+//
+// Handlers run inside Ginkgo specs: failed assertions in a handler fail the spec, and so does a request that no route
+// matches.
+package fakeservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo"
+)
+
+// Handler handles a request to a route.
+type Handler func(call *Call)
+
+// Interceptor sees every request before it is routed, and reports whether it answered the request itself.
+type Interceptor func(call *Call) bool
+
+// Server : A local REST endpoint
+type Server struct {
+	*httptest.Server
+
+	lock        sync.Mutex
+	routes      []*Route
+	intercept   Interceptor
+	requests    []string
+	inFlight    int
+	maxInFlight int
+}
+
+// Route : The handler of the requests with a method whose path matches a pattern
+type Route struct {
+	method  string
+	pattern []string
+	handler Handler
+	delay   time.Duration
+}
+
+// Delay : Holds the requests to the route before they are handled, without blocking other requests, so that
+// concurrent requests overlap
+func (route *Route) Delay(delay time.Duration) *Route {
+	route.delay = delay
+	return route
+}
+
+// Call : A request to a route, and the response to it
+type Call struct {
+	*http.Request
+	Response http.ResponseWriter
+
+	// The values of the parameters of the path pattern, by name.
+	Params map[string]string
+}
+
+// NewServer : Starts a server without routes
+func NewServer() *Server {
+	server := &Server{}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	return server
+}
+
+// Handle : Routes the requests with method whose path matches pattern to handler. A segment of the pattern in braces,
+// such as `/v1/customizations/{id}`, matches any one segment of the path and is passed to the handler in Params.
+// Routes are matched in the order they were added.
+func (server *Server) Handle(method string, pattern string, handler Handler) *Route {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	route := &Route{method: method, pattern: strings.Split(strings.Trim(pattern, "/"), "/"), handler: handler}
+	server.routes = append(server.routes, route)
+	return route
+}
+
+// Intercept : Sets a function that sees every request before it is routed, for behavior that is common to the routes,
+// such as rejecting requests while the service is busy
+func (server *Server) Intercept(intercept Interceptor) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	server.intercept = intercept
+}
+
+// Requests returns the requests other than GET that the server received, in order, as the method and the path with
+// its query, for example `POST /v1/customizations/cust1/train?customization_weight=0.5`.
+func (server *Server) Requests() []string {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	return append([]string(nil), server.requests...)
+}
+
+// ClearRequests forgets the requests that the server received so far.
+func (server *Server) ClearRequests() {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	server.requests = nil
+}
+
+// MaxInFlight returns the largest number of requests that the server was handling at the same time.
+func (server *Server) MaxInFlight() int {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	return server.maxInFlight
+}
+
+// Do runs f while no handler is running, so that a spec can read or change the state that the handlers share while
+// requests are in flight.
+func (server *Server) Do(f func()) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	f()
+}
+
+func (server *Server) serveHTTP(response http.ResponseWriter, request *http.Request) {
+	defer ginkgo.GinkgoRecover()
+	call := &Call{Request: request, Response: response}
+
+	server.lock.Lock()
+	server.inFlight++
+	if server.inFlight > server.maxInFlight {
+		server.maxInFlight = server.inFlight
+	}
+	route := server.route(call)
+	server.lock.Unlock()
+	defer func() {
+		server.lock.Lock()
+		server.inFlight--
+		server.lock.Unlock()
+	}()
+	if route != nil && route.delay > 0 {
+		time.Sleep(route.delay)
+	}
+
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	if request.Method != http.MethodGet {
+		entry := request.Method + " " + request.URL.Path
+		if request.URL.RawQuery != "" {
+			entry += "?" + request.URL.RawQuery
+		}
+		server.requests = append(server.requests, entry)
+	}
+	if server.intercept != nil && server.intercept(call) {
+		return
+	}
+	if route == nil {
+		call.Error(http.StatusNotFound, "Not found")
+		ginkgo.Fail(fmt.Sprintf("unexpected request %s %s", request.Method, request.URL.Path))
+	}
+	route.handler(call)
+}
+
+// route : Finds the route of a call and sets its parameters
+func (server *Server) route(call *Call) *Route {
+	segments := strings.Split(strings.Trim(call.URL.Path, "/"), "/")
+	for _, route := range server.routes {
+		if route.method != call.Method || len(route.pattern) != len(segments) {
+			continue
+		}
+		params := map[string]string{}
+		for i, segment := range route.pattern {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				params[strings.Trim(segment, "{}")] = segments[i]
+			} else if segment != segments[i] {
+				params = nil
+				break
+			}
+		}
+		if params != nil {
+			call.Params = params
+			return route
+		}
+	}
+	return nil
+}
+
+// JSON : Answers with a status and a value encoded as JSON. A string or byte slice is sent as is.
+func (call *Call) JSON(status int, value interface{}) {
+	call.Response.Header().Set("Content-Type", "application/json")
+	call.Response.WriteHeader(status)
+	switch value := value.(type) {
+	case string:
+		_, _ = call.Response.Write([]byte(value))
+	case []byte:
+		_, _ = call.Response.Write(value)
+	default:
+		if err := json.NewEncoder(call.Response).Encode(value); err != nil {
+			ginkgo.Fail(err.Error())
+		}
+	}
+}
+
+// Error : Answers with a status and the error body of the service
+func (call *Call) Error(status int, message string) {
+	call.JSON(status, map[string]interface{}{"code": status, "error": message})
+}
+
+// Audio : Answers with audio of a content type
+func (call *Call) Audio(contentType string, audio []byte) {
+	call.Response.Header().Set("Content-Type", contentType)
+	_, _ = call.Response.Write(audio)
+}
@@ -0,0 +1,42 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package jsontuple decodes the fixed-length JSON arrays, such as `["word", start, end]`, that the speech services use
+// for word timings, confidences and marks.
+package jsontuple
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Unmarshal : Decodes a tuple into targets, one target per element. The tuple must have exactly one element per target
+// and each element must decode into its target. The name of the tuple is used in the errors.
+func Unmarshal(data []byte, name string, targets ...interface{}) error {
+	var tuple []json.RawMessage
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return fmt.Errorf("%s %s is not a tuple: %s", name, data, err)
+	}
+	if len(tuple) != len(targets) {
+		return fmt.Errorf("%s %s does not have %d elements", name, data, len(targets))
+	}
+	for i, target := range targets {
+		if err := json.Unmarshal(tuple[i], target); err != nil {
+			return fmt.Errorf("element %d of %s %s: %s", i, name, data, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package rawaudio describes the layout of uncompressed audio for the speechtotextv1 and texttospeechv1 packages.
+// The service packages export Format as speechtotextv1.RawAudioFormat and audioutil.RawFormat.
+package rawaudio
+
+import (
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Encoding : How the samples of audio are encoded, as the format tag of a WAV file
+type Encoding uint16
+
+// The encodings of raw audio that the services accept and synthesize
+const (
+	EncodingPCM   Encoding = 1
+	EncodingALaw  Encoding = 6
+	EncodingMuLaw Encoding = 7
+)
+
+// Format : The layout of uncompressed audio, described by a content type such as `audio/l16;rate=22050` or by the
+// header of a WAV file.
+type Format struct {
+	Encoding Encoding
+
+	// The sampling rate in Hertz.
+	Rate int
+
+	// The number of interleaved channels.
+	Channels int
+
+	// The number of bits in a single sample of a single channel.
+	BitsPerSample int
+
+	// Whether the samples of `audio/l16` are big-endian. Samples in WAV audio are always little-endian.
+	BigEndian bool
+}
+
+// FrameSize returns the number of bytes in one sample of every channel.
+func (format Format) FrameSize() int {
+	return format.Channels * format.BitsPerSample / 8
+}
+
+// ByteRate returns the number of bytes in one second of audio.
+func (format Format) ByteRate() int {
+	return format.Rate * format.FrameSize()
+}
+
+// Duration returns how long size bytes of audio play.
+func (format Format) Duration(size int64) time.Duration {
+	return time.Duration(float64(size) / float64(format.ByteRate()) * float64(time.Second))
+}
+
+// Parse : Parses a raw audio content type: `audio/l16`, `audio/mulaw` and `audio/alaw` with a `rate` parameter, and
+// `audio/basic`. The `channels` and `endianness` parameters are read too; `audio/l16` is little-endian unless
+// `endianness=big-endian` is given. An error is returned for compressed or containerized formats, whose layout cannot
+// be derived from the content type alone.
+func Parse(contentType string) (format Format, err error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return format, fmt.Errorf("invalid content type %q: %s", contentType, err)
+	}
+
+	format.Channels = 1
+	format.BitsPerSample = 8
+	switch mediaType {
+	case "audio/l16":
+		format.Encoding = EncodingPCM
+		format.BitsPerSample = 16
+		format.BigEndian = strings.EqualFold(params["endianness"], "big-endian")
+	case "audio/mulaw":
+		format.Encoding = EncodingMuLaw
+	case "audio/alaw":
+		format.Encoding = EncodingALaw
+	case "audio/basic":
+		format.Encoding = EncodingMuLaw
+		format.Rate = 8000
+		return format, nil
+	default:
+		return format, fmt.Errorf("content type %s is not a raw audio format", mediaType)
+	}
+
+	rate, ok := params["rate"]
+	if !ok {
+		return format, fmt.Errorf("content type %s requires a rate parameter", mediaType)
+	}
+	if format.Rate, err = strconv.Atoi(strings.TrimSpace(rate)); err != nil || format.Rate <= 0 {
+		return format, fmt.Errorf("invalid rate %q in content type %s", rate, contentType)
+	}
+	if channels, ok := params["channels"]; ok {
+		if format.Channels, err = strconv.Atoi(strings.TrimSpace(channels)); err != nil || format.Channels <= 0 {
+			return format, fmt.Errorf("invalid channels %q in content type %s", channels, contentType)
+		}
+	}
+	return format, nil
+}
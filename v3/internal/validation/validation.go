@@ -0,0 +1,62 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package validation collects the problems that the Validate methods of the grammar, ssml, lexicon and prompts
+// packages find, so that they are reported together in one error. Each package exports Error as its ValidationError.
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error : The problems that a Validate method found
+type Error struct {
+	// What was validated, such as `SSML` or `grammar`.
+	Subject string
+
+	Problems []string
+}
+
+func (err *Error) Error() string {
+	return "invalid " + err.Subject + ": " + strings.Join(err.Problems, "; ")
+}
+
+// Problems : The problems found so far
+type Problems []string
+
+// Add : Adds a problem described by a format and its arguments, as for fmt.Sprintf
+func (problems *Problems) Add(format string, args ...interface{}) {
+	*problems = append(*problems, fmt.Sprintf(format, args...))
+}
+
+// Err : Returns an *Error for subject that lists the problems, or nil when there are none
+func (problems Problems) Err(subject string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return &Error{Subject: subject, Problems: problems}
+}
+
+// Contains : Reports whether values include value
+func Contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
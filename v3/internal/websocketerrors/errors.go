@@ -0,0 +1,93 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package websocketerrors provides the errors that the speechtotextv1 and texttospeechv1 packages report for their
+// websocket connections. The service packages export them under their own names, as WebsocketDialError and
+// WebsocketCloseError.
+package websocketerrors
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+// DialError : The websocket handshake with the service failed. When the service answered the handshake, the HTTP
+// status code and body of its response are included.
+type DialError struct {
+	// The HTTP status code of the handshake response, or 0 if no response was received.
+	StatusCode int
+
+	// The body of the handshake response, typically a JSON error document.
+	Body string
+
+	// The underlying dial error.
+	Err error
+}
+
+func (e *DialError) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("websocket dial failed: %s", e.Err)
+	}
+	if e.Body == "" {
+		return fmt.Sprintf("websocket dial failed with status %d: %s", e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("websocket dial failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+func (e *DialError) Unwrap() error {
+	return e.Err
+}
+
+// CloseError : The websocket connection was closed with a code other than a normal closure.
+type CloseError struct {
+	// The close code, see https://www.rfc-editor.org/rfc/rfc6455#section-7.4.1.
+	Code int
+
+	// The close reason sent with the code, if any.
+	Text string
+}
+
+func (e *CloseError) Error() string {
+	if e.Text == "" {
+		return fmt.Sprintf("websocket closed abnormally with code %d", e.Code)
+	}
+	return fmt.Sprintf("websocket closed abnormally with code %d: %s", e.Code, e.Text)
+}
+
+// NewDialError : Wraps a dial error with the details of the handshake response, if any
+func NewDialError(response *http.Response, err error) error {
+	dialErr := &DialError{Err: err}
+	if response != nil {
+		dialErr.StatusCode = response.StatusCode
+		if response.Body != nil {
+			body, _ := ioutil.ReadAll(response.Body)
+			response.Body.Close()
+			dialErr.Body = string(body)
+		}
+	}
+	return dialErr
+}
+
+// NewReadError : Converts a websocket close error to a CloseError, other errors are returned as is
+func NewReadError(err error) error {
+	if closeErr, ok := err.(*websocket.CloseError); ok {
+		return &CloseError{Code: closeErr.Code, Text: closeErr.Text}
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package workerpool runs the requests of the helpers that split a job into many requests, such as recognizing long
+// audio or uploading the resources of a custom model, on a bounded number of goroutines. The first request that fails
+// cancels the others.
+package workerpool
+
+import (
+	"context"
+	"sync"
+)
+
+// Pool : Runs tasks on up to a number of goroutines at the same time
+type Pool struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	workers chan struct{}
+	wait    sync.WaitGroup
+
+	lock sync.Mutex
+	err  error
+}
+
+// New : Instantiate a Pool of up to workers goroutines, whose tasks run with a context derived from ctx
+func New(ctx context.Context, workers int) *Pool {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Pool{ctx: ctx, cancel: cancel, workers: make(chan struct{}, workers)}
+}
+
+// Context returns the context of the tasks, which is canceled when a task fails.
+func (pool *Pool) Context() context.Context {
+	return pool.ctx
+}
+
+// Acquire : Waits until a worker is free and takes it, and reports false without taking one when the context of the
+// pool is done. A worker is taken before the task is prepared, so that no more than the number of workers are
+// prepared at the same time; pass it to Go, or give it back with Release.
+func (pool *Pool) Acquire() bool {
+	if pool.ctx.Err() != nil {
+		return false
+	}
+	select {
+	case pool.workers <- struct{}{}:
+		return true
+	case <-pool.ctx.Done():
+		return false
+	}
+}
+
+// Release : Gives back a worker taken with Acquire that no task was started on
+func (pool *Pool) Release() {
+	<-pool.workers
+}
+
+// Go : Runs task on a new goroutine with a worker taken with Acquire, and gives the worker back when the task returns.
+// A task that fails cancels the pool.
+func (pool *Pool) Go(task func(ctx context.Context) error) {
+	pool.wait.Add(1)
+	go func() {
+		defer pool.wait.Done()
+		defer pool.Release()
+		if err := task(pool.ctx); err != nil {
+			pool.Fail(err)
+		}
+	}()
+}
+
+// Fail : Cancels the tasks of the pool, and makes err the error of the pool unless a task has failed already
+func (pool *Pool) Fail(err error) {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	if pool.err == nil {
+		pool.err = err
+		pool.cancel()
+	}
+}
+
+// Wait : Waits until every task has returned, and returns the first error of the tasks, or the error of the context
+// when it was done before the tasks failed
+func (pool *Pool) Wait() error {
+	pool.wait.Wait()
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	err := pool.err
+	if err == nil {
+		err = pool.ctx.Err()
+	}
+	pool.cancel()
+	return err
+}
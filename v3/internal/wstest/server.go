@@ -0,0 +1,161 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package wstest provides the local websocket server that the sttest and ttstest packages build their service
+// protocols on. A Server runs a Handler for every connection to its path, and the Handler drives the service side of
+// the connection with the methods of Conn.
+package wstest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Handler scripts the service side of a single connection.
+type Handler func(conn *Conn)
+
+// Server : A local websocket endpoint
+type Server struct {
+	*httptest.Server
+
+	path    string
+	handler Handler
+
+	lock        sync.Mutex
+	connections int
+}
+
+// NewServer : Starts a server that runs handler for every connection to path. The connection is closed normally when
+// the handler returns, unless the handler closed or aborted it already.
+func NewServer(path string, handler Handler) *Server {
+	server := &Server{path: path, handler: handler}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	return server
+}
+
+// Connections returns the number of connections the server has accepted.
+func (server *Server) Connections() int {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	return server.connections
+}
+
+func (server *Server) serveHTTP(response http.ResponseWriter, request *http.Request) {
+	if request.URL.Path != server.path {
+		http.NotFound(response, request)
+		return
+	}
+	server.lock.Lock()
+	server.connections++
+	server.lock.Unlock()
+
+	conn := &Conn{Request: request, response: response}
+	server.handler(conn)
+	if conn.ws != nil && !conn.closed {
+		_ = conn.CloseWith(websocket.CloseNormalClosure, "")
+	}
+}
+
+// Conn : The service side of a connection. The connection is upgraded to a websocket on first use, unless the handler
+// calls Reject first.
+type Conn struct {
+	// The handshake request sent by the client, including its query parameters and headers.
+	Request *http.Request
+
+	response http.ResponseWriter
+	ws       *websocket.Conn
+	closed   bool
+}
+
+// Reject : Fails the handshake with the given HTTP status code and body instead of upgrading the connection
+func (conn *Conn) Reject(statusCode int, body string) {
+	conn.response.Header().Set("Content-Type", "application/json")
+	conn.response.WriteHeader(statusCode)
+	fmt.Fprint(conn.response, body)
+	conn.closed = true
+}
+
+func (conn *Conn) upgrade() (*websocket.Conn, error) {
+	if conn.closed {
+		return nil, errors.New("connection is closed")
+	}
+	if conn.ws == nil {
+		upgrader := websocket.Upgrader{}
+		ws, err := upgrader.Upgrade(conn.response, conn.Request, nil)
+		if err != nil {
+			conn.closed = true
+			return nil, err
+		}
+		conn.ws = ws
+	}
+	return conn.ws, nil
+}
+
+// ReadMessage : Reads the next message from the client
+func (conn *Conn) ReadMessage() (messageType int, data []byte, err error) {
+	ws, err := conn.upgrade()
+	if err != nil {
+		return
+	}
+	return ws.ReadMessage()
+}
+
+// SendJSON : Sends a JSON text message
+func (conn *Conn) SendJSON(message interface{}) error {
+	ws, err := conn.upgrade()
+	if err != nil {
+		return err
+	}
+	return ws.WriteJSON(message)
+}
+
+// SendBinary : Sends a binary message
+func (conn *Conn) SendBinary(data []byte) error {
+	ws, err := conn.upgrade()
+	if err != nil {
+		return err
+	}
+	return ws.WriteMessage(websocket.BinaryMessage, data)
+}
+
+// CloseWith : Closes the connection with the given close code and reason
+func (conn *Conn) CloseWith(code int, text string) error {
+	ws, err := conn.upgrade()
+	if err != nil {
+		return err
+	}
+	conn.closed = true
+	deadline := time.Now().Add(time.Second)
+	err = ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), deadline)
+	ws.Close()
+	return err
+}
+
+// Abort : Drops the connection without a close message, as a network failure would
+func (conn *Conn) Abort() error {
+	ws, err := conn.upgrade()
+	if err != nil {
+		return err
+	}
+	conn.closed = true
+	return ws.UnderlyingConn().Close()
+}
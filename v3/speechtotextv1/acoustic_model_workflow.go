@@ -0,0 +1,674 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/workerpool"
+)
+
+// Limits that the service enforces on the audio of a custom acoustic model
+const (
+	minAcousticModelAudio  = 10 * time.Minute
+	maxAcousticModelAudio  = 200 * time.Hour
+	maxWavChannels         = 9
+	maxContainedNameLength = 128
+)
+
+// AcousticModelSpec : The desired state of a custom acoustic model, for ApplyAcousticModelSpec
+type AcousticModelSpec struct {
+	// The name of the model. The model is identified by its name and base model.
+	Name string
+
+	// The name of the base model.
+	BaseModelName string
+
+	// The description of the model, used only when the model is created.
+	Description string
+
+	// The audio resources of the model, identified by name. A resource that exists is not uploaded again unless the
+	// service found it invalid; give a resource a new name to replace its content.
+	Audio []AudioSpec
+
+	// Deletes audio resources that the model has but the spec does not list.
+	Prune bool
+
+	// The ID of a custom language model to train and upgrade the model with. The custom language model must be
+	// available and based on the same version of the same base model.
+	CustomLanguageModelID string
+
+	// Upgrades the model to the latest version of its base model after training, when it is not available for the
+	// latest version. The latest version is the newest one that the custom language model or another custom acoustic
+	// model of the same base model is available for. A custom language model that the model is trained with must be
+	// upgraded first. Changed audio is a reason to train the model, not to upgrade it.
+	Upgrade bool
+}
+
+// AudioSpec : An audio resource of an AcousticModelSpec
+type AudioSpec struct {
+	// The name of the audio resource.
+	Name string
+
+	// The format of the resource, as for AddAudio. When empty, the format is detected from the content, see
+	// DetectAudioFormat.
+	ContentType string
+
+	// The format of the files of an archive-type resource, as for AddAudio. When empty, the format of every file is
+	// detected from its content.
+	ContainedContentType string
+
+	// Opens the content of the resource. It is called to validate the content and for every upload attempt.
+	Open func() (io.ReadCloser, error)
+}
+
+// AudioFromFile : Builds an AudioSpec that uploads the file at path, detecting its format
+func AudioFromFile(name string, path string) AudioSpec {
+	return AudioSpec{Name: name, Open: openFile(path)}
+}
+
+// InvalidAudio : An audio resource that failed validation
+type InvalidAudio struct {
+	// The name of the audio resource.
+	Name string
+
+	// Why the resource is invalid. For an archive, reasons that concern one of its files start with the name of the
+	// file.
+	Reasons []string
+}
+
+// InvalidAudioError : Audio resources that failed validation, either locally before any of them was uploaded or by
+// the service after they were uploaded
+type InvalidAudioError struct {
+	Resources []InvalidAudio
+}
+
+func (err *InvalidAudioError) Error() string {
+	var resources []string
+	for _, resource := range err.Resources {
+		resources = append(resources, fmt.Sprintf("%s (%s)", resource.Name, strings.Join(resource.Reasons, "; ")))
+	}
+	return fmt.Sprintf("invalid audio resources: %s", strings.Join(resources, ", "))
+}
+
+// AcousticModelChanges : What ApplyAcousticModelSpec changed
+type AcousticModelChanges struct {
+	// The model after the changes.
+	Model *AcousticModel
+
+	// Whether the model was created.
+	Created bool
+
+	AddedAudio []string
+
+	// Resources that the service had found invalid and that were uploaded again.
+	ReplacedAudio []string
+
+	DeletedAudio []string
+
+	// Whether the model was trained.
+	Trained bool
+
+	// The warnings the service returned for training.
+	TrainingWarnings []TrainingWarning
+
+	// Whether the model was upgraded.
+	Upgraded bool
+}
+
+// ApplyAcousticModelSpec : Brings a custom acoustic model to the state described by spec and trains it. The audio of
+// the spec is validated before anything is uploaded: its format, its sampling rate against the rate of the base
+// model, its size, and the total duration of audio the model would have against the 10 minutes to 200 hours that
+// training requires. The total duration is checked only as far as the durations can be read from the audio headers.
+//
+// The model is created if it does not exist; otherwise only missing resources are uploaded, concurrently, and the
+// model is trained only if it changed or has untrained data. The method waits for the service to process every
+// resource and reports the resources it found invalid as an *InvalidAudioError before training, and waits for training
+// and upgrade to finish.
+func (speechToText *SpeechToTextV1) ApplyAcousticModelSpec(ctx context.Context, spec *AcousticModelSpec, options *CustomizationWorkflowOptions) (*AcousticModelChanges, error) {
+	if err := core.ValidateNotNil(spec, "spec cannot be nil"); err != nil {
+		return nil, err
+	}
+	if spec.Name == "" || spec.BaseModelName == "" {
+		return nil, fmt.Errorf("the spec must name the model and its base model")
+	}
+	workflow := &acousticModelWorkflow{
+		service: speechToText,
+		spec:    spec,
+		options: options.withDefaults(),
+		changes: &AcousticModelChanges{},
+		audio:   make(map[string]validatedAudio),
+	}
+	if err := workflow.run(ctx); err != nil {
+		return workflow.changes, err
+	}
+	return workflow.changes, nil
+}
+
+type acousticModelWorkflow struct {
+	service         *SpeechToTextV1
+	spec            *AcousticModelSpec
+	options         CustomizationWorkflowOptions
+	changes         *AcousticModelChanges
+	customizationID string
+
+	// The newest version of the base model that another custom acoustic model is available for
+	latestVersion string
+
+	// The minimum sampling rate of the base model
+	sampleRate int64
+
+	// The audio of the spec, by name
+	audio map[string]validatedAudio
+}
+
+// validatedAudio : What local validation learned about an audio resource
+type validatedAudio struct {
+	// The content type to upload the resource with.
+	contentType string
+
+	// The duration of the audio, or zero when it could not be determined.
+	duration time.Duration
+}
+
+func (workflow *acousticModelWorkflow) run(ctx context.Context) error {
+	service, spec := workflow.service, workflow.spec
+	baseModel, _, err := service.GetModelWithContext(ctx, service.NewGetModelOptions(spec.BaseModelName))
+	if err != nil {
+		return err
+	}
+	if baseModel.Rate != nil {
+		workflow.sampleRate = *baseModel.Rate
+	}
+	if err = workflow.validate(); err != nil {
+		return err
+	}
+	if err = workflow.findOrCreate(ctx); err != nil {
+		return err
+	}
+	if _, err = workflow.waitForModel(ctx); err != nil {
+		return err
+	}
+	if err = workflow.syncAudio(ctx); err != nil {
+		return err
+	}
+
+	changes := workflow.changes
+	changed := len(changes.AddedAudio)+len(changes.ReplacedAudio)+len(changes.DeletedAudio) > 0
+	model, err := workflow.waitForModel(ctx)
+	if err != nil {
+		return err
+	}
+	status := core.StringNilMapper(model.Status)
+	if (changed || status == AcousticModelStatusReadyConst || status == AcousticModelStatusFailedConst) &&
+		status != AcousticModelStatusPendingConst {
+		if err = workflow.train(ctx); err != nil {
+			return err
+		}
+		if model, err = workflow.waitForModel(ctx); err != nil {
+			return err
+		}
+		if core.StringNilMapper(model.Status) == AcousticModelStatusFailedConst {
+			changes.Model = model
+			return fmt.Errorf("training of custom acoustic model %s failed: %s", workflow.customizationID,
+				core.StringNilMapper(model.Warnings))
+		}
+	}
+
+	upgrade := false
+	if spec.Upgrade {
+		if upgrade, err = workflow.behindBaseModel(ctx, model); err != nil {
+			changes.Model = model
+			return err
+		}
+	}
+	if upgrade {
+		upgradeOptions := service.NewUpgradeAcousticModelOptions(workflow.customizationID)
+		if spec.CustomLanguageModelID != "" {
+			upgradeOptions.SetCustomLanguageModelID(spec.CustomLanguageModelID)
+		}
+		err = workflow.options.retryBusy(ctx, func() (*core.DetailedResponse, error) {
+			return service.UpgradeAcousticModelWithContext(ctx, upgradeOptions)
+		})
+		if err != nil {
+			changes.Model = model
+			return err
+		}
+		changes.Upgraded = true
+		if model, err = workflow.waitForModel(ctx); err != nil {
+			return err
+		}
+	}
+	changes.Model = model
+	return nil
+}
+
+// validate : Checks every audio resource of the spec locally and reports all invalid ones together
+func (workflow *acousticModelWorkflow) validate() error {
+	invalid := &InvalidAudioError{}
+	for _, audio := range workflow.spec.Audio {
+		if audio.Name == "" {
+			return fmt.Errorf("an audio resource of the spec has no name")
+		}
+		if _, ok := workflow.audio[audio.Name]; ok {
+			return fmt.Errorf("the spec lists audio resource %q more than once", audio.Name)
+		}
+		validated, reasons, err := workflow.validateAudio(audio)
+		if err != nil {
+			return fmt.Errorf("audio resource %q: %s", audio.Name, err)
+		}
+		if len(reasons) > 0 {
+			invalid.Resources = append(invalid.Resources, InvalidAudio{Name: audio.Name, Reasons: reasons})
+		}
+		workflow.audio[audio.Name] = validated
+	}
+	if len(invalid.Resources) > 0 {
+		return invalid
+	}
+	return nil
+}
+
+// validateAudio : Reads an audio resource and returns the reasons it would be invalid. The error is for a resource
+// that cannot be read.
+func (workflow *acousticModelWorkflow) validateAudio(audio AudioSpec) (validated validatedAudio, reasons []string, err error) {
+	if audio.Open == nil {
+		return validated, []string{"the resource has no content"}, nil
+	}
+	file, err := audio.Open()
+	if err != nil {
+		return validated, nil, err
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(file, maxAudioResourceSize+1))
+	file.Close()
+	if err != nil {
+		return validated, nil, err
+	}
+	if len(content) > maxAudioResourceSize {
+		return validated, []string{"the resource is larger than 100 MB"}, nil
+	}
+
+	contentType := audio.ContentType
+	if format, err := detectAudioFormat(content); contentType == "" && err == nil && format != nil {
+		contentType = format.ContentType
+	}
+	mediaType := mediaTypeOf(contentType)
+	if mediaType != "application/zip" && mediaType != "application/gzip" {
+		if audio.ContainedContentType != "" {
+			reasons = append(reasons, "a contained content type applies only to archives")
+		}
+		info, err := inspectAudio(content, audio.ContentType)
+		if err != nil {
+			return validated, append(reasons, err.Error()), nil
+		}
+		validated = validatedAudio{contentType: contentType, duration: info.duration}
+		return validated, append(reasons, workflow.checkAudio(info)...), nil
+	}
+
+	entries, err := inspectArchive(content, mediaType, audio.ContainedContentType)
+	if err != nil {
+		return validated, []string{err.Error()}, nil
+	}
+	if len(entries) == 0 {
+		return validated, []string{"the archive contains no audio files"}, nil
+	}
+	validated.contentType = contentType
+	durationKnown := true
+	for _, entry := range entries {
+		if len(entry.name) > maxContainedNameLength {
+			reasons = append(reasons, fmt.Sprintf("%s: the name is longer than %d characters", entry.name,
+				maxContainedNameLength))
+		}
+		if entry.err != nil {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", entry.name, entry.err))
+			continue
+		}
+		for _, reason := range workflow.checkAudio(entry.info) {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", entry.name, reason))
+		}
+		validated.duration += entry.info.duration
+		durationKnown = durationKnown && entry.info.duration > 0
+	}
+	if !durationKnown {
+		validated.duration = 0
+	}
+	return validated, reasons, nil
+}
+
+// checkAudio : Returns the reasons the service would find audio invalid
+func (workflow *acousticModelWorkflow) checkAudio(info audioInfo) (reasons []string) {
+	if info.sampleRate > 0 && info.sampleRate < workflow.sampleRate {
+		reasons = append(reasons, fmt.Sprintf("the sampling rate of %d Hz is below the %d Hz of the base model",
+			info.sampleRate, workflow.sampleRate))
+	}
+	if info.mediaType == AddAudioOptionsContainedContentTypeAudioWavConst && info.channels > maxWavChannels {
+		reasons = append(reasons, fmt.Sprintf("WAV audio can have at most %d channels, not %d", maxWavChannels,
+			info.channels))
+	}
+	return
+}
+
+// findOrCreate : Looks the model up by name and base model, and creates it when there is none
+func (workflow *acousticModelWorkflow) findOrCreate(ctx context.Context) error {
+	service, spec := workflow.service, workflow.spec
+	listOptions := service.NewListAcousticModelsOptions()
+	if language := strings.SplitN(spec.BaseModelName, "_", 2)[0]; language != spec.BaseModelName {
+		listOptions.SetLanguage(language)
+	}
+	models, _, err := service.ListAcousticModelsWithContext(ctx, listOptions)
+	if err != nil {
+		return err
+	}
+	var matches []string
+	for _, model := range models.Customizations {
+		if core.StringNilMapper(model.BaseModelName) != spec.BaseModelName {
+			continue
+		}
+		if core.StringNilMapper(model.Name) == spec.Name {
+			matches = append(matches, core.StringNilMapper(model.CustomizationID))
+		} else {
+			workflow.latestVersion = newestVersion(workflow.latestVersion, model.Versions)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		workflow.customizationID = matches[0]
+		return nil
+	case 0:
+	default:
+		return fmt.Errorf("%d custom acoustic models are named %q: %s", len(matches), spec.Name, strings.Join(matches, ", "))
+	}
+
+	createOptions := service.NewCreateAcousticModelOptions(spec.Name, spec.BaseModelName)
+	if spec.Description != "" {
+		createOptions.SetDescription(spec.Description)
+	}
+	model, _, err := service.CreateAcousticModelWithContext(ctx, createOptions)
+	if err != nil {
+		return err
+	}
+	workflow.customizationID = core.StringNilMapper(model.CustomizationID)
+	workflow.changes.Created = true
+	return nil
+}
+
+// behindBaseModel : Reports whether the model is not available for the latest version of its base model that the
+// custom language model of the spec or another custom acoustic model is available for
+func (workflow *acousticModelWorkflow) behindBaseModel(ctx context.Context, model *AcousticModel) (bool, error) {
+	service, latest := workflow.service, workflow.latestVersion
+	if workflow.spec.CustomLanguageModelID != "" {
+		getOptions := service.NewGetLanguageModelOptions(workflow.spec.CustomLanguageModelID)
+		languageModel, _, err := service.GetLanguageModelWithContext(ctx, getOptions)
+		if err != nil {
+			return false, err
+		}
+		latest = newestVersion(latest, languageModel.Versions)
+	}
+	return latest != "" && newestVersion("", model.Versions) < latest, nil
+}
+
+// newestVersion : Returns the newest of a version and a list of versions of a base model. Versions of the same base
+// model, such as `en-US_BroadbandModel.v2020-01-16`, order by their date.
+func newestVersion(newest string, versions []string) string {
+	for _, version := range versions {
+		if version > newest {
+			newest = version
+		}
+	}
+	return newest
+}
+
+// waitForModel : Waits until the model is not training or upgrading and returns it
+func (workflow *acousticModelWorkflow) waitForModel(ctx context.Context) (*AcousticModel, error) {
+	getOptions := workflow.service.NewGetAcousticModelOptions(workflow.customizationID)
+	for {
+		model, _, err := workflow.service.GetAcousticModelWithContext(ctx, getOptions)
+		if err != nil {
+			return nil, err
+		}
+		switch core.StringNilMapper(model.Status) {
+		case AcousticModelStatusTrainingConst, AcousticModelStatusUpgradingConst:
+		default:
+			return model, nil
+		}
+		if err = workflow.options.pause(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (workflow *acousticModelWorkflow) syncAudio(ctx context.Context) error {
+	service, customizationID := workflow.service, workflow.customizationID
+	resources, _, err := service.ListAudioWithContext(ctx, service.NewListAudioOptions(customizationID))
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]AudioResource)
+	for _, resource := range resources.Audio {
+		existing[core.StringNilMapper(resource.Name)] = resource
+	}
+
+	// Resources that the service found invalid are uploaded again
+	var uploads []AudioSpec
+	replace := make(map[string]bool)
+	wanted := make(map[string]bool)
+	for _, audio := range workflow.spec.Audio {
+		wanted[audio.Name] = true
+		resource, ok := existing[audio.Name]
+		if ok && core.StringNilMapper(resource.Status) != AudioResourceStatusInvalidConst {
+			continue
+		}
+		uploads = append(uploads, audio)
+		replace[audio.Name] = ok
+	}
+
+	var total time.Duration
+	durationKnown := true
+	for name, resource := range existing {
+		if replace[name] || !wanted[name] && workflow.spec.Prune ||
+			core.StringNilMapper(resource.Status) == AudioResourceStatusInvalidConst {
+			continue
+		}
+		if resource.Duration == nil {
+			durationKnown = false
+			continue
+		}
+		total += time.Duration(*resource.Duration) * time.Second
+	}
+	for _, audio := range uploads {
+		duration := workflow.audio[audio.Name].duration
+		durationKnown = durationKnown && duration > 0
+		total += duration
+	}
+	if total > maxAcousticModelAudio {
+		return fmt.Errorf("the model would have %s of audio; training allows at most %s", total, maxAcousticModelAudio)
+	}
+	if durationKnown && total < minAcousticModelAudio {
+		return fmt.Errorf("the model would have %s of audio; training requires at least %s", total, minAcousticModelAudio)
+	}
+
+	if workflow.spec.Prune {
+		names := make(map[string]bool, len(existing))
+		for name := range existing {
+			names[name] = true
+		}
+		for _, name := range sortedKeys(names) {
+			if wanted[name] {
+				continue
+			}
+			err = workflow.options.retryBusy(ctx, func() (*core.DetailedResponse, error) {
+				return service.DeleteAudioWithContext(ctx, service.NewDeleteAudioOptions(customizationID, name))
+			})
+			if err != nil {
+				return err
+			}
+			workflow.changes.DeletedAudio = append(workflow.changes.DeletedAudio, name)
+		}
+	}
+
+	if err = workflow.upload(ctx, uploads, replace); err != nil {
+		return err
+	}
+	return workflow.waitForAudio(ctx)
+}
+
+// upload : Adds audio resources to the model with up to Workers requests at the same time. The first failure cancels
+// the uploads that have not finished.
+func (workflow *acousticModelWorkflow) upload(ctx context.Context, uploads []AudioSpec, replace map[string]bool) error {
+	service, customizationID := workflow.service, workflow.customizationID
+	pool := workerpool.New(ctx, workflow.options.Workers)
+	uploaded := make([]bool, len(uploads))
+	for i, audio := range uploads {
+		if !pool.Acquire() {
+			break
+		}
+		i, audio := i, audio
+		pool.Go(func(ctx context.Context) error {
+			err := workflow.options.retryBusy(ctx, func() (*core.DetailedResponse, error) {
+				file, err := audio.Open()
+				if err != nil {
+					return nil, err
+				}
+				defer file.Close()
+				addOptions := service.NewAddAudioOptions(customizationID, audio.Name, file).
+					SetContentType(workflow.audio[audio.Name].contentType)
+				if audio.ContainedContentType != "" {
+					addOptions.SetContainedContentType(audio.ContainedContentType)
+				}
+				if replace[audio.Name] {
+					addOptions.SetAllowOverwrite(true)
+				}
+				return service.AddAudioWithContext(ctx, addOptions)
+			})
+			if err != nil {
+				return fmt.Errorf("audio resource %q: %s", audio.Name, err)
+			}
+			uploaded[i] = true
+			return nil
+		})
+	}
+	err := pool.Wait()
+
+	for i, audio := range uploads {
+		switch {
+		case !uploaded[i]:
+		case replace[audio.Name]:
+			workflow.changes.ReplacedAudio = append(workflow.changes.ReplacedAudio, audio.Name)
+		default:
+			workflow.changes.AddedAudio = append(workflow.changes.AddedAudio, audio.Name)
+		}
+	}
+	return err
+}
+
+// waitForAudio : Waits until the service has processed every audio resource of the model and reports the invalid ones
+func (workflow *acousticModelWorkflow) waitForAudio(ctx context.Context) error {
+	service, customizationID := workflow.service, workflow.customizationID
+	for {
+		resources, _, err := service.ListAudioWithContext(ctx, service.NewListAudioOptions(customizationID))
+		if err != nil {
+			return err
+		}
+		processing := false
+		var invalid []string
+		for _, resource := range resources.Audio {
+			switch core.StringNilMapper(resource.Status) {
+			case AudioResourceStatusBeingProcessedConst:
+				processing = true
+			case AudioResourceStatusInvalidConst:
+				invalid = append(invalid, core.StringNilMapper(resource.Name))
+			}
+		}
+		if !processing {
+			if len(invalid) > 0 {
+				return workflow.invalidAudioError(ctx, invalid)
+			}
+			return nil
+		}
+		if err = workflow.options.pause(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// invalidAudioError : Retrieves the details of the resources that the service found invalid, down to the files of an
+// archive, to explain why
+func (workflow *acousticModelWorkflow) invalidAudioError(ctx context.Context, names []string) error {
+	service := workflow.service
+	sort.Strings(names)
+	invalid := &InvalidAudioError{}
+	for _, name := range names {
+		listing, _, err := service.GetAudioWithContext(ctx, service.NewGetAudioOptions(workflow.customizationID, name))
+		if err != nil {
+			return err
+		}
+		resource := InvalidAudio{Name: name}
+		if listing.Container == nil {
+			resource.Reasons = []string{workflow.invalidAudioReason(listing.Details)}
+		}
+		for _, file := range listing.Audio {
+			if core.StringNilMapper(file.Status) == AudioResourceStatusInvalidConst {
+				resource.Reasons = append(resource.Reasons, fmt.Sprintf("%s: %s", core.StringNilMapper(file.Name),
+					workflow.invalidAudioReason(file.Details)))
+			}
+		}
+		if len(resource.Reasons) == 0 {
+			resource.Reasons = []string{workflow.invalidAudioReason(nil)}
+		}
+		invalid.Resources = append(invalid.Resources, resource)
+	}
+	return invalid
+}
+
+func (workflow *acousticModelWorkflow) invalidAudioReason(details *AudioDetails) string {
+	switch {
+	case details == nil:
+	case core.StringNilMapper(details.Type) == AudioDetailsTypeUndeterminedConst:
+		return "the service could not determine the format of the audio"
+	case details.Frequency != nil && *details.Frequency < workflow.sampleRate:
+		return fmt.Sprintf("the sampling rate of %d Hz is below the %d Hz of the base model", *details.Frequency,
+			workflow.sampleRate)
+	}
+	return "the service could not process the audio"
+}
+
+func (workflow *acousticModelWorkflow) train(ctx context.Context) error {
+	service := workflow.service
+	trainOptions := service.NewTrainAcousticModelOptions(workflow.customizationID)
+	if workflow.spec.CustomLanguageModelID != "" {
+		trainOptions.SetCustomLanguageModelID(workflow.spec.CustomLanguageModelID)
+	}
+	var result *TrainingResponse
+	err := workflow.options.retryBusy(ctx, func() (response *core.DetailedResponse, err error) {
+		result, response, err = service.TrainAcousticModelWithContext(ctx, trainOptions)
+		return
+	})
+	if err != nil {
+		return err
+	}
+	workflow.changes.Trained = true
+	if result != nil {
+		workflow.changes.TrainingWarnings = result.Warnings
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/fakeservice"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+)
+
+// acousticAudio : An audio resource of the fake acoustic model service
+type acousticAudio struct {
+	status      string
+	contentType string
+	contained   string
+	frequency   int64
+}
+
+// acousticModelService emulates the customization interface for a single custom acoustic model on a broadband base
+// model. Audio is processed on the first listing after it is added; audio whose name starts with "low-rate" is found
+// invalid. Training and upgrade complete on the first check after they start. The custom language model "language1"
+// is available for the latest version of the base model.
+type acousticModelService struct {
+	*fakeservice.Server
+	created  bool
+	status   string
+	versions []string
+	latest   string
+	audio    map[string]*acousticAudio
+}
+
+func newAcousticModelService() *acousticModelService {
+	service := &acousticModelService{
+		Server: fakeservice.NewServer(),
+		audio:  map[string]*acousticAudio{},
+		latest: "en-US_BroadbandModel.v2020-01-16",
+	}
+	model := func() map[string]interface{} {
+		return map[string]interface{}{"customization_id": "acoustic1", "name": "call-center", "base_model_name": "en-US_BroadbandModel",
+			"status": service.status, "versions": service.versions}
+	}
+	resource := func(name string) map[string]interface{} {
+		audio := service.audio[name]
+		return map[string]interface{}{"name": name, "status": audio.status, "duration": 300,
+			"details": map[string]interface{}{"type": "audio", "frequency": audio.frequency}}
+	}
+
+	service.Handle(http.MethodGet, "/v1/models/en-US_BroadbandModel", func(call *fakeservice.Call) {
+		call.JSON(http.StatusOK, `{"name": "en-US_BroadbandModel", "language": "en-US", "rate": 16000, "url": "", "description": "", "supported_features": {"custom_language_model": true, "speaker_labels": true}}`)
+	})
+	service.Handle(http.MethodGet, "/v1/customizations/language1", func(call *fakeservice.Call) {
+		call.JSON(http.StatusOK, map[string]interface{}{"customization_id": "language1", "status": "available", "versions": []string{service.latest}})
+	})
+	service.Handle(http.MethodGet, "/v1/acoustic_customizations", func(call *fakeservice.Call) {
+		customizations := []interface{}{}
+		if service.created {
+			customizations = append(customizations, model())
+		}
+		call.JSON(http.StatusOK, map[string]interface{}{"customizations": customizations})
+	})
+	service.Handle(http.MethodPost, "/v1/acoustic_customizations", func(call *fakeservice.Call) {
+		service.created = true
+		service.status = "pending"
+		service.versions = []string{service.latest}
+		call.JSON(http.StatusCreated, model())
+	})
+	service.Handle(http.MethodGet, "/v1/acoustic_customizations/{id}", func(call *fakeservice.Call) {
+		switch service.status {
+		case "training":
+			service.status = "available"
+		case "upgrading":
+			service.status = "available"
+			if service.versions[len(service.versions)-1] != service.latest {
+				service.versions = append(service.versions, service.latest)
+			}
+		}
+		call.JSON(http.StatusOK, model())
+	})
+	service.Handle(http.MethodPost, "/v1/acoustic_customizations/{id}/train", func(call *fakeservice.Call) {
+		service.status = "training"
+		call.JSON(http.StatusOK, map[string]interface{}{})
+	})
+	service.Handle(http.MethodPost, "/v1/acoustic_customizations/{id}/upgrade_model", func(call *fakeservice.Call) {
+		service.status = "upgrading"
+		call.JSON(http.StatusOK, map[string]interface{}{})
+	})
+	service.Handle(http.MethodGet, "/v1/acoustic_customizations/{id}/audio", func(call *fakeservice.Call) {
+		list := []interface{}{}
+		for _, name := range service.sortedAudio() {
+			audio := service.audio[name]
+			if audio.status == "being_processed" {
+				audio.status = "ok"
+				if strings.HasPrefix(name, "low-rate") {
+					audio.status = "invalid"
+				}
+			}
+			list = append(list, resource(name))
+		}
+		call.JSON(http.StatusOK, map[string]interface{}{"total_minutes_of_audio": 5 * len(list), "audio": list})
+	})
+	// Uploads are slow enough to overlap
+	service.Handle(http.MethodPost, "/v1/acoustic_customizations/{id}/audio/{name}", func(call *fakeservice.Call) {
+		name := call.Params["name"]
+		_, _ = ioutil.ReadAll(call.Body)
+		if _, ok := service.audio[name]; ok && call.URL.Query().Get("allow_overwrite") != "true" {
+			call.Error(http.StatusBadRequest, "Audio resource already exists")
+			return
+		}
+		audio := &acousticAudio{status: "being_processed", frequency: 16000,
+			contentType: call.Header.Get("Content-Type"), contained: call.Header.Get("Contained-Content-Type")}
+		if strings.HasPrefix(name, "low-rate") {
+			audio.frequency = 8000
+		}
+		service.audio[name] = audio
+		service.status = "ready"
+		call.JSON(http.StatusCreated, map[string]interface{}{})
+	}).Delay(20 * time.Millisecond)
+	service.Handle(http.MethodGet, "/v1/acoustic_customizations/{id}/audio/{name}", func(call *fakeservice.Call) {
+		call.JSON(http.StatusOK, resource(call.Params["name"]))
+	})
+	service.Handle(http.MethodDelete, "/v1/acoustic_customizations/{id}/audio/{name}", func(call *fakeservice.Call) {
+		delete(service.audio, call.Params["name"])
+		call.JSON(http.StatusOK, map[string]interface{}{})
+	})
+	return service
+}
+
+func (service *acousticModelService) sortedAudio() []string {
+	var names []string
+	for name := range service.audio {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// wavAudio : Builds a WAV file of 16-bit PCM silence
+func wavAudio(sampleRate int, channels int, duration time.Duration) []byte {
+	blockAlign := channels * 2
+	dataSize := int(int64(sampleRate) * int64(duration) / int64(time.Second) * int64(blockAlign))
+	header := make([]byte, 44)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(36+dataSize))
+	copy(header[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16)
+	binary.LittleEndian.PutUint16(header[20:], 1)
+	binary.LittleEndian.PutUint16(header[22:], uint16(channels))
+	binary.LittleEndian.PutUint32(header[24:], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(header[28:], uint32(sampleRate*blockAlign))
+	binary.LittleEndian.PutUint16(header[32:], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(header[34:], 16)
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], uint32(dataSize))
+	return append(header, make([]byte, dataSize)...)
+}
+
+// flacAudio : Builds the header of a FLAC stream of 16-bit audio, which is all that validation reads
+func flacAudio(sampleRate int64, channels int64, duration time.Duration) []byte {
+	totalSamples := sampleRate * int64(duration) / int64(time.Second)
+	streamInfo := make([]byte, 34)
+	binary.BigEndian.PutUint16(streamInfo[0:], 4096)
+	binary.BigEndian.PutUint16(streamInfo[2:], 4096)
+	streamInfo[10] = byte(sampleRate >> 12)
+	streamInfo[11] = byte(sampleRate >> 4)
+	streamInfo[12] = byte(sampleRate&0x0f)<<4 | byte(channels-1)<<1 | byte(15>>4)
+	streamInfo[13] = byte(15&0x0f)<<4 | byte(totalSamples>>32&0x0f)
+	binary.BigEndian.PutUint32(streamInfo[14:], uint32(totalSamples))
+	return append([]byte("fLaC\x80\x00\x00\x22"), streamInfo...)
+}
+
+func zipArchive(names []string, files ...[]byte) []byte {
+	buffer := new(bytes.Buffer)
+	archive := zip.NewWriter(buffer)
+	for i, name := range names {
+		file, err := archive.Create(name)
+		Expect(err).To(BeNil())
+		_, err = file.Write(files[i])
+		Expect(err).To(BeNil())
+	}
+	Expect(archive.Close()).To(Succeed())
+	return buffer.Bytes()
+}
+
+func bytesContent(content []byte) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(content)), nil
+	}
+}
+
+var _ = Describe(`ApplyAcousticModelSpec`, func() {
+	var service *acousticModelService
+	var speechToText *speechtotextv1.SpeechToTextV1
+	var spec *speechtotextv1.AcousticModelSpec
+	options := &speechtotextv1.CustomizationWorkflowOptions{PollInterval: time.Millisecond}
+	BeforeEach(func() {
+		service = newAcousticModelService()
+		var err error
+		speechToText, err = speechtotextv1.NewSpeechToTextV1(&speechtotextv1.SpeechToTextV1Options{
+			URL:           service.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		spec = &speechtotextv1.AcousticModelSpec{
+			Name:          "call-center",
+			BaseModelName: "en-US_BroadbandModel",
+			Audio: []speechtotextv1.AudioSpec{
+				{Name: "calls-1", Open: bytesContent(flacAudio(16000, 1, 6*time.Minute))},
+				{Name: "greeting", Open: bytesContent(wavAudio(22050, 2, 100*time.Millisecond))},
+				{Name: "calls-2", Open: bytesContent(zipArchive([]string{"a.flac", "b.flac"},
+					flacAudio(44100, 2, 2*time.Minute), flacAudio(16000, 1, 3*time.Minute)))},
+			},
+			CustomLanguageModelID: "language1",
+			Prune:                 true,
+		}
+	})
+	AfterEach(func() {
+		service.Close()
+	})
+
+	It(`Creates, populates and trains a new model`, func() {
+		spec.Upgrade = true
+		changes, err := speechToText.ApplyAcousticModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(changes.Created).To(BeTrue())
+		Expect(changes.AddedAudio).To(Equal([]string{"calls-1", "greeting", "calls-2"}))
+		Expect(changes.Trained).To(BeTrue())
+		Expect(changes.Upgraded).To(BeFalse())
+		Expect(*changes.Model.Status).To(Equal(speechtotextv1.AcousticModelStatusAvailableConst))
+
+		requests := service.Requests()
+		Expect(requests[0]).To(Equal("POST /v1/acoustic_customizations"))
+		Expect(requests[1:4]).To(ConsistOf(
+			"POST /v1/acoustic_customizations/acoustic1/audio/calls-1",
+			"POST /v1/acoustic_customizations/acoustic1/audio/greeting",
+			"POST /v1/acoustic_customizations/acoustic1/audio/calls-2",
+		))
+		Expect(requests[4:]).To(Equal([]string{
+			"POST /v1/acoustic_customizations/acoustic1/train?custom_language_model_id=language1",
+		}))
+		Expect(service.MaxInFlight()).To(BeNumerically(">", 1))
+		Expect(service.audio["calls-1"].contentType).To(Equal("audio/flac"))
+		Expect(service.audio["greeting"].contentType).To(Equal("audio/wav"))
+		Expect(service.audio["calls-2"].contentType).To(Equal("application/zip"))
+		Expect(service.audio["calls-2"].contained).To(BeEmpty())
+	})
+
+	It(`Does nothing when the model matches the spec`, func() {
+		spec.Upgrade = true
+		_, err := speechToText.ApplyAcousticModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		service.ClearRequests()
+
+		changes, err := speechToText.ApplyAcousticModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(changes.Created).To(BeFalse())
+		Expect(changes.Trained).To(BeFalse())
+		Expect(changes.Upgraded).To(BeFalse())
+		Expect(service.Requests()).To(BeEmpty())
+	})
+
+	It(`Upgrades a model that is behind its custom language model`, func() {
+		spec.Upgrade = true
+		_, err := speechToText.ApplyAcousticModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		service.ClearRequests()
+
+		service.latest = "en-US_BroadbandModel.v2024-05-01"
+		changes, err := speechToText.ApplyAcousticModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(changes.Trained).To(BeFalse())
+		Expect(changes.Upgraded).To(BeTrue())
+		Expect(changes.Model.Versions).To(Equal([]string{"en-US_BroadbandModel.v2020-01-16", "en-US_BroadbandModel.v2024-05-01"}))
+		Expect(service.Requests()).To(Equal([]string{"POST /v1/acoustic_customizations/acoustic1/upgrade_model?custom_language_model_id=language1"}))
+
+		service.ClearRequests()
+		changes, err = speechToText.ApplyAcousticModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(changes.Upgraded).To(BeFalse())
+		Expect(service.Requests()).To(BeEmpty())
+	})
+
+	It(`Deletes resources that the spec no longer lists`, func() {
+		_, err := speechToText.ApplyAcousticModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		service.ClearRequests()
+
+		spec.Audio = append(spec.Audio[:1], speechtotextv1.AudioSpec{
+			Name: "calls-3", Open: bytesContent(flacAudio(16000, 1, 5*time.Minute)),
+		})
+		changes, err := speechToText.ApplyAcousticModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(changes.DeletedAudio).To(Equal([]string{"calls-2", "greeting"}))
+		Expect(changes.AddedAudio).To(Equal([]string{"calls-3"}))
+		Expect(service.Requests()).To(Equal([]string{
+			"DELETE /v1/acoustic_customizations/acoustic1/audio/calls-2",
+			"DELETE /v1/acoustic_customizations/acoustic1/audio/greeting",
+			"POST /v1/acoustic_customizations/acoustic1/audio/calls-3",
+			"POST /v1/acoustic_customizations/acoustic1/train?custom_language_model_id=language1",
+		}))
+	})
+
+	It(`Validates the audio before changing the model`, func() {
+		spec.Audio = []speechtotextv1.AudioSpec{
+			{Name: "calls-1", Open: bytesContent(flacAudio(16000, 1, 20*time.Minute))},
+			{Name: "narrowband", Open: bytesContent(wavAudio(8000, 1, time.Second))},
+			{Name: "mislabeled", ContentType: "audio/flac", Open: bytesContent(wavAudio(16000, 1, time.Second))},
+			{Name: "unknown", Open: bytesContent([]byte("not audio"))},
+			{Name: "archive", Open: bytesContent(zipArchive([]string{"ok.flac", "low.flac"},
+				flacAudio(16000, 1, time.Minute), flacAudio(8000, 1, time.Minute)))},
+		}
+		_, err := speechToText.ApplyAcousticModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeAssignableToTypeOf(&speechtotextv1.InvalidAudioError{}))
+		Expect(err.(*speechtotextv1.InvalidAudioError).Resources).To(Equal([]speechtotextv1.InvalidAudio{
+			{Name: "narrowband", Reasons: []string{"the sampling rate of 8000 Hz is below the 16000 Hz of the base model"}},
+			{Name: "mislabeled", Reasons: []string{"unsupported audio: the content type is audio/flac but the audio is audio/wav"}},
+			{Name: "unknown", Reasons: []string{"the format of the audio could not be determined; set its content type"}},
+			{Name: "archive", Reasons: []string{"low.flac: the sampling rate of 8000 Hz is below the 16000 Hz of the base model"}},
+		}))
+		Expect(service.Requests()).To(BeEmpty())
+	})
+
+	It(`Requires enough audio to train`, func() {
+		spec.Audio = spec.Audio[1:]
+		_, err := speechToText.ApplyAcousticModelSpec(context.Background(), spec, options)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("the model would have 5m0.1s of audio; training requires at least 10m0s"))
+		Expect(service.Requests()).To(Equal([]string{"POST /v1/acoustic_customizations"}))
+	})
+
+	It(`Reports the audio that the service finds invalid and uploads it again`, func() {
+		// The header claims a rate that the audio does not have
+		spec.Audio = append(spec.Audio, speechtotextv1.AudioSpec{
+			Name: "low-rate", Open: bytesContent(flacAudio(16000, 1, time.Minute)),
+		})
+		changes, err := speechToText.ApplyAcousticModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeAssignableToTypeOf(&speechtotextv1.InvalidAudioError{}))
+		Expect(err.Error()).To(Equal("invalid audio resources: low-rate (the sampling rate of 8000 Hz is below the 16000 Hz of the base model)"))
+		Expect(changes.Trained).To(BeFalse())
+
+		service.ClearRequests()
+		changes, err = speechToText.ApplyAcousticModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeAssignableToTypeOf(&speechtotextv1.InvalidAudioError{}))
+		Expect(changes.ReplacedAudio).To(Equal([]string{"low-rate"}))
+		Expect(service.Requests()).To(Equal([]string{"POST /v1/acoustic_customizations/acoustic1/audio/low-rate?allow_overwrite=true"}))
+	})
+})
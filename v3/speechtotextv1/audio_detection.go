@@ -0,0 +1,386 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// audioHeaderSize is the number of bytes that DetectAudioFormat reads to detect a format.
+const audioHeaderSize = 4096
+
+// DetectedAudioFormat : The format of audio as detected from its header
+type DetectedAudioFormat struct {
+	// The content type to send the audio with, for example `audio/wav` or `audio/ogg;codecs=opus`. The sampling rate
+	// and channels of these formats are in their headers, so the content type has no parameters for them.
+	ContentType string
+
+	// The sampling rate in Hertz, or 0 if the header does not give it.
+	Rate int
+
+	// The number of channels, or 0 if the header does not give it.
+	Channels int
+}
+
+// UnsupportedAudioError : Audio that the service would reject, as detected from its header
+type UnsupportedAudioError struct {
+	// The content type that was given for the audio, if any.
+	ContentType string
+
+	// The content type detected from the header, if the format was recognized.
+	Detected string
+
+	// Why the audio is rejected.
+	Reason string
+}
+
+func (e *UnsupportedAudioError) Error() string {
+	return fmt.Sprintf("unsupported audio: %s", e.Reason)
+}
+
+// DetectAudioFormat : Detects the format of audio from its first bytes. WAV, FLAC, Ogg, MP3 and WebM audio, and the
+// zip and gzip archives that AddAudio accepts, are recognized; the format is nil for anything else, such as raw
+// `audio/l16` that has no header. An *UnsupportedAudioError is returned for a recognized format that the service does
+// not accept.
+//
+// The bytes that are read are not lost: the returned reader yields the complete audio and closes the original one,
+// so it must be used in place of audio. The reader is returned even when there is an error. Detection waits until
+// 4 KB of audio or all of it can be read.
+func DetectAudioFormat(audio io.ReadCloser) (format *DetectedAudioFormat, replay io.ReadCloser, err error) {
+	header := make([]byte, audioHeaderSize)
+	n, err := io.ReadFull(audio, header)
+	header = header[:n]
+	replay = &replayReader{Reader: io.MultiReader(bytes.NewReader(header), audio), Closer: audio}
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, replay, err
+	}
+	format, err = detectAudioFormat(header)
+	return format, replay, err
+}
+
+// replayReader : Reads the bytes that were peeked from a reader and then the rest of it
+type replayReader struct {
+	io.Reader
+	io.Closer
+}
+
+// detectAudioFormat : Detects the format of audio from its header
+func detectAudioFormat(header []byte) (*DetectedAudioFormat, error) {
+	unsupported := func(detected string, reason string) error {
+		return &UnsupportedAudioError{Detected: detected, Reason: reason}
+	}
+	switch {
+	case len(header) >= 12 && string(header[0:4]) == "RIFF" && string(header[8:12]) == "WAVE":
+		return detectWav(header)
+	case len(header) >= 12 && string(header[0:4]) == "RIFF":
+		return nil, unsupported("", fmt.Sprintf("RIFF %q content is not audio that the service accepts", header[8:12]))
+	case bytes.HasPrefix(header, []byte("fLaC")):
+		format := &DetectedAudioFormat{ContentType: AddAudioOptionsContainedContentTypeAudioFlacConst}
+		if len(header) >= 8+18 && header[4]&0x7f == 0 {
+			streamInfo := header[8:]
+			format.Rate = int(streamInfo[10])<<12 | int(streamInfo[11])<<4 | int(streamInfo[12])>>4
+			format.Channels = int(streamInfo[12]>>1&0x07) + 1
+		}
+		return format, nil
+	case bytes.HasPrefix(header, []byte("OggS")):
+		return detectOgg(header)
+	case bytes.HasPrefix(header, []byte("\x1a\x45\xdf\xa3")):
+		return detectWebm(header)
+	case bytes.HasPrefix(header, []byte("ID3")):
+		return detectMp3(header)
+	case len(header) >= 7 && header[0] == 0xff && header[1]&0xf6 == 0xf0:
+		// AAC in ADTS frames, recognized only when the next frame follows
+		frameLength := int(header[3]&0x03)<<11 | int(header[4])<<3 | int(header[5])>>5
+		if len(header) > frameLength+1 && header[frameLength] == 0xff && header[frameLength+1]&0xf6 == 0xf0 {
+			return nil, unsupported("", "AAC audio is not supported")
+		}
+	case len(header) >= 2 && header[0] == 0xff && header[1]&0xe0 == 0xe0:
+		return detectMp3(header)
+	case len(header) >= 8 && string(header[4:8]) == "ftyp":
+		return nil, unsupported("", "MP4 and M4A audio is not supported")
+	case len(header) >= 12 && string(header[0:4]) == "FORM" && (string(header[8:12]) == "AIFF" || string(header[8:12]) == "AIFC"):
+		return nil, unsupported("", "AIFF audio is not supported")
+	case bytes.HasPrefix(header, []byte("#!AMR")):
+		return nil, unsupported("", "AMR audio is not supported")
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")), bytes.HasPrefix(header, []byte("PK\x05\x06")):
+		return &DetectedAudioFormat{ContentType: "application/zip"}, nil
+	case bytes.HasPrefix(header, []byte("\x1f\x8b\x08")):
+		return &DetectedAudioFormat{ContentType: "application/gzip"}, nil
+	}
+	return nil, nil
+}
+
+// detectWav : Reads the fmt chunk of a WAV header, if it is within the header
+func detectWav(header []byte) (*DetectedAudioFormat, error) {
+	format := &DetectedAudioFormat{ContentType: AddAudioOptionsContainedContentTypeAudioWavConst}
+	for offset := 12; offset+8 <= len(header); {
+		size := int(binary.LittleEndian.Uint32(header[offset+4 : offset+8]))
+		if string(header[offset:offset+4]) != "fmt " {
+			offset += 8 + size + size%2
+			continue
+		}
+		if offset+8+16 > len(header) {
+			break
+		}
+		fmtChunk := header[offset+8:]
+		format.Channels = int(binary.LittleEndian.Uint16(fmtChunk[2:]))
+		format.Rate = int(binary.LittleEndian.Uint32(fmtChunk[4:]))
+		switch encoding := binary.LittleEndian.Uint16(fmtChunk[0:]); encoding {
+		case 0x0001, 0x0003, 0x0006, 0x0007, 0xfffe:
+			// PCM, IEEE float, A-law, mu-law and the extensible format
+		default:
+			return nil, &UnsupportedAudioError{Detected: format.ContentType,
+				Reason: fmt.Sprintf("WAV audio with encoding 0x%04x is not supported", encoding)}
+		}
+		if format.Channels > 9 {
+			return nil, &UnsupportedAudioError{Detected: format.ContentType,
+				Reason: fmt.Sprintf("WAV audio has %d channels; the service accepts at most 9", format.Channels)}
+		}
+		break
+	}
+	return format, nil
+}
+
+// detectOgg : Identifies the codec of an Ogg stream from the first packet of its first page
+func detectOgg(header []byte) (*DetectedAudioFormat, error) {
+	format := &DetectedAudioFormat{ContentType: AddAudioOptionsContainedContentTypeAudioOggConst}
+	if len(header) < 27 || len(header) < 27+int(header[26]) {
+		return format, nil
+	}
+	packet := header[27+int(header[26]):]
+	unsupported := func(reason string) error {
+		return &UnsupportedAudioError{Detected: format.ContentType, Reason: reason}
+	}
+	switch {
+	case bytes.HasPrefix(packet, []byte("OpusHead")) && len(packet) >= 16:
+		format.ContentType = AddAudioOptionsContainedContentTypeAudioOggCodecsOpusConst
+		format.Channels = int(packet[9])
+		// Opus is always decoded at 48 kHz; the header gives the rate of the original audio
+		format.Rate = int(binary.LittleEndian.Uint32(packet[12:]))
+		if format.Rate == 0 {
+			format.Rate = 48000
+		}
+	case bytes.HasPrefix(packet, []byte("\x01vorbis")) && len(packet) >= 16:
+		format.ContentType = AddAudioOptionsContainedContentTypeAudioOggCodecsVorbisConst
+		format.Channels = int(packet[11])
+		format.Rate = int(binary.LittleEndian.Uint32(packet[12:]))
+	case bytes.HasPrefix(packet, []byte("\x7fFLAC")):
+		return nil, unsupported("FLAC audio in an Ogg container is not supported; send it as audio/flac")
+	case bytes.HasPrefix(packet, []byte("Speex   ")):
+		return nil, unsupported("Speex audio is not supported")
+	case bytes.HasPrefix(packet, []byte("\x80theora")):
+		return nil, unsupported("Ogg video is not supported")
+	}
+	return format, nil
+}
+
+// detectWebm : Identifies WebM audio and its codec from the EBML header and the track entries that follow it
+func detectWebm(header []byte) (*DetectedAudioFormat, error) {
+	format := &DetectedAudioFormat{ContentType: AddAudioOptionsContainedContentTypeAudioWebmConst}
+	// The DocType element (0x4282) of the EBML header, with a size of 4 or 8
+	if bytes.Contains(header, []byte("\x42\x82\x88matroska")) {
+		return nil, &UnsupportedAudioError{Detected: "video/x-matroska", Reason: "Matroska content is not supported; only WebM is"}
+	}
+	// The CodecID element (0x86) of the audio track
+	switch {
+	case bytes.Contains(header, []byte("\x86\x86A_OPUS")):
+		format.ContentType = AddAudioOptionsContainedContentTypeAudioWebmCodecsOpusConst
+	case bytes.Contains(header, []byte("\x86\x88A_VORBIS")):
+		format.ContentType = AddAudioOptionsContainedContentTypeAudioWebmCodecsVorbisConst
+	}
+	return format, nil
+}
+
+// Bit rates in kbit/s of MPEG audio layer III, by bit rate index, for MPEG-1 and for MPEG-2 and 2.5
+var (
+	mpeg1BitRates = [15]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320}
+	mpeg2BitRates = [15]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160}
+)
+
+// detectMp3 : Reads the first MP3 frame header, after the ID3 tag if there is one. Without an ID3 tag, the frame must
+// be followed by another one, since raw audio can look like a frame header.
+func detectMp3(header []byte) (*DetectedAudioFormat, error) {
+	format := &DetectedAudioFormat{ContentType: AddAudioOptionsContainedContentTypeAudioMp3Const}
+	tagged := bytes.HasPrefix(header, []byte("ID3"))
+	offset := 0
+	if tagged {
+		if len(header) < 10 {
+			return format, nil
+		}
+		// The size of the tag is a big-endian number of 7-bit bytes, without the 10-byte header and optional footer
+		offset = 10 + (int(header[6])<<21 | int(header[7])<<14 | int(header[8])<<7 | int(header[9]))
+		if header[5]&0x10 != 0 {
+			offset += 10
+		}
+	}
+
+	rate, channels, frameLength := mp3FrameHeader(header, offset)
+	switch {
+	case frameLength == 0 && tagged:
+		return format, nil
+	case frameLength == 0:
+		return nil, nil
+	case !tagged:
+		if _, _, next := mp3FrameHeader(header, offset+frameLength); next == 0 {
+			return nil, nil
+		}
+	}
+	format.Rate, format.Channels = rate, channels
+	return format, nil
+}
+
+// mp3FrameHeader : Reads the MPEG audio layer III frame header at offset. The frame length is zero when there is no
+// valid header there.
+func mp3FrameHeader(header []byte, offset int) (rate int, channels int, frameLength int) {
+	if offset < 0 || offset+4 > len(header) {
+		return
+	}
+	frame := header[offset : offset+4]
+	// The frame sync, and layer III
+	if frame[0] != 0xff || frame[1]&0xe0 != 0xe0 || frame[1]&0x06 != 0x02 {
+		return
+	}
+	bitRateIndex, rateIndex := int(frame[2]>>4), int(frame[2]>>2&0x03)
+	if bitRateIndex == 0 || bitRateIndex == 15 || rateIndex == 3 {
+		return
+	}
+	rates := [3]int{44100, 48000, 32000}
+	padding := int(frame[2] >> 1 & 0x01)
+	switch frame[1] >> 3 & 0x03 {
+	case 3: // MPEG-1
+		rate = rates[rateIndex]
+		frameLength = 144000*mpeg1BitRates[bitRateIndex]/rate + padding
+	case 2: // MPEG-2
+		rate = rates[rateIndex] / 2
+		frameLength = 72000*mpeg2BitRates[bitRateIndex]/rate + padding
+	case 0: // MPEG-2.5
+		rate = rates[rateIndex] / 4
+		frameLength = 72000*mpeg2BitRates[bitRateIndex]/rate + padding
+	default:
+		return 0, 0, 0
+	}
+	channels = 2
+	if frame[3]>>6 == 3 {
+		channels = 1
+	}
+	return
+}
+
+// checkContentType : Reports audio whose detected format contradicts the content type given for it. A content type
+// is not checked against audio whose format was not detected.
+func checkContentType(contentType string, format *DetectedAudioFormat) error {
+	declared := mediaTypeOf(contentType)
+	if declared == "" || declared == "application/octet-stream" || format == nil {
+		return nil
+	}
+
+	detected := mediaTypeOf(format.ContentType)
+	switch declared {
+	case AddAudioOptionsContainedContentTypeAudioL16Const, AddAudioOptionsContainedContentTypeAudioMulawConst,
+		AddAudioOptionsContainedContentTypeAudioAlawConst, AddAudioOptionsContainedContentTypeAudioBasicConst:
+		// Raw audio has no header and can start with any bytes, so only formats with long signatures are reported
+		switch detected {
+		case AddAudioOptionsContainedContentTypeAudioWavConst, AddAudioOptionsContainedContentTypeAudioFlacConst,
+			AddAudioOptionsContainedContentTypeAudioOggConst, AddAudioOptionsContainedContentTypeAudioWebmConst:
+		default:
+			return nil
+		}
+	}
+	if declared == AddAudioOptionsContainedContentTypeAudioMpegConst {
+		declared = AddAudioOptionsContainedContentTypeAudioMp3Const
+	}
+	if declared == detected {
+		// A codec that is given must match too
+		if codec := contentTypeCodec(contentType); codec != "" && contentTypeCodec(format.ContentType) != "" &&
+			codec != contentTypeCodec(format.ContentType) {
+			return &UnsupportedAudioError{ContentType: contentType, Detected: format.ContentType,
+				Reason: fmt.Sprintf("the content type is %s but the audio is %s", contentType, format.ContentType)}
+		}
+		return nil
+	}
+	return &UnsupportedAudioError{ContentType: contentType, Detected: format.ContentType,
+		Reason: fmt.Sprintf("the content type is %s but the audio is %s", declared, detected)}
+}
+
+func contentTypeCodec(contentType string) string {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(params["codecs"])
+}
+
+// detectContentType : Detects the format of the audio of a request. The audio is replaced by a reader that replays
+// the detected header, the content type is inferred when none is given and checked otherwise, and audio that the
+// service would reject is reported before the request is sent. Archives are accepted only when archives is set.
+func detectContentType(audio io.ReadCloser, contentType *string, archives bool) (io.ReadCloser, *string, error) {
+	if audio == nil {
+		return audio, contentType, nil
+	}
+	format, audio, err := DetectAudioFormat(audio)
+	if err != nil {
+		if unsupported, ok := err.(*UnsupportedAudioError); ok {
+			unsupported.ContentType = core.StringNilMapper(contentType)
+		}
+		return audio, contentType, err
+	}
+	if format != nil && !archives {
+		if mediaType := mediaTypeOf(format.ContentType); mediaType == "application/zip" || mediaType == "application/gzip" {
+			return audio, contentType, &UnsupportedAudioError{ContentType: core.StringNilMapper(contentType),
+				Detected: format.ContentType, Reason: "archives can be added only to custom acoustic models"}
+		}
+	}
+	if contentType == nil || *contentType == "" {
+		if format != nil {
+			contentType = core.StringPtr(format.ContentType)
+		}
+		return audio, contentType, nil
+	}
+	return audio, contentType, checkContentType(*contentType, format)
+}
+
+// DetectContentType : Detects the format of the audio before the request is sent. The content type is inferred when
+// none is set and checked against the audio otherwise, and audio that the service would reject is reported as an
+// *UnsupportedAudioError. The audio is replaced by a reader that replays the detected header, see DetectAudioFormat.
+// The method applies to RecognizeUsingWebsocketOptions as well.
+func (recognizeOptions *RecognizeOptions) DetectContentType() (err error) {
+	recognizeOptions.Audio, recognizeOptions.ContentType, err = detectContentType(recognizeOptions.Audio,
+		recognizeOptions.ContentType, false)
+	return
+}
+
+// DetectContentType : Detects the format of the audio before the job is created; see RecognizeOptions.DetectContentType
+func (createJobOptions *CreateJobOptions) DetectContentType() (err error) {
+	createJobOptions.Audio, createJobOptions.ContentType, err = detectContentType(createJobOptions.Audio,
+		createJobOptions.ContentType, false)
+	return
+}
+
+// DetectContentType : Detects the format of the audio resource before it is added; see
+// RecognizeOptions.DetectContentType. Zip and gzip archives are accepted, and their content type is inferred.
+func (addAudioOptions *AddAudioOptions) DetectContentType() (err error) {
+	addAudioOptions.AudioResource, addAudioOptions.ContentType, err = detectContentType(addAudioOptions.AudioResource,
+		addAudioOptions.ContentType, true)
+	return
+}
@@ -0,0 +1,229 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1/sttest"
+)
+
+// oggPage : Builds the first page of an Ogg stream that carries one packet
+func oggPage(packet []byte) []byte {
+	page := append([]byte("OggS\x00\x02"), make([]byte, 20)...)
+	return append(append(page, 1, byte(len(packet))), packet...)
+}
+
+func opusHead(channels byte, rate uint32) []byte {
+	head := append([]byte("OpusHead\x01"), channels, 0x38, 0x01)
+	head = append(head, make([]byte, 4)...)
+	binary.LittleEndian.PutUint32(head[12:], rate)
+	return append(head, 0, 0, 0)
+}
+
+// mp3Frames : Builds two silent MPEG-1 layer III frames at 128 kbit/s and 44.1 kHz
+func mp3Frames() []byte {
+	frame := append([]byte{0xff, 0xfb, 0x90, 0x64}, make([]byte, 417-4)...)
+	return append(append([]byte(nil), frame...), frame...)
+}
+
+func wavWithEncoding(encoding uint16, channels uint16) []byte {
+	wav := wavAudio(16000, 1, 0)
+	binary.LittleEndian.PutUint16(wav[20:], encoding)
+	binary.LittleEndian.PutUint16(wav[22:], channels)
+	return wav
+}
+
+var _ = Describe(`DetectAudioFormat`, func() {
+	table.DescribeTable(`Detects the format and its parameters`,
+		func(audio []byte, contentType string, rate int, channels int) {
+			format, replay, err := speechtotextv1.DetectAudioFormat(ioutil.NopCloser(bytes.NewReader(audio)))
+			Expect(err).To(BeNil())
+			Expect(format).To(Equal(&speechtotextv1.DetectedAudioFormat{ContentType: contentType, Rate: rate, Channels: channels}))
+			replayed, err := ioutil.ReadAll(replay)
+			Expect(err).To(BeNil())
+			Expect(replayed).To(Equal(audio))
+		},
+		table.Entry("WAV", wavAudio(22050, 2, 0), "audio/wav", 22050, 2),
+		table.Entry("FLAC", flacAudio(16000, 1, 0), "audio/flac", 16000, 1),
+		table.Entry("Ogg Opus", oggPage(opusHead(2, 16000)), "audio/ogg;codecs=opus", 16000, 2),
+		table.Entry("Ogg Vorbis", oggPage(append([]byte("\x01vorbis\x00\x00\x00\x00\x01\x44\xac\x00\x00"), make([]byte, 14)...)), "audio/ogg;codecs=vorbis", 44100, 1),
+		table.Entry("Ogg with an unknown codec", oggPage([]byte("unknown codec")), "audio/ogg", 0, 0),
+		table.Entry("MP3", mp3Frames(), "audio/mp3", 44100, 2),
+		table.Entry("MP3 with an ID3 tag", append([]byte("ID3\x03\x00\x00\x00\x00\x00\x02\x00\x00\xff\xf3\x14\xc4"), make([]byte, 100)...), "audio/mp3", 24000, 1),
+		table.Entry("WebM Opus", []byte("\x1a\x45\xdf\xa3\x9f\x42\x82\x84webm\x18\x53\x80\x67\xae\x86\x86A_OPUS"), "audio/webm;codecs=opus", 0, 0),
+		table.Entry("WebM Vorbis", []byte("\x1a\x45\xdf\xa3\x9f\x42\x82\x84webm\x86\x88A_VORBIS"), "audio/webm;codecs=vorbis", 0, 0),
+		table.Entry("zip archive", zipArchive([]string{"a.flac"}, flacAudio(16000, 1, 0)), "application/zip", 0, 0),
+	)
+
+	It(`Leaves audio without a header undetected`, func() {
+		for _, audio := range [][]byte{
+			[]byte("This is a mock file."), {0xff, 0xff, 0xff, 0xfe, 0x00, 0x01}, {},
+			{0xff, 0xf1}, {0xff, 0xf1, 0x00}, {0xff, 0xf1, 0x00, 0x00, 0x00},
+		} {
+			format, replay, err := speechtotextv1.DetectAudioFormat(ioutil.NopCloser(bytes.NewReader(audio)))
+			Expect(err).To(BeNil())
+			Expect(format).To(BeNil())
+			replayed, _ := ioutil.ReadAll(replay)
+			Expect(replayed).To(Equal(audio))
+		}
+	})
+
+	table.DescribeTable(`Rejects formats that the service does not accept`,
+		func(audio []byte, reason string) {
+			_, replay, err := speechtotextv1.DetectAudioFormat(ioutil.NopCloser(bytes.NewReader(audio)))
+			Expect(err).To(BeAssignableToTypeOf(&speechtotextv1.UnsupportedAudioError{}))
+			Expect(err.(*speechtotextv1.UnsupportedAudioError).Reason).To(Equal(reason))
+			Expect(replay).ToNot(BeNil())
+		},
+		table.Entry("MP4", []byte("\x00\x00\x00\x20ftypM4A \x00\x00\x00\x00"), "MP4 and M4A audio is not supported"),
+		table.Entry("AIFF", []byte("FORM\x00\x00\x00\x00AIFFCOMM"), "AIFF audio is not supported"),
+		table.Entry("compressed WAV", wavWithEncoding(0x55, 1), "WAV audio with encoding 0x0055 is not supported"),
+		table.Entry("WAV with too many channels", wavWithEncoding(1, 12), "WAV audio has 12 channels; the service accepts at most 9"),
+		table.Entry("Ogg FLAC", oggPage([]byte("\x7fFLAC\x01\x00")), "FLAC audio in an Ogg container is not supported; send it as audio/flac"),
+		table.Entry("Matroska", []byte("\x1a\x45\xdf\xa3\xa3\x42\x82\x88matroska"), "Matroska content is not supported; only WebM is"),
+	)
+})
+
+var _ = Describe(`Content type detection for requests`, func() {
+	var contentTypes []string
+	var testServer *httptest.Server
+	var speechToText *speechtotextv1.SpeechToTextV1
+	BeforeEach(func() {
+		contentTypes = nil
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			contentTypes = append(contentTypes, req.Header.Get("Content-Type"))
+			body, _ := ioutil.ReadAll(req.Body)
+			Expect(body).ToNot(BeEmpty())
+			res.Header().Set("Content-type", "application/json")
+			res.WriteHeader(201)
+			_, _ = res.Write([]byte(`{"id": "job1", "status": "waiting", "results": []}`))
+		}))
+		var err error
+		speechToText, err = speechtotextv1.NewSpeechToTextV1(&speechtotextv1.SpeechToTextV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`Infers the content type of Recognize, CreateJob and AddAudio requests`, func() {
+		recognizeOptions := speechToText.NewRecognizeOptions(ioutil.NopCloser(bytes.NewReader(flacAudio(16000, 1, 0))))
+		Expect(recognizeOptions.DetectContentType()).To(Succeed())
+		Expect(*recognizeOptions.ContentType).To(Equal("audio/flac"))
+		_, _, err := speechToText.Recognize(recognizeOptions)
+		Expect(err).To(BeNil())
+
+		createJobOptions := speechToText.NewCreateJobOptions(ioutil.NopCloser(bytes.NewReader(oggPage(opusHead(1, 48000)))))
+		Expect(createJobOptions.DetectContentType()).To(Succeed())
+		_, _, err = speechToText.CreateJob(createJobOptions)
+		Expect(err).To(BeNil())
+
+		addAudioOptions := speechToText.NewAddAudioOptions("acoustic1", "calls",
+			ioutil.NopCloser(bytes.NewReader(zipArchive([]string{"a.wav"}, wavAudio(16000, 1, 0)))))
+		Expect(addAudioOptions.DetectContentType()).To(Succeed())
+		_, err = speechToText.AddAudio(addAudioOptions)
+		Expect(err).To(BeNil())
+
+		Expect(contentTypes).To(Equal([]string{"audio/flac", "audio/ogg;codecs=opus", "application/zip"}))
+	})
+
+	It(`Detects nothing unless asked to`, func() {
+		recognizeOptions := speechToText.NewRecognizeOptions(ioutil.NopCloser(bytes.NewReader(wavAudio(16000, 1, 0)))).
+			SetContentType("audio/l16;rate=16000")
+		_, _, err := speechToText.Recognize(recognizeOptions)
+		Expect(err).To(BeNil())
+		Expect(contentTypes).To(Equal([]string{"audio/l16;rate=16000"}))
+	})
+
+	It(`Keeps a content type that agrees with the audio`, func() {
+		recognizeOptions := speechToText.NewRecognizeOptions(ioutil.NopCloser(bytes.NewReader(mp3Frames()))).
+			SetContentType("audio/mpeg")
+		Expect(recognizeOptions.DetectContentType()).To(Succeed())
+		_, _, err := speechToText.Recognize(recognizeOptions)
+		Expect(err).To(BeNil())
+		Expect(contentTypes).To(Equal([]string{"audio/mpeg"}))
+	})
+
+	It(`Rejects audio before sending it`, func() {
+		recognizeOptions := speechToText.NewRecognizeOptions(ioutil.NopCloser(bytes.NewReader(wavAudio(16000, 1, 0)))).
+			SetContentType("audio/l16;rate=16000")
+		err := recognizeOptions.DetectContentType()
+		Expect(err).To(Equal(&speechtotextv1.UnsupportedAudioError{ContentType: "audio/l16;rate=16000", Detected: "audio/wav",
+			Reason: "the content type is audio/l16 but the audio is audio/wav"}))
+
+		createJobOptions := speechToText.NewCreateJobOptions(ioutil.NopCloser(bytes.NewReader(zipArchive([]string{"a.wav"}, wavAudio(16000, 1, 0)))))
+		err = createJobOptions.DetectContentType()
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("unsupported audio: archives can be added only to custom acoustic models"))
+
+		createJobOptions = speechToText.NewCreateJobOptions(ioutil.NopCloser(bytes.NewReader(oggPage(opusHead(1, 48000))))).
+			SetContentType("audio/ogg;codecs=vorbis")
+		err = createJobOptions.DetectContentType()
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("unsupported audio: the content type is audio/ogg;codecs=vorbis but the audio is audio/ogg;codecs=opus"))
+		Expect(contentTypes).To(BeEmpty())
+	})
+
+	It(`Sends the detected content type in the websocket start message`, func() {
+		websocketServer := sttest.NewServer(func(conn *sttest.Conn) {
+			defer GinkgoRecover()
+			start, err := conn.ReadStart()
+			Expect(err).To(BeNil())
+			Expect(start["content-type"]).To(Equal("audio/wav"))
+			Expect(conn.SendListening()).To(Succeed())
+			audio, err := conn.ReadAudio()
+			Expect(err).To(BeNil())
+			Expect(audio).To(Equal(wavAudio(16000, 1, 0)))
+			Expect(conn.SendResults(sttest.FinalResult(0, "hello"))).To(Succeed())
+			Expect(conn.SendListening()).To(Succeed())
+		})
+		defer websocketServer.Close()
+		websocketService, err := speechtotextv1.NewSpeechToTextV1(&speechtotextv1.SpeechToTextV1Options{
+			URL:           websocketServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+
+		recognizeOptions := &speechtotextv1.RecognizeUsingWebsocketOptions{}
+		recognizeOptions.Audio = ioutil.NopCloser(bytes.NewReader(wavAudio(16000, 1, 0)))
+		Expect(recognizeOptions.DetectContentType()).To(Succeed())
+		results, errs := websocketService.RecognizeUsingWebsocketWithContext(context.Background(), recognizeOptions)
+		var transcripts []string
+		for result := range results {
+			transcripts = append(transcripts, *result.Results[0].Alternatives[0].Transcript)
+		}
+		Expect(<-errs).To(BeNil())
+		Expect(transcripts).To(Equal([]string{"hello"}))
+
+		recognizeOptions = websocketService.NewRecognizeUsingWebsocketOptions(ioutil.NopCloser(bytes.NewReader(wavAudio(16000, 1, 0))), "audio/flac")
+		Expect(recognizeOptions.DetectContentType()).To(BeAssignableToTypeOf(&speechtotextv1.UnsupportedAudioError{}))
+	})
+})
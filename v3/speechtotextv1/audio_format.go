@@ -0,0 +1,30 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import "github.com/watson-developer-cloud/go-sdk/v3/internal/rawaudio"
+
+// RawAudioFormat : The layout of uncompressed audio described by a content type such as `audio/l16;rate=16000`. It is
+// the same type as audioutil.RawFormat of the texttospeechv1 package.
+type RawAudioFormat = rawaudio.Format
+
+// ParseRawAudioFormat : Parses a raw audio content type (`audio/l16`, `audio/mulaw`, `audio/alaw` or `audio/basic`).
+// An error is returned for compressed or containerized formats, whose byte rate cannot be derived from the content
+// type alone.
+func ParseRawAudioFormat(contentType string) (RawAudioFormat, error) {
+	return rawaudio.Parse(contentType)
+}
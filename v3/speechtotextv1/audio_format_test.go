@@ -0,0 +1,58 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/rawaudio"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+)
+
+var _ = Describe(`ParseRawAudioFormat`, func() {
+	table.DescribeTable(`Parses raw content types`,
+		func(contentType string, expected speechtotextv1.RawAudioFormat) {
+			format, err := speechtotextv1.ParseRawAudioFormat(contentType)
+			Expect(err).To(BeNil())
+			Expect(format).To(Equal(expected))
+		},
+		table.Entry(`l16`, "audio/l16;rate=16000", speechtotextv1.RawAudioFormat{Encoding: rawaudio.EncodingPCM, Rate: 16000, Channels: 1, BitsPerSample: 16}),
+		table.Entry(`l16 with channels`, "audio/l16; rate=22050; channels=2", speechtotextv1.RawAudioFormat{Encoding: rawaudio.EncodingPCM, Rate: 22050, Channels: 2, BitsPerSample: 16}),
+		table.Entry(`mulaw`, "audio/mulaw;rate=8000", speechtotextv1.RawAudioFormat{Encoding: rawaudio.EncodingMuLaw, Rate: 8000, Channels: 1, BitsPerSample: 8}),
+		table.Entry(`basic`, "audio/basic", speechtotextv1.RawAudioFormat{Encoding: rawaudio.EncodingMuLaw, Rate: 8000, Channels: 1, BitsPerSample: 8}),
+	)
+	table.DescribeTable(`Rejects other content types`,
+		func(contentType string) {
+			_, err := speechtotextv1.ParseRawAudioFormat(contentType)
+			Expect(err).ToNot(BeNil())
+		},
+		table.Entry(`a compressed format`, "audio/ogg;codecs=opus"),
+		table.Entry(`a missing rate`, "audio/l16"),
+		table.Entry(`an invalid rate`, "audio/l16;rate=fast"),
+		table.Entry(`invalid channels`, "audio/l16;rate=16000;channels=0"),
+		table.Entry(`a malformed content type`, ";rate=16000"),
+	)
+	It(`Derives frame size, byte rate and duration`, func() {
+		format := speechtotextv1.RawAudioFormat{Rate: 16000, Channels: 2, BitsPerSample: 16}
+		Expect(format.FrameSize()).To(Equal(4))
+		Expect(format.ByteRate()).To(Equal(64000))
+		Expect(format.Duration(16000)).To(Equal(250 * time.Millisecond))
+	})
+})
@@ -0,0 +1,228 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"strings"
+	"time"
+)
+
+// maxAudioResourceSize is the largest audio resource, audio- or archive-type, that the service accepts.
+const maxAudioResourceSize = 100 * 1024 * 1024
+
+// audioInfo : What the header of an audio file tells about it
+type audioInfo struct {
+	// The media type of the format, without parameters.
+	mediaType string
+
+	// The sampling rate in Hertz, or zero when it cannot be determined.
+	sampleRate int64
+
+	// The number of channels, or zero when it cannot be determined.
+	channels int64
+
+	// The duration of the audio, or zero when it cannot be determined.
+	duration time.Duration
+}
+
+// archiveEntry : An audio file of an archive
+type archiveEntry struct {
+	name string
+	info audioInfo
+	err  error
+}
+
+// mediaTypeOf : Returns the media type of a content type without its parameters
+func mediaTypeOf(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	}
+	return mediaType
+}
+
+// inspectAudio : Reads the format, sampling rate and duration of audio from its header, or for raw formats from the
+// parameters of the content type. An empty content type means the format is detected; the content type must
+// otherwise agree with the content, see checkContentType.
+func inspectAudio(content []byte, contentType string) (audioInfo, error) {
+	format, err := detectAudioFormat(content)
+	if err != nil {
+		return audioInfo{}, err
+	}
+	if contentType == "" {
+		if format == nil {
+			return audioInfo{}, fmt.Errorf("the format of the audio could not be determined; set its content type")
+		}
+		contentType = format.ContentType
+	} else if err = checkContentType(contentType, format); err != nil {
+		return audioInfo{}, err
+	}
+
+	switch mediaType := mediaTypeOf(contentType); mediaType {
+	case AddAudioOptionsContainedContentTypeAudioWavConst:
+		return parseWavHeader(content)
+	case AddAudioOptionsContainedContentTypeAudioFlacConst:
+		return parseFlacHeader(content)
+	case AddAudioOptionsContainedContentTypeAudioL16Const, AddAudioOptionsContainedContentTypeAudioMulawConst,
+		AddAudioOptionsContainedContentTypeAudioAlawConst, AddAudioOptionsContainedContentTypeAudioBasicConst:
+		rawFormat, err := ParseRawAudioFormat(contentType)
+		if err != nil {
+			return audioInfo{mediaType: mediaType}, err
+		}
+		return audioInfo{
+			mediaType:  mediaType,
+			sampleRate: int64(rawFormat.Rate),
+			channels:   int64(rawFormat.Channels),
+			duration:   time.Duration(float64(len(content)) / float64(rawFormat.ByteRate()) * float64(time.Second)),
+		}, nil
+	default:
+		info := audioInfo{mediaType: mediaType}
+		if format != nil {
+			info.sampleRate, info.channels = int64(format.Rate), int64(format.Channels)
+		}
+		return info, nil
+	}
+}
+
+// parseWavHeader : Reads the fmt chunk of a WAV file and the length of its data chunk. A data chunk whose length is
+// unknown, as in a WAV file that was written while streaming, is taken to run to the end of the file.
+func parseWavHeader(content []byte) (audioInfo, error) {
+	info := audioInfo{mediaType: AddAudioOptionsContainedContentTypeAudioWavConst}
+	var byteRate int64
+	for offset := 12; offset+8 <= len(content); {
+		id := string(content[offset : offset+4])
+		size := int64(binary.LittleEndian.Uint32(content[offset+4 : offset+8]))
+		offset += 8
+		remaining := int64(len(content) - offset)
+		switch id {
+		case "fmt ":
+			if size < 16 || remaining < 16 {
+				return info, fmt.Errorf("the fmt chunk of the WAV header is truncated")
+			}
+			info.channels = int64(binary.LittleEndian.Uint16(content[offset+2:]))
+			info.sampleRate = int64(binary.LittleEndian.Uint32(content[offset+4:]))
+			byteRate = int64(binary.LittleEndian.Uint32(content[offset+8:]))
+		case "data":
+			if byteRate == 0 {
+				return info, fmt.Errorf("the WAV header has no fmt chunk before its data")
+			}
+			if size == 0 || size > remaining {
+				size = remaining
+			}
+			info.duration = time.Duration(float64(size) / float64(byteRate) * float64(time.Second))
+			return info, nil
+		}
+		if size > remaining {
+			break
+		}
+		// Chunks are padded to an even length
+		offset += int(size + size%2)
+	}
+	if byteRate == 0 {
+		return info, fmt.Errorf("the WAV header has no fmt chunk")
+	}
+	return info, fmt.Errorf("the WAV file has no data chunk")
+}
+
+// parseFlacHeader : Reads the STREAMINFO block that starts every FLAC stream
+func parseFlacHeader(content []byte) (audioInfo, error) {
+	info := audioInfo{mediaType: AddAudioOptionsContainedContentTypeAudioFlacConst}
+	if len(content) < 8+34 || content[4]&0x7f != 0 {
+		return info, fmt.Errorf("the FLAC stream has no STREAMINFO block")
+	}
+	streamInfo := content[8:]
+	info.sampleRate = int64(streamInfo[10])<<12 | int64(streamInfo[11])<<4 | int64(streamInfo[12])>>4
+	info.channels = int64(streamInfo[12]>>1&0x07) + 1
+	totalSamples := int64(streamInfo[13]&0x0f)<<32 | int64(binary.BigEndian.Uint32(streamInfo[14:18]))
+	if info.sampleRate == 0 {
+		return info, fmt.Errorf("the FLAC stream has an invalid sampling rate")
+	}
+	// The number of samples is zero when the encoder did not know it
+	info.duration = time.Duration(float64(totalSamples) / float64(info.sampleRate) * float64(time.Second))
+	return info, nil
+}
+
+// inspectArchive : Inspects every file of a zip or gzip-compressed tar archive. The files are inspected with the
+// contained content type, which is empty when they are to be detected.
+func inspectArchive(content []byte, mediaType string, containedContentType string) ([]archiveEntry, error) {
+	var entries []archiveEntry
+	inspect := func(name string, file io.Reader) error {
+		data, err := ioutil.ReadAll(io.LimitReader(file, maxAudioResourceSize+1))
+		if err != nil {
+			return err
+		}
+		entry := archiveEntry{name: name}
+		entry.info, entry.err = inspectAudio(data, containedContentType)
+		entries = append(entries, entry)
+		return nil
+	}
+
+	switch mediaType {
+	case "application/zip":
+		archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid zip archive: %s", err)
+		}
+		for _, file := range archive.File {
+			if file.FileInfo().IsDir() {
+				continue
+			}
+			reader, err := file.Open()
+			if err != nil {
+				return nil, fmt.Errorf("invalid zip archive: %s", err)
+			}
+			err = inspect(file.Name, reader)
+			reader.Close()
+			if err != nil {
+				return nil, fmt.Errorf("invalid zip archive: %s", err)
+			}
+		}
+	case "application/gzip":
+		uncompressed, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip archive: %s", err)
+		}
+		archive := tar.NewReader(uncompressed)
+		for {
+			header, err := archive.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid tar.gz archive: %s", err)
+			}
+			if header.Typeflag != tar.TypeReg {
+				continue
+			}
+			if err = inspect(header.Name, archive); err != nil {
+				return nil, fmt.Errorf("invalid tar.gz archive: %s", err)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%s is not an archive", mediaType)
+	}
+	return entries, nil
+}
@@ -0,0 +1,167 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Constants associated with the RecognizeUsingWebsocketOptions.Pacing property.
+// How quickly audio is sent over the websocket connection:
+// * `fixed_delay`: Chunks are separated by a 10 millisecond pause (the default).
+// * `none`: Chunks are sent as fast as the connection accepts them, for batch transcription of stored audio.
+// * `realtime`: Audio is sent at the rate it would be captured, computed from the rate, channels and sample size of a
+// raw audio content type (see ParseRawAudioFormat).
+// * `rate`: Audio is sent at the number of bytes per second given by the `PacingRate` option.
+const (
+	RecognizeUsingWebsocketOptionsPacingFixedDelayConst = "fixed_delay"
+	RecognizeUsingWebsocketOptionsPacingNoneConst       = "none"
+	RecognizeUsingWebsocketOptionsPacingRateConst       = "rate"
+	RecognizeUsingWebsocketOptionsPacingRealtimeConst   = "realtime"
+)
+
+// TokenBucket : A byte rate limiter that can be shared by many recognize connections so that together they do not
+// exceed the capacity of the link. Tokens are reserved in arrival order; a request larger than the burst size is
+// allowed and delays later requests accordingly.
+type TokenBucket struct {
+	rate  float64
+	burst float64
+
+	lock   sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket : Instantiate a TokenBucket that refills at bytesPerSecond and holds at most burst bytes
+func NewTokenBucket(bytesPerSecond int64, burst int64) (*TokenBucket, error) {
+	if bytesPerSecond <= 0 {
+		return nil, fmt.Errorf("bytesPerSecond must be positive")
+	}
+	if burst <= 0 {
+		return nil, fmt.Errorf("burst must be positive")
+	}
+	return &TokenBucket{
+		rate:   float64(bytesPerSecond),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}, nil
+}
+
+// Wait : Blocks until n bytes may be sent or the context is done. The bytes of a wait that the context ends are given
+// back, so that they do not delay the other users of the bucket.
+func (bucket *TokenBucket) Wait(ctx context.Context, n int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	bucket.lock.Lock()
+	now := time.Now()
+	bucket.tokens += now.Sub(bucket.last).Seconds() * bucket.rate
+	if bucket.tokens > bucket.burst {
+		bucket.tokens = bucket.burst
+	}
+	bucket.last = now
+	bucket.tokens -= float64(n)
+	delay := time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+	bucket.lock.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		bucket.lock.Lock()
+		bucket.tokens += float64(n)
+		bucket.lock.Unlock()
+		return ctx.Err()
+	}
+}
+
+// audioPacer : Spaces out the audio chunks of one connection according to the pacing options
+type audioPacer struct {
+	chunkSize int
+	delay     time.Duration
+	own       *TokenBucket
+	shared    *TokenBucket
+}
+
+// newAudioPacer : Validates the pacing options and builds the pacer for a connection
+func newAudioPacer(recognizeWSOptions *RecognizeUsingWebsocketOptions) (pacer *audioPacer, err error) {
+	pacer = &audioPacer{chunkSize: ONE_KB * 2, shared: recognizeWSOptions.RateLimiter}
+	if recognizeWSOptions.ChunkSize != nil {
+		if *recognizeWSOptions.ChunkSize <= 0 {
+			return nil, fmt.Errorf("chunk size must be positive")
+		}
+		pacer.chunkSize = int(*recognizeWSOptions.ChunkSize)
+	}
+
+	switch pacing := core.StringNilMapper(recognizeWSOptions.Pacing); pacing {
+	case "", RecognizeUsingWebsocketOptionsPacingFixedDelayConst:
+		pacer.delay = TEN_MILLISECONDS
+	case RecognizeUsingWebsocketOptionsPacingNoneConst:
+	case RecognizeUsingWebsocketOptionsPacingRealtimeConst:
+		format, formatErr := ParseRawAudioFormat(core.StringNilMapper(recognizeWSOptions.ContentType))
+		if formatErr != nil {
+			return nil, fmt.Errorf("realtime pacing requires a raw audio content type: %s", formatErr)
+		}
+		pacer.own, err = NewTokenBucket(int64(format.ByteRate()), int64(pacer.chunkSize))
+	case RecognizeUsingWebsocketOptionsPacingRateConst:
+		if recognizeWSOptions.PacingRate == nil {
+			return nil, fmt.Errorf("rate pacing requires a pacing rate")
+		}
+		pacer.own, err = NewTokenBucket(*recognizeWSOptions.PacingRate, int64(pacer.chunkSize))
+	default:
+		return nil, fmt.Errorf("unknown pacing %q", pacing)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pacer, nil
+}
+
+// wait : Blocks until a chunk of n bytes may be sent
+func (pacer *audioPacer) wait(ctx context.Context, n int) error {
+	if pacer.own != nil {
+		if err := pacer.own.Wait(ctx, n); err != nil {
+			return err
+		}
+	}
+	if pacer.shared != nil {
+		if err := pacer.shared.Wait(ctx, n); err != nil {
+			return err
+		}
+	}
+	if pacer.delay > 0 {
+		timer := time.NewTimer(pacer.delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
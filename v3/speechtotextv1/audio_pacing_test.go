@@ -0,0 +1,203 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1/sttest"
+)
+
+var _ = Describe(`TokenBucket`, func() {
+	It(`Validates the rate and burst`, func() {
+		_, err := speechtotextv1.NewTokenBucket(0, 100)
+		Expect(err).ToNot(BeNil())
+		_, err = speechtotextv1.NewTokenBucket(100, 0)
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Allows the burst at once and delays what exceeds it`, func() {
+		bucket, err := speechtotextv1.NewTokenBucket(10000, 1000)
+		Expect(err).To(BeNil())
+
+		started := time.Now()
+		Expect(bucket.Wait(context.Background(), 1000)).To(Succeed())
+		Expect(time.Since(started)).To(BeNumerically("<", 50*time.Millisecond))
+		Expect(bucket.Wait(context.Background(), 2000)).To(Succeed())
+		Expect(time.Since(started)).To(BeNumerically(">=", 190*time.Millisecond))
+	})
+	It(`Stops waiting when the context is done`, func() {
+		bucket, err := speechtotextv1.NewTokenBucket(100, 100)
+		Expect(err).To(BeNil())
+		Expect(bucket.Wait(context.Background(), 100)).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		started := time.Now()
+		Expect(bucket.Wait(ctx, 1000)).To(Equal(context.DeadlineExceeded))
+		Expect(time.Since(started)).To(BeNumerically("<", time.Second))
+	})
+	It(`Gives back the bytes of a wait that the context ends`, func() {
+		bucket, err := speechtotextv1.NewTokenBucket(1000, 100)
+		Expect(err).To(BeNil())
+		Expect(bucket.Wait(context.Background(), 100)).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		Expect(bucket.Wait(ctx, 1000)).To(Equal(context.DeadlineExceeded))
+		Expect(bucket.Wait(ctx, 1000)).To(Equal(context.DeadlineExceeded))
+
+		started := time.Now()
+		Expect(bucket.Wait(context.Background(), 100)).To(Succeed())
+		Expect(time.Since(started)).To(BeNumerically("<", 500*time.Millisecond))
+	})
+})
+
+var _ = Describe(`RecognizeUsingWebsocketWithContext pacing`, func() {
+	var testServer *sttest.Server
+	var speechToTextService *speechtotextv1.SpeechToTextV1
+
+	BeforeEach(func() {
+		testServer = sttest.NewTranscribingServer(sttest.FinalResult(0, "hello"))
+		speechToTextService = newWebsocketService(testServer)
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	newOptions := func(size int, contentType string) *speechtotextv1.RecognizeUsingWebsocketOptions {
+		return speechToTextService.NewRecognizeUsingWebsocketOptions(ioutil.NopCloser(bytes.NewReader(make([]byte, size))), contentType)
+	}
+	// recognize : Recognizes the audio of the options and returns how long it took
+	recognize := func(recognizeOptions *speechtotextv1.RecognizeUsingWebsocketOptions) time.Duration {
+		started := time.Now()
+		results, errs := speechToTextService.RecognizeUsingWebsocketWithContext(context.Background(), recognizeOptions)
+		for range results {
+		}
+		Expect(<-errs).To(BeNil())
+		return time.Since(started)
+	}
+
+	It(`Sends audio without delay with no pacing`, func() {
+		recognizeOptions := newOptions(64000, "audio/l16;rate=16000")
+		recognizeOptions.SetPacing(speechtotextv1.RecognizeUsingWebsocketOptionsPacingNoneConst)
+		recognizeOptions.SetChunkSize(1000)
+		Expect(recognize(recognizeOptions)).To(BeNumerically("<", 500*time.Millisecond))
+	})
+	It(`Waits a fixed delay before every chunk by default`, func() {
+		recognizeOptions := newOptions(20000, "audio/l16;rate=16000")
+		recognizeOptions.SetChunkSize(1000)
+		Expect(recognize(recognizeOptions)).To(BeNumerically(">=", 200*time.Millisecond))
+	})
+	It(`Sends at the pacing rate`, func() {
+		recognizeOptions := newOptions(10000, "audio/l16;rate=16000")
+		recognizeOptions.SetPacing(speechtotextv1.RecognizeUsingWebsocketOptionsPacingRateConst)
+		recognizeOptions.SetPacingRate(20000)
+		recognizeOptions.SetChunkSize(1000)
+		Expect(recognize(recognizeOptions)).To(BeNumerically(">=", 450*time.Millisecond))
+	})
+	It(`Sends raw audio in real time`, func() {
+		// 8 kHz 16-bit mono is 16000 bytes per second
+		recognizeOptions := newOptions(8000, "audio/l16;rate=8000")
+		recognizeOptions.SetPacing(speechtotextv1.RecognizeUsingWebsocketOptionsPacingRealtimeConst)
+		recognizeOptions.SetChunkSize(1000)
+		Expect(recognize(recognizeOptions)).To(BeNumerically(">=", 430*time.Millisecond))
+	})
+	It(`Shares a rate limiter between connections`, func() {
+		limiter, err := speechtotextv1.NewTokenBucket(20000, 1000)
+		Expect(err).To(BeNil())
+
+		started := time.Now()
+		var wait sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			recognizeOptions := newOptions(5000, "audio/l16;rate=16000")
+			recognizeOptions.SetPacing(speechtotextv1.RecognizeUsingWebsocketOptionsPacingNoneConst)
+			recognizeOptions.SetChunkSize(1000)
+			recognizeOptions.SetRateLimiter(limiter)
+			wait.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wait.Done()
+				recognize(recognizeOptions)
+			}()
+		}
+		wait.Wait()
+		// Together the connections sent 10000 bytes, of which the first 1000 are the burst
+		Expect(time.Since(started)).To(BeNumerically(">=", 450*time.Millisecond))
+		Expect(testServer.Connections()).To(Equal(2))
+	})
+	It(`Paces the audio that is replayed after reconnecting`, func() {
+		replayed := make(chan time.Duration, 1)
+		testServer.Close()
+		testServer = sttest.NewServer(func(conn *sttest.Conn) {
+			defer GinkgoRecover()
+			_, err := conn.ReadStart()
+			Expect(err).To(BeNil())
+			Expect(conn.SendListening()).To(Succeed())
+			if testServer.Connections() == 1 {
+				_, err = conn.ReadAudio()
+				Expect(err).To(BeNil())
+				Expect(conn.Abort()).To(Succeed())
+				return
+			}
+			started := time.Now()
+			audio, err := conn.ReadAudio()
+			Expect(err).To(BeNil())
+			Expect(audio).To(HaveLen(32000))
+			replayed <- time.Since(started)
+			Expect(conn.SendListening()).To(Succeed())
+		})
+		speechToTextService = newWebsocketService(testServer)
+
+		recognizeOptions := newOptions(32000, "audio/l16;rate=16000")
+		recognizeOptions.SetPacing(speechtotextv1.RecognizeUsingWebsocketOptionsPacingRateConst)
+		recognizeOptions.SetPacingRate(64000)
+		recognizeOptions.SetReconnect(&speechtotextv1.ReconnectOptions{InitialBackoff: time.Millisecond})
+		recognize(recognizeOptions)
+		Expect(testServer.Connections()).To(Equal(2))
+		// The replay of 32000 bytes at 64000 bytes per second, less the burst of one chunk
+		Expect(<-replayed).To(BeNumerically(">=", 400*time.Millisecond))
+	})
+	It(`Rejects invalid pacing options`, func() {
+		recognizeOptions := newOptions(1000, "audio/l16;rate=16000")
+		recognizeOptions.SetPacing("warp")
+		_, errs := speechToTextService.RecognizeUsingWebsocketWithContext(context.Background(), recognizeOptions)
+		Expect(<-errs).ToNot(BeNil())
+
+		recognizeOptions = newOptions(1000, "audio/l16;rate=16000")
+		recognizeOptions.SetPacing(speechtotextv1.RecognizeUsingWebsocketOptionsPacingRateConst)
+		_, errs = speechToTextService.RecognizeUsingWebsocketWithContext(context.Background(), recognizeOptions)
+		Expect(<-errs).ToNot(BeNil())
+
+		recognizeOptions = newOptions(1000, "audio/flac")
+		recognizeOptions.SetPacing(speechtotextv1.RecognizeUsingWebsocketOptionsPacingRealtimeConst)
+		_, errs = speechToTextService.RecognizeUsingWebsocketWithContext(context.Background(), recognizeOptions)
+		Expect(<-errs).ToNot(BeNil())
+
+		recognizeOptions = newOptions(1000, "audio/l16;rate=16000")
+		recognizeOptions.SetChunkSize(0)
+		_, errs = speechToTextService.RecognizeUsingWebsocketWithContext(context.Background(), recognizeOptions)
+		Expect(<-errs).ToNot(BeNil())
+		Expect(testServer.Connections()).To(Equal(0))
+	})
+})
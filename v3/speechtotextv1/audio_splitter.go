@@ -0,0 +1,670 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"mime"
+	"strings"
+
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/rawaudio"
+)
+
+// audioPiece : A part of the audio that can be recognized on its own
+type audioPiece struct {
+	// The position of the piece in the audio, from zero.
+	index int
+
+	// The complete content of the piece, including a header for formats that have one.
+	content []byte
+
+	contentType string
+
+	// The time the piece starts at in the audio, in seconds.
+	start float64
+}
+
+// audioSplitter : Cuts audio into pieces, reading it as the pieces are needed
+type audioSplitter interface {
+	// next : Returns the next piece, or io.EOF after the last one
+	next() (*audioPiece, error)
+}
+
+// newAudioSplitter : Creates the splitter for the format of the audio, which is detected when no content type is
+// given
+func newAudioSplitter(audio io.ReadCloser, contentType string, settings RecognizeLargeOptions) (audioSplitter, error) {
+	format, audio, err := DetectAudioFormat(audio)
+	if err != nil {
+		return nil, err
+	}
+	if contentType == "" {
+		if format == nil {
+			return nil, fmt.Errorf("the format of the audio could not be detected; set its content type")
+		}
+		contentType = format.ContentType
+	} else if err = checkContentType(contentType, format); err != nil {
+		return nil, err
+	}
+
+	switch mediaType := mediaTypeOf(contentType); mediaType {
+	case AddAudioOptionsContainedContentTypeAudioWavConst:
+		return newWavSplitter(audio, settings)
+	case AddAudioOptionsContainedContentTypeAudioFlacConst:
+		return newFlacSplitter(audio, settings)
+	case AddAudioOptionsContainedContentTypeAudioL16Const, AddAudioOptionsContainedContentTypeAudioMulawConst,
+		AddAudioOptionsContainedContentTypeAudioAlawConst, AddAudioOptionsContainedContentTypeAudioBasicConst:
+		rawFormat, err := ParseRawAudioFormat(contentType)
+		if err != nil {
+			return nil, err
+		}
+		_, params, _ := mime.ParseMediaType(contentType)
+		return &pcmSplitter{
+			reader:      audio,
+			format:      rawFormat,
+			linear16:    mediaType == AddAudioOptionsContainedContentTypeAudioL16Const,
+			bigEndian:   strings.EqualFold(params["endianness"], "big-endian"),
+			contentType: contentType,
+			settings:    settings,
+		}, nil
+	default:
+		return nil, fmt.Errorf("audio in the %s format cannot be split; use WAV, FLAC or raw audio", mediaType)
+	}
+}
+
+// finalPieceMerge is the duration in seconds below which the end of the audio is added to the last piece rather than
+// sent as a piece of its own, which the service might reject as too short. The last piece can exceed the limits by as
+// much.
+const finalPieceMerge = 1.0
+
+// pcmSplitter : Splits raw audio, and the data of WAV files, at the quietest point before each piece would exceed the
+// limits. Silence is detected only in 16-bit linear audio; other encodings are cut at the limits.
+type pcmSplitter struct {
+	reader      io.Reader
+	format      RawAudioFormat
+	linear16    bool
+	bigEndian   bool
+	contentType string
+	settings    RecognizeLargeOptions
+
+	// Builds the header of a piece with the given data size, for formats that have one
+	header func(dataSize int) []byte
+
+	buffer []byte
+	eof    bool
+	frames int64
+	pieces int
+}
+
+func (splitter *pcmSplitter) next() (*audioPiece, error) {
+	frameSize := splitter.format.FrameSize()
+	headerSize := 0
+	if splitter.header != nil {
+		headerSize = len(splitter.header(0))
+	}
+	maxFrames := (splitter.settings.MaxPieceSize - int64(headerSize)) / int64(frameSize)
+	if durationFrames := int64(splitter.settings.MaxPieceDuration.Seconds() * float64(splitter.format.Rate)); durationFrames < maxFrames {
+		maxFrames = durationFrames
+	}
+	if maxFrames < 1 {
+		maxFrames = 1
+	}
+	maxBytes := int(maxFrames) * frameSize
+	mergeBytes := int(finalPieceMerge*float64(splitter.format.Rate)) * frameSize
+
+	chunk := make([]byte, 64*1024)
+	for !splitter.eof && len(splitter.buffer) <= maxBytes+mergeBytes {
+		n, err := io.ReadFull(splitter.reader, chunk)
+		splitter.buffer = append(splitter.buffer, chunk[:n]...)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			splitter.eof = true
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	if len(splitter.buffer) == 0 {
+		return nil, io.EOF
+	}
+
+	size := len(splitter.buffer)
+	if size > maxBytes {
+		size = splitter.quietestCut(splitter.buffer[:maxBytes])
+		if len(splitter.buffer)-size < mergeBytes {
+			// The end of the audio is too short for a piece of its own
+			size = len(splitter.buffer)
+		}
+	}
+	data := splitter.buffer[:size]
+	splitter.buffer = append([]byte(nil), splitter.buffer[size:]...)
+	piece := &audioPiece{
+		index:       splitter.pieces,
+		contentType: splitter.contentType,
+		start:       float64(splitter.frames) / float64(splitter.format.Rate),
+		content:     data,
+	}
+	if splitter.header != nil {
+		piece.content = append(splitter.header(len(data)), data...)
+	}
+	splitter.pieces++
+	splitter.frames += int64(size / frameSize)
+	return piece, nil
+}
+
+// quietestCut : Returns the size of the first piece of data when it is cut in the middle of the longest silence in
+// the search window at its end, or else at the quietest 10 milliseconds of the window. The size is a whole number of
+// frames and never zero.
+func (splitter *pcmSplitter) quietestCut(data []byte) int {
+	frameSize := splitter.format.FrameSize()
+	frames := len(data) / frameSize
+	if !splitter.linear16 {
+		return frames * frameSize
+	}
+	block := splitter.format.Rate / 100
+	if block < 1 {
+		block = 1
+	}
+	window := int(splitter.settings.SearchWindow.Seconds() * float64(splitter.format.Rate))
+	start := frames - window
+	if start < 0 {
+		start = 0
+	}
+
+	threshold := splitter.settings.SilenceThreshold * 32768
+	cut, runStart, longestRun := -1, -1, 0
+	quietest, quietestLevel := -1, math.Inf(1)
+	for from := start; from+block <= frames; from += block {
+		level := splitter.level(data[from*frameSize : (from+block)*frameSize])
+		if level < quietestLevel {
+			quietest, quietestLevel = from, level
+		}
+		if level >= threshold {
+			runStart = -1
+			continue
+		}
+		if runStart < 0 {
+			runStart = from
+		}
+		if run := from + block - runStart; run > longestRun {
+			longestRun = run
+			cut = runStart + run/2
+		}
+	}
+	if cut < 0 && quietest >= 0 {
+		cut = quietest + block/2
+	}
+	if cut <= 0 {
+		cut = frames
+	}
+	return cut * frameSize
+}
+
+// level : Returns the root mean square of 16-bit samples
+func (splitter *pcmSplitter) level(data []byte) float64 {
+	var order binary.ByteOrder = binary.LittleEndian
+	if splitter.bigEndian {
+		order = binary.BigEndian
+	}
+	var sum float64
+	samples := len(data) / 2
+	for i := 0; i < samples; i++ {
+		sample := float64(int16(order.Uint16(data[2*i:])))
+		sum += sample * sample
+	}
+	return math.Sqrt(sum / float64(samples))
+}
+
+// newWavSplitter : Reads the header of a WAV file and splits its data, writing a new header for every piece
+func newWavSplitter(audio io.Reader, settings RecognizeLargeOptions) (audioSplitter, error) {
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(audio, header); err != nil {
+		return nil, fmt.Errorf("invalid WAV header: %s", err)
+	}
+	var fmtChunk []byte
+	for {
+		chunkHeader := make([]byte, 8)
+		if _, err := io.ReadFull(audio, chunkHeader); err != nil {
+			return nil, fmt.Errorf("invalid WAV header: no data chunk")
+		}
+		size := int64(binary.LittleEndian.Uint32(chunkHeader[4:]))
+		switch string(chunkHeader[:4]) {
+		case "fmt ":
+			fmtChunk = make([]byte, size)
+			if _, err := io.ReadFull(audio, fmtChunk); err != nil || size < 16 {
+				return nil, fmt.Errorf("invalid WAV header: truncated fmt chunk")
+			}
+			if size%2 == 1 {
+				_, _ = io.CopyN(ioutil.Discard, audio, 1)
+			}
+			continue
+		case "data":
+		default:
+			if _, err := io.CopyN(ioutil.Discard, audio, size+size%2); err != nil {
+				return nil, fmt.Errorf("invalid WAV header: truncated %q chunk", chunkHeader[:4])
+			}
+			continue
+		}
+		if fmtChunk == nil {
+			return nil, fmt.Errorf("invalid WAV header: no fmt chunk before the data")
+		}
+		// The data runs to the end of a file that was written while streaming
+		if size != 0 && size != 0xffffffff {
+			audio = io.LimitReader(audio, size)
+		}
+		break
+	}
+
+	encoding := binary.LittleEndian.Uint16(fmtChunk[0:])
+	if encoding == 0xfffe && len(fmtChunk) >= 26 {
+		// The extensible format gives the encoding in the first bytes of its subformat
+		encoding = binary.LittleEndian.Uint16(fmtChunk[24:])
+	}
+	channels := int(binary.LittleEndian.Uint16(fmtChunk[2:]))
+	blockAlign := int(binary.LittleEndian.Uint16(fmtChunk[12:]))
+	bitsPerSample := int(binary.LittleEndian.Uint16(fmtChunk[14:]))
+	if channels == 0 || blockAlign == 0 || blockAlign%channels != 0 {
+		return nil, fmt.Errorf("invalid WAV header: %d channels with %d bytes per frame", channels, blockAlign)
+	}
+
+	return &pcmSplitter{
+		reader: audio,
+		format: RawAudioFormat{
+			Encoding:      rawaudio.Encoding(encoding),
+			Rate:          int(binary.LittleEndian.Uint32(fmtChunk[4:])),
+			Channels:      channels,
+			BitsPerSample: 8 * blockAlign / channels,
+		},
+		linear16:    encoding == 0x0001 && bitsPerSample == 16,
+		contentType: AddAudioOptionsContainedContentTypeAudioWavConst,
+		settings:    settings,
+		header: func(dataSize int) []byte {
+			header := make([]byte, 20+len(fmtChunk)+8)
+			copy(header, "RIFF")
+			binary.LittleEndian.PutUint32(header[4:], uint32(4+8+len(fmtChunk)+8+dataSize))
+			copy(header[8:], "WAVEfmt ")
+			binary.LittleEndian.PutUint32(header[16:], uint32(len(fmtChunk)))
+			copy(header[20:], fmtChunk)
+			copy(header[20+len(fmtChunk):], "data")
+			binary.LittleEndian.PutUint32(header[24+len(fmtChunk):], uint32(dataSize))
+			return header
+		},
+	}, nil
+}
+
+// flacSplitter : Splits a FLAC stream between frames, preferring frames that are silent. Every piece gets the
+// STREAMINFO block of the stream, without its total number of samples and checksum, and frames that are numbered
+// from zero.
+type flacSplitter struct {
+	reader     io.Reader
+	streamInfo []byte
+	rate       int
+	settings   RecognizeLargeOptions
+
+	// The stream from the first frame that has not been parsed
+	buffer []byte
+	eof    bool
+
+	// The frames that have been parsed but not yet put in a piece
+	frames  []flacFrame
+	samples int64
+	pieces  int
+}
+
+// flacFrame : A frame of a FLAC stream
+type flacFrame struct {
+	data      []byte
+	headerLen int
+	numberLen int
+	samples   int
+
+	// Whether the frame is silent, as far as the encoder represented its first channel by a constant value
+	silent bool
+}
+
+func newFlacSplitter(audio io.Reader, settings RecognizeLargeOptions) (audioSplitter, error) {
+	marker := make([]byte, 4)
+	if _, err := io.ReadFull(audio, marker); err != nil || string(marker) != "fLaC" {
+		return nil, fmt.Errorf("invalid FLAC stream: no fLaC marker")
+	}
+	splitter := &flacSplitter{reader: audio, settings: settings}
+	for last := false; !last; {
+		blockHeader := make([]byte, 4)
+		if _, err := io.ReadFull(audio, blockHeader); err != nil {
+			return nil, fmt.Errorf("invalid FLAC stream: truncated metadata")
+		}
+		last = blockHeader[0]&0x80 != 0
+		block := make([]byte, int(blockHeader[1])<<16|int(blockHeader[2])<<8|int(blockHeader[3]))
+		if _, err := io.ReadFull(audio, block); err != nil {
+			return nil, fmt.Errorf("invalid FLAC stream: truncated metadata")
+		}
+		if blockHeader[0]&0x7f == 0 && len(block) == 34 {
+			splitter.streamInfo = block
+		}
+	}
+	if splitter.streamInfo == nil {
+		return nil, fmt.Errorf("invalid FLAC stream: no STREAMINFO block")
+	}
+	splitter.rate = int(splitter.streamInfo[10])<<12 | int(splitter.streamInfo[11])<<4 | int(splitter.streamInfo[12])>>4
+	if splitter.rate == 0 {
+		return nil, fmt.Errorf("invalid FLAC stream: invalid sampling rate")
+	}
+	return splitter, nil
+}
+
+func (splitter *flacSplitter) next() (*audioPiece, error) {
+	// The header of a piece is the marker and the STREAMINFO block
+	maxBytes := int(splitter.settings.MaxPieceSize) - 4 - 4 - len(splitter.streamInfo)
+	maxSamples := int64(splitter.settings.MaxPieceDuration.Seconds() * float64(splitter.rate))
+	size, samples := 0, int64(0)
+	for _, frame := range splitter.frames {
+		size += len(frame.data)
+		samples += int64(frame.samples)
+	}
+	for size <= maxBytes && samples <= maxSamples {
+		frame, err := splitter.readFrame()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		splitter.frames = append(splitter.frames, frame)
+		size += len(frame.data)
+		samples += int64(frame.samples)
+	}
+	if len(splitter.frames) == 0 {
+		return nil, io.EOF
+	}
+
+	count := len(splitter.frames)
+	if size > maxBytes || samples > maxSamples {
+		count = splitter.quietestCut(maxBytes, maxSamples)
+		mergeSamples := int64(finalPieceMerge * float64(splitter.rate))
+		var remaining int64
+		for _, frame := range splitter.frames[count:] {
+			remaining += int64(frame.samples)
+		}
+		for remaining < mergeSamples {
+			frame, err := splitter.readFrame()
+			if err == io.EOF {
+				// The end of the audio is too short for a piece of its own
+				count = len(splitter.frames)
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			splitter.frames = append(splitter.frames, frame)
+			remaining += int64(frame.samples)
+		}
+	}
+	frames := splitter.frames[:count]
+	splitter.frames = append([]flacFrame(nil), splitter.frames[count:]...)
+
+	streamInfo := append([]byte(nil), splitter.streamInfo...)
+	// The total number of samples and the MD5 signature are unknown for a piece
+	streamInfo[13] &= 0xf0
+	for i := 14; i < 34; i++ {
+		streamInfo[i] = 0
+	}
+	content := append([]byte("fLaC\x80\x00\x00\x22"), streamInfo...)
+	var pieceSamples int64
+	for i, frame := range frames {
+		number := int64(i)
+		if frame.data[1]&0x01 != 0 {
+			// A stream with variable block sizes numbers its frames by their first sample
+			number = pieceSamples
+		}
+		content = append(content, frame.renumber(number)...)
+		pieceSamples += int64(frame.samples)
+	}
+
+	piece := &audioPiece{
+		index:       splitter.pieces,
+		content:     content,
+		contentType: AddAudioOptionsContainedContentTypeAudioFlacConst,
+		start:       float64(splitter.samples) / float64(splitter.rate),
+	}
+	splitter.pieces++
+	splitter.samples += pieceSamples
+	return piece, nil
+}
+
+// quietestCut : Returns the number of frames in the next piece: the most that fit the limits, cut in the middle of
+// the longest run of silent frames in the search window when there is one.
+func (splitter *flacSplitter) quietestCut(maxBytes int, maxSamples int64) int {
+	size, samples, fit := 0, int64(0), 0
+	for _, frame := range splitter.frames {
+		if size+len(frame.data) > maxBytes || samples+int64(frame.samples) > maxSamples {
+			break
+		}
+		size += len(frame.data)
+		samples += int64(frame.samples)
+		fit++
+	}
+	if fit == 0 {
+		return 1
+	}
+
+	windowSamples := int64(splitter.settings.SearchWindow.Seconds() * float64(splitter.rate))
+	cut, runStart, longestRun := fit, -1, 0
+	var fromEnd int64
+	for i := fit - 1; i >= 0 && fromEnd < windowSamples; i-- {
+		fromEnd += int64(splitter.frames[i].samples)
+		if !splitter.frames[i].silent {
+			runStart = -1
+			continue
+		}
+		if runStart < 0 {
+			runStart = i
+		}
+		if run := runStart - i + 1; run > longestRun {
+			longestRun = run
+			cut = i + (run+1)/2
+		}
+	}
+	return cut
+}
+
+// readFrame : Reads the next frame of the stream. A frame ends where the next valid frame header starts and its
+// checksum matches, or at the end of the stream.
+func (splitter *flacSplitter) readFrame() (flacFrame, error) {
+	for {
+		if len(splitter.buffer) == 0 && splitter.eof {
+			return flacFrame{}, io.EOF
+		}
+		headerLen, numberLen, samples, ok := parseFlacFrameHeader(splitter.buffer, splitter.streamInfo)
+		if !ok && len(splitter.buffer) >= 16 {
+			return flacFrame{}, fmt.Errorf("invalid FLAC stream: no frame header at sample %d", splitter.samples)
+		}
+		if ok {
+			end := flacFrameEnd(splitter.buffer, headerLen, splitter.streamInfo)
+			if end < 0 && splitter.eof {
+				end = len(splitter.buffer)
+			}
+			if end > 0 {
+				frame := flacFrame{
+					data:      append([]byte(nil), splitter.buffer[:end]...),
+					headerLen: headerLen,
+					numberLen: numberLen,
+					samples:   samples,
+				}
+				// The first subframe starts after the header; its type bits are zero for a constant subframe
+				frame.silent = end > headerLen && frame.data[headerLen]&0x7e == 0
+				splitter.buffer = append([]byte(nil), splitter.buffer[end:]...)
+				return frame, nil
+			}
+		}
+		if splitter.eof {
+			return flacFrame{}, fmt.Errorf("invalid FLAC stream: truncated frame at sample %d", splitter.samples)
+		}
+		chunk := make([]byte, 64*1024)
+		n, err := io.ReadFull(splitter.reader, chunk)
+		splitter.buffer = append(splitter.buffer, chunk[:n]...)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			splitter.eof = true
+		} else if err != nil {
+			return flacFrame{}, err
+		}
+	}
+}
+
+// flacFrameEnd : Returns the length of the frame at the start of data, or -1 if its end is not in data
+func flacFrameEnd(data []byte, headerLen int, streamInfo []byte) int {
+	var history [3]uint16
+	crc := uint16(0)
+	for i := 0; i < len(data)-1; i++ {
+		// history holds the checksum of data[:i-2], data[:i-1] and data[:i]
+		history[0], history[1], history[2] = history[1], history[2], crc
+		if i >= headerLen+2 && data[i] == 0xff && data[i+1]&0xfe == 0xf8 && history[0] == binary.BigEndian.Uint16(data[i-2:]) {
+			if _, _, _, ok := parseFlacFrameHeader(data[i:], streamInfo); ok {
+				return i
+			}
+		}
+		crc = crc<<8 ^ flacCRC16Table[byte(crc>>8)^data[i]]
+	}
+	return -1
+}
+
+// parseFlacFrameHeader : Parses the frame header at the start of data and verifies its checksum. It returns the
+// length of the header, the length of its coded frame or sample number, and the number of samples in the frame.
+func parseFlacFrameHeader(data []byte, streamInfo []byte) (headerLen int, numberLen int, samples int, ok bool) {
+	if len(data) < 6 || data[0] != 0xff || data[1]&0xfe != 0xf8 || data[3]&0x01 != 0 {
+		return
+	}
+	blockSizeCode, rateCode := data[2]>>4, data[2]&0x0f
+	if blockSizeCode == 0 || rateCode == 0x0f || data[3]>>4 > 10 || data[3]>>1&0x07 == 3 || data[3]>>1&0x07 == 7 {
+		return
+	}
+
+	// The frame or sample number is coded like UTF-8
+	switch first := data[4]; {
+	case first&0x80 == 0:
+		numberLen = 1
+	case first&0xe0 == 0xc0:
+		numberLen = 2
+	case first&0xf0 == 0xe0:
+		numberLen = 3
+	case first&0xf8 == 0xf0:
+		numberLen = 4
+	case first&0xfc == 0xf8:
+		numberLen = 5
+	case first&0xfe == 0xfc:
+		numberLen = 6
+	case first == 0xfe:
+		numberLen = 7
+	default:
+		return
+	}
+	headerLen = 4 + numberLen
+	switch {
+	case blockSizeCode == 1:
+		samples = 192
+	case blockSizeCode <= 5:
+		samples = 576 << (blockSizeCode - 2)
+	case blockSizeCode == 6:
+		headerLen++
+	case blockSizeCode == 7:
+		headerLen += 2
+	default:
+		samples = 256 << (blockSizeCode - 8)
+	}
+	switch rateCode {
+	case 0x0c:
+		headerLen++
+	case 0x0d, 0x0e:
+		headerLen += 2
+	}
+	if len(data) < headerLen+1 {
+		return
+	}
+	switch blockSizeCode {
+	case 6:
+		samples = int(data[4+numberLen]) + 1
+	case 7:
+		samples = int(binary.BigEndian.Uint16(data[4+numberLen:])) + 1
+	}
+
+	crc := byte(0)
+	for _, b := range data[:headerLen] {
+		crc = flacCRC8Table[crc^b]
+	}
+	if crc != data[headerLen] {
+		return
+	}
+	return headerLen + 1, numberLen, samples, true
+}
+
+// renumber : Returns the frame with its frame or sample number replaced and its checksums updated
+func (frame flacFrame) renumber(number int64) []byte {
+	var coded []byte
+	switch {
+	case number < 0x80:
+		coded = []byte{byte(number)}
+	default:
+		// Every continuation byte holds 6 bits; the first byte holds the rest after its length prefix
+		var continuation []byte
+		for remaining := number; ; remaining >>= 6 {
+			continuation = append([]byte{0x80 | byte(remaining&0x3f)}, continuation...)
+			length := len(continuation) + 1
+			if remaining>>6 < int64(1)<<(7-length) {
+				coded = append([]byte{byte(0xff<<(8-length)) | byte(remaining>>6)}, continuation...)
+				break
+			}
+		}
+	}
+
+	numberStart := 4
+	renumbered := make([]byte, 0, len(frame.data)+len(coded)-frame.numberLen)
+	renumbered = append(renumbered, frame.data[:numberStart]...)
+	renumbered = append(renumbered, coded...)
+	renumbered = append(renumbered, frame.data[numberStart+frame.numberLen:frame.headerLen-1]...)
+	crc8 := byte(0)
+	for _, b := range renumbered {
+		crc8 = flacCRC8Table[crc8^b]
+	}
+	renumbered = append(renumbered, crc8)
+	renumbered = append(renumbered, frame.data[frame.headerLen:len(frame.data)-2]...)
+	crc16 := uint16(0)
+	for _, b := range renumbered {
+		crc16 = crc16<<8 ^ flacCRC16Table[byte(crc16>>8)^b]
+	}
+	return append(renumbered, byte(crc16>>8), byte(crc16))
+}
+
+// The checksums of FLAC frames: CRC-8 with polynomial 0x07 for headers, CRC-16 with polynomial 0x8005 for frames
+var flacCRC8Table, flacCRC16Table = func() (crc8 [256]byte, crc16 [256]uint16) {
+	for i := 0; i < 256; i++ {
+		c8, c16 := byte(i), uint16(i)<<8
+		for bit := 0; bit < 8; bit++ {
+			if c8&0x80 != 0 {
+				c8 = c8<<1 ^ 0x07
+			} else {
+				c8 <<= 1
+			}
+			if c16&0x8000 != 0 {
+				c16 = c16<<1 ^ 0x8005
+			} else {
+				c16 <<= 1
+			}
+		}
+		crc8[i], crc16[i] = c8, c16
+	}
+	return
+}()
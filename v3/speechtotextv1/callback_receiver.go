@@ -0,0 +1,179 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// Constants associated with the RecognitionEvent.Event property.
+// The event that the service notifies a callback URL of:
+// * `recognitions.started`: The job has started processing.
+// * `recognitions.completed`: The job has completed; its results can be retrieved with CheckJob.
+// * `recognitions.completed_with_results`: The job has completed; the notification carries its results.
+// * `recognitions.failed`: The job has failed.
+const (
+	RecognitionEventCompletedConst            = "recognitions.completed"
+	RecognitionEventCompletedWithResultsConst = "recognitions.completed_with_results"
+	RecognitionEventFailedConst               = "recognitions.failed"
+	RecognitionEventStartedConst              = "recognitions.started"
+)
+
+// CallbackSignatureHeader is the header that carries the signature of a callback request.
+const CallbackSignatureHeader = "X-Callback-Signature"
+
+// DefaultCallbackMaxBodySize is the default limit of the body of a notification, which is read before its signature
+// can be verified.
+const DefaultCallbackMaxBodySize int64 = 32 << 20
+
+// RecognitionEvent : A job notification that the service sends to a callback URL
+type RecognitionEvent struct {
+	// The ID of the job.
+	ID string `json:"id"`
+
+	// The event, one of the RecognitionEvent...Const values.
+	Event string `json:"event"`
+
+	// The user token that was given when the job was created.
+	UserToken string `json:"user_token,omitempty"`
+
+	// The results of the job, for the `recognitions.completed_with_results` event.
+	Results []SpeechRecognitionResults `json:"results,omitempty"`
+}
+
+// RecognitionEventHandler : Handles a job notification
+type RecognitionEventHandler func(event *RecognitionEvent)
+
+// CallbackReceiver : An http.Handler for the callback URL of asynchronous jobs. Serve it at the URL given to
+// RegisterCallback; it answers the registration challenge and dispatches job notifications to the handlers registered
+// with Handle. When the callback is registered with a user secret, every request must carry a valid signature.
+//
+// To have a RecognitionJobManager retrieve results as soon as a job completes:
+//
+//	receiver.Handle(speechtotextv1.RecognitionEventCompletedConst, func(event *speechtotextv1.RecognitionEvent) {
+//		manager.Notify(event.ID)
+//	})
+type CallbackReceiver struct {
+	secret      []byte
+	maxBodySize int64
+
+	lock     sync.RWMutex
+	handlers map[string][]RecognitionEventHandler
+}
+
+// NewCallbackReceiver : Instantiate a CallbackReceiver. The user secret must be the one given to RegisterCallback, or
+// empty if the callback was registered without one, in which case requests are not verified.
+func NewCallbackReceiver(userSecret string) *CallbackReceiver {
+	return &CallbackReceiver{
+		secret:      []byte(userSecret),
+		maxBodySize: DefaultCallbackMaxBodySize,
+		handlers:    make(map[string][]RecognitionEventHandler),
+	}
+}
+
+// SetMaxBodySize : Allow notifications of up to maxBodySize bytes, which `recognitions.completed_with_results` events
+// of long jobs may need. Larger notifications are rejected with status 413 before they are verified. Defaults to
+// DefaultCallbackMaxBodySize.
+func (receiver *CallbackReceiver) SetMaxBodySize(maxBodySize int64) *CallbackReceiver {
+	receiver.maxBodySize = maxBodySize
+	return receiver
+}
+
+// Handle : Registers a handler for an event, or for every event when event is empty. Handlers run on the goroutine of
+// the request in the order they were registered, and the service waits for them before the notification counts as
+// delivered, so they should return quickly.
+func (receiver *CallbackReceiver) Handle(event string, handler RecognitionEventHandler) {
+	receiver.lock.Lock()
+	defer receiver.lock.Unlock()
+	receiver.handlers[event] = append(receiver.handlers[event], handler)
+}
+
+// ServeHTTP : Answers the registration challenge for GET requests and dispatches the notifications of POST requests
+func (receiver *CallbackReceiver) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		challenge := req.URL.Query().Get("challenge_string")
+		if challenge == "" {
+			http.Error(res, "missing challenge_string", http.StatusBadRequest)
+			return
+		}
+		if !receiver.verify([]byte(challenge), req.Header.Get(CallbackSignatureHeader)) {
+			http.Error(res, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		res.Header().Set("Content-Type", "text/plain")
+		_, _ = res.Write([]byte(challenge))
+
+	case http.MethodPost:
+		body, err := ioutil.ReadAll(http.MaxBytesReader(res, req.Body, receiver.maxBodySize))
+		if err != nil && int64(len(body)) >= receiver.maxBodySize {
+			http.Error(res, "notification too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !receiver.verify(body, req.Header.Get(CallbackSignatureHeader)) {
+			http.Error(res, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		event := new(RecognitionEvent)
+		if err = json.Unmarshal(body, event); err != nil || event.ID == "" || event.Event == "" {
+			http.Error(res, "invalid notification", http.StatusBadRequest)
+			return
+		}
+		receiver.dispatch(event)
+		res.WriteHeader(http.StatusOK)
+
+	default:
+		res.Header().Set("Allow", "GET, POST")
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (receiver *CallbackReceiver) dispatch(event *RecognitionEvent) {
+	receiver.lock.RLock()
+	handlers := append(append([]RecognitionEventHandler(nil), receiver.handlers[event.Event]...), receiver.handlers[""]...)
+	receiver.lock.RUnlock()
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// verify : Checks the signature of a request. Every request is accepted when there is no secret.
+func (receiver *CallbackReceiver) verify(payload []byte, signature string) bool {
+	if len(receiver.secret) == 0 {
+		return true
+	}
+	return hmac.Equal([]byte(signature), []byte(SignCallbackPayload(string(receiver.secret), payload)))
+}
+
+// SignCallbackPayload : Computes the signature that the service sends in the X-Callback-Signature header: the
+// base64-encoded HMAC-SHA1 of the payload keyed with the user secret. The payload is the challenge string of a
+// registration request and the body of a notification.
+func SignCallbackPayload(userSecret string, payload []byte) string {
+	mac := hmac.New(sha1.New, []byte(userSecret))
+	mac.Write(payload)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
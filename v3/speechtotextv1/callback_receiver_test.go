@@ -0,0 +1,141 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+)
+
+var _ = Describe(`CallbackReceiver`, func() {
+	const secret = "ThisIsMySecret"
+	var receiver *speechtotextv1.CallbackReceiver
+	var testServer *httptest.Server
+	BeforeEach(func() {
+		receiver = speechtotextv1.NewCallbackReceiver(secret)
+		testServer = httptest.NewServer(receiver)
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	challenge := func(challengeString string, signature string) (int, string) {
+		req, err := http.NewRequest(http.MethodGet, testServer.URL+"?challenge_string="+url.QueryEscape(challengeString), nil)
+		Expect(err).To(BeNil())
+		req.Header.Set(speechtotextv1.CallbackSignatureHeader, signature)
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).To(BeNil())
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		Expect(err).To(BeNil())
+		return res.StatusCode, string(body)
+	}
+
+	notify := func(body string, signature string) int {
+		req, err := http.NewRequest(http.MethodPost, testServer.URL, strings.NewReader(body))
+		Expect(err).To(BeNil())
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(speechtotextv1.CallbackSignatureHeader, signature)
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).To(BeNil())
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	It(`Answers a signed registration challenge`, func() {
+		status, body := challenge("H2S7vx1BPdAo", speechtotextv1.SignCallbackPayload(secret, []byte("H2S7vx1BPdAo")))
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("H2S7vx1BPdAo"))
+
+		status, _ = challenge("H2S7vx1BPdAo", speechtotextv1.SignCallbackPayload("wrong", []byte("H2S7vx1BPdAo")))
+		Expect(status).To(Equal(http.StatusUnauthorized))
+	})
+
+	It(`Matches the signature computed by the service`, func() {
+		// HMAC-SHA1 of "payload" keyed with "ThisIsMySecret"
+		Expect(speechtotextv1.SignCallbackPayload(secret, []byte("payload"))).To(Equal("F6Y7u4g/3bVgtFEqOwa3W+fuo1w="))
+	})
+
+	It(`Dispatches verified notifications to handlers`, func() {
+		var lock sync.Mutex
+		var completed, all []*speechtotextv1.RecognitionEvent
+		receiver.Handle(speechtotextv1.RecognitionEventCompletedWithResultsConst, func(event *speechtotextv1.RecognitionEvent) {
+			lock.Lock()
+			defer lock.Unlock()
+			completed = append(completed, event)
+		})
+		receiver.Handle("", func(event *speechtotextv1.RecognitionEvent) {
+			lock.Lock()
+			defer lock.Unlock()
+			all = append(all, event)
+		})
+
+		started := `{"id": "4bd734c0", "event": "recognitions.started", "user_token": "job25"}`
+		Expect(notify(started, speechtotextv1.SignCallbackPayload(secret, []byte(started)))).To(Equal(http.StatusOK))
+
+		withResults := `{"id": "4bd734c0", "event": "recognitions.completed_with_results", "user_token": "job25", "results": [{"result_index": 0, "results": [{"final": true, "alternatives": [{"transcript": "several tornadoes touch down "}]}]}]}`
+		Expect(notify(withResults, speechtotextv1.SignCallbackPayload(secret, []byte(withResults)))).To(Equal(http.StatusOK))
+
+		Expect(notify(started, "forged")).To(Equal(http.StatusUnauthorized))
+		Expect(notify(`{"unexpected": true}`, speechtotextv1.SignCallbackPayload(secret, []byte(`{"unexpected": true}`)))).To(Equal(http.StatusBadRequest))
+
+		lock.Lock()
+		defer lock.Unlock()
+		Expect(all).To(HaveLen(2))
+		Expect(all[0].Event).To(Equal(speechtotextv1.RecognitionEventStartedConst))
+		Expect(completed).To(HaveLen(1))
+		Expect(completed[0].ID).To(Equal("4bd734c0"))
+		Expect(completed[0].UserToken).To(Equal("job25"))
+		Expect(*completed[0].Results[0].Results[0].Alternatives[0].Transcript).To(Equal("several tornadoes touch down "))
+	})
+
+	It(`Accepts unsigned requests without a secret`, func() {
+		unsigned := httptest.NewServer(speechtotextv1.NewCallbackReceiver(""))
+		defer unsigned.Close()
+		res, err := http.Get(unsigned.URL + "?challenge_string=abc")
+		Expect(err).To(BeNil())
+		defer res.Body.Close()
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res, err = http.Get(unsigned.URL)
+		Expect(err).To(BeNil())
+		res.Body.Close()
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+
+	It(`Rejects notifications larger than the limit`, func() {
+		var dispatched int
+		receiver.Handle("", func(event *speechtotextv1.RecognitionEvent) {
+			dispatched++
+		})
+		started := `{"id": "4bd734c0", "event": "recognitions.started", "user_token": "job25"}`
+		receiver.SetMaxBodySize(int64(len(started)))
+		Expect(notify(started, speechtotextv1.SignCallbackPayload(secret, []byte(started)))).To(Equal(http.StatusOK))
+
+		receiver.SetMaxBodySize(16)
+		Expect(notify(started, speechtotextv1.SignCallbackPayload(secret, []byte(started)))).To(Equal(http.StatusRequestEntityTooLarge))
+		Expect(dispatched).To(Equal(1))
+	})
+})
@@ -0,0 +1,84 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// CustomizationWorkflowOptions : Controls how the customization workflows wait for the service. Zero values select
+// the defaults.
+type CustomizationWorkflowOptions struct {
+	// The delay between checks of a resource that the service is processing, and between retries of a request that
+	// the service rejected because the model was busy. Defaults to 10 seconds.
+	PollInterval time.Duration
+
+	// The number of times a request that the service rejected with status 409 because the model was busy is retried.
+	// Defaults to 60.
+	MaxBusyRetries int
+
+	// The maximum number of resources that are uploaded at the same time, for workflows that upload them
+	// concurrently. Defaults to 4.
+	Workers int
+}
+
+func (options *CustomizationWorkflowOptions) withDefaults() CustomizationWorkflowOptions {
+	withDefaults := CustomizationWorkflowOptions{}
+	if options != nil {
+		withDefaults = *options
+	}
+	if withDefaults.PollInterval <= 0 {
+		withDefaults.PollInterval = 10 * time.Second
+	}
+	if withDefaults.MaxBusyRetries <= 0 {
+		withDefaults.MaxBusyRetries = 60
+	}
+	if withDefaults.Workers <= 0 {
+		withDefaults.Workers = 4
+	}
+	return withDefaults
+}
+
+// pause : Waits for the poll interval or until the context is done
+func (options CustomizationWorkflowOptions) pause(ctx context.Context) error {
+	timer := time.NewTimer(options.PollInterval)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryBusy : Makes a request, and makes it again while the service rejects it because the model is busy processing
+// another request. The request must be rebuilt by every call, since the body of a failed request has been consumed.
+func (options CustomizationWorkflowOptions) retryBusy(ctx context.Context, request func() (*core.DetailedResponse, error)) error {
+	for attempt := 0; ; attempt++ {
+		response, err := request()
+		if err == nil || response == nil || response.StatusCode != http.StatusConflict || attempt >= options.MaxBusyRetries {
+			return err
+		}
+		if err = options.pause(ctx); err != nil {
+			return err
+		}
+	}
+}
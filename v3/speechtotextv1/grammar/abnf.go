@@ -0,0 +1,634 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grammar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseABNF : Reads a grammar in the ABNF form
+func ParseABNF(reader io.Reader) (*Grammar, error) {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	parser := &abnfParser{lexer: &abnfLexer{input: []rune(string(content)), line: 1}}
+	grammar, err := parser.parseGrammar()
+	if err != nil {
+		return nil, fmt.Errorf("invalid ABNF grammar: line %d: %s", parser.lexer.line, err)
+	}
+	return grammar, nil
+}
+
+type abnfKind int
+
+const (
+	abnfEOF abnfKind = iota
+	abnfWord
+	abnfQuoted
+	abnfRef
+	abnfTag
+	abnfPunct
+)
+
+type abnfToken struct {
+	kind abnfKind
+	text string
+}
+
+func (token abnfToken) String() string {
+	switch token.kind {
+	case abnfEOF:
+		return "the end of the grammar"
+	case abnfQuoted:
+		return strconv.Quote(token.text)
+	case abnfRef:
+		return "$" + token.text
+	case abnfTag:
+		return "a tag"
+	}
+	return "'" + token.text + "'"
+}
+
+// abnfPunctuation are the characters that are tokens of their own and end words
+const abnfPunctuation = ";|/()[]<>=!"
+
+type abnfLexer struct {
+	input  []rune
+	offset int
+	line   int
+	peeked *abnfToken
+}
+
+func (lexer *abnfLexer) peek() (abnfToken, error) {
+	if lexer.peeked == nil {
+		token, err := lexer.scan()
+		if err != nil {
+			return token, err
+		}
+		lexer.peeked = &token
+	}
+	return *lexer.peeked, nil
+}
+
+func (lexer *abnfLexer) next() (abnfToken, error) {
+	token, err := lexer.peek()
+	lexer.peeked = nil
+	return token, err
+}
+
+func (lexer *abnfLexer) at(text string) bool {
+	end := lexer.offset + len([]rune(text))
+	return end <= len(lexer.input) && string(lexer.input[lexer.offset:end]) == text
+}
+
+// until : Returns the input up to the first occurrence of end and moves past it
+func (lexer *abnfLexer) until(end string) (string, error) {
+	start := lexer.offset
+	for ; lexer.offset < len(lexer.input); lexer.offset++ {
+		if lexer.at(end) {
+			text := string(lexer.input[start:lexer.offset])
+			lexer.offset += len([]rune(end))
+			return text, nil
+		}
+		if lexer.input[lexer.offset] == '\n' {
+			lexer.line++
+		}
+	}
+	return "", fmt.Errorf("%q is missing", end)
+}
+
+func (lexer *abnfLexer) scan() (abnfToken, error) {
+	// Skip white space and comments
+	for lexer.offset < len(lexer.input) {
+		switch r := lexer.input[lexer.offset]; {
+		case r == '\n':
+			lexer.line++
+			lexer.offset++
+		case unicode.IsSpace(r):
+			lexer.offset++
+		case lexer.at("//"):
+			if _, err := lexer.until("\n"); err != nil {
+				lexer.offset = len(lexer.input)
+			} else {
+				lexer.line++
+			}
+		case lexer.at("/*"):
+			if _, err := lexer.until("*/"); err != nil {
+				return abnfToken{}, fmt.Errorf("unterminated comment")
+			}
+		default:
+			return lexer.scanToken()
+		}
+	}
+	return abnfToken{kind: abnfEOF}, nil
+}
+
+func (lexer *abnfLexer) scanToken() (abnfToken, error) {
+	r := lexer.input[lexer.offset]
+	switch {
+	case r == '"':
+		lexer.offset++
+		text := new(strings.Builder)
+		for ; lexer.offset < len(lexer.input); lexer.offset++ {
+			switch r := lexer.input[lexer.offset]; r {
+			case '"':
+				lexer.offset++
+				return abnfToken{kind: abnfQuoted, text: text.String()}, nil
+			case '\\':
+				if lexer.offset+1 < len(lexer.input) {
+					lexer.offset++
+					text.WriteRune(lexer.input[lexer.offset])
+				}
+			case '\n':
+				return abnfToken{}, fmt.Errorf("unterminated quoted token")
+			default:
+				text.WriteRune(r)
+			}
+		}
+		return abnfToken{}, fmt.Errorf("unterminated quoted token")
+	case r == '{':
+		end := "}"
+		lexer.offset++
+		if lexer.at("!{") {
+			lexer.offset += 2
+			end = "}!}"
+		}
+		content, err := lexer.until(end)
+		return abnfToken{kind: abnfTag, text: strings.TrimSpace(content)}, err
+	case r == '$':
+		lexer.offset++
+		if lexer.at("<") {
+			lexer.offset++
+			uri, err := lexer.until(">")
+			return abnfToken{kind: abnfRef, text: "<" + strings.TrimSpace(uri) + ">"}, err
+		}
+		token := lexer.word()
+		if token.text == "" {
+			return token, fmt.Errorf("a rule reference needs a rule name")
+		}
+		token.kind = abnfRef
+		return token, nil
+	case strings.ContainsRune(abnfPunctuation, r):
+		lexer.offset++
+		return abnfToken{kind: abnfPunct, text: string(r)}, nil
+	}
+	token := lexer.word()
+	if token.text == "" {
+		return token, fmt.Errorf("unexpected %q", r)
+	}
+	return token, nil
+}
+
+func (lexer *abnfLexer) word() abnfToken {
+	start := lexer.offset
+	for lexer.offset < len(lexer.input) {
+		r := lexer.input[lexer.offset]
+		if unicode.IsSpace(r) || strings.ContainsRune(abnfPunctuation+`{}$"`, r) {
+			break
+		}
+		lexer.offset++
+	}
+	return abnfToken{kind: abnfWord, text: string(lexer.input[start:lexer.offset])}
+}
+
+type abnfParser struct {
+	lexer *abnfLexer
+}
+
+// expect : Reads the next token and checks that it is the punctuation or word given
+func (parser *abnfParser) expect(text string) error {
+	token, err := parser.lexer.next()
+	if err != nil {
+		return err
+	}
+	if (token.kind != abnfPunct && token.kind != abnfWord) || token.text != text {
+		return fmt.Errorf("expected '%s' but found %s", text, token)
+	}
+	return nil
+}
+
+func (parser *abnfParser) expectKind(kind abnfKind, what string) (abnfToken, error) {
+	token, err := parser.lexer.next()
+	if err != nil {
+		return token, err
+	}
+	if token.kind != kind {
+		return token, fmt.Errorf("expected %s but found %s", what, token)
+	}
+	return token, nil
+}
+
+func (parser *abnfParser) parseGrammar() (*Grammar, error) {
+	if err := parser.expect("#ABNF"); err != nil {
+		return nil, fmt.Errorf("the grammar must start with an #ABNF header: %s", err)
+	}
+	version, err := parser.expectKind(abnfWord, "a version")
+	if err != nil {
+		return nil, err
+	}
+	if version.text != "1.0" {
+		return nil, fmt.Errorf("version %q is not supported", version.text)
+	}
+	if token, err := parser.lexer.peek(); err == nil && token.kind == abnfWord {
+		// The service ignores the encoding and expects UTF-8
+		_, _ = parser.lexer.next()
+	}
+	if err = parser.expect(";"); err != nil {
+		return nil, err
+	}
+
+	grammar := &Grammar{}
+	for {
+		token, err := parser.lexer.peek()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case token.kind == abnfEOF:
+			return grammar, nil
+		case token.kind == abnfWord && (token.text == "public" || token.text == "private"):
+			_, _ = parser.lexer.next()
+			rule, err := parser.parseRule(Scope(token.text))
+			if err != nil {
+				return nil, err
+			}
+			grammar.Rules = append(grammar.Rules, rule)
+		case token.kind == abnfRef:
+			rule, err := parser.parseRule(Private)
+			if err != nil {
+				return nil, err
+			}
+			grammar.Rules = append(grammar.Rules, rule)
+		case token.kind == abnfWord:
+			if err = parser.parseDeclaration(grammar, token.text); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("expected a declaration or a rule but found %s", token)
+		}
+	}
+}
+
+func (parser *abnfParser) parseDeclaration(grammar *Grammar, keyword string) error {
+	_, _ = parser.lexer.next()
+	switch keyword {
+	case "language", "mode":
+		value, err := parser.expectKind(abnfWord, "a "+keyword)
+		if err != nil {
+			return err
+		}
+		if keyword == "language" {
+			grammar.Language = value.text
+		} else {
+			grammar.Mode = value.text
+		}
+	case "root":
+		ref, err := parser.expectKind(abnfRef, "a rule reference")
+		if err != nil {
+			return err
+		}
+		grammar.Root = ref.text
+	case "tag-format", "base":
+		if err := parser.expect("<"); err != nil {
+			return err
+		}
+		parser.lexer.peeked = nil
+		value, err := parser.lexer.until(">")
+		if err != nil {
+			return err
+		}
+		if keyword == "tag-format" {
+			grammar.TagFormat = strings.TrimSpace(value)
+		}
+	case "meta", "http-equiv":
+		// Metadata does not change what the grammar matches
+		if _, err := parser.expectKind(abnfQuoted, "a quoted name"); err != nil {
+			return err
+		}
+		if err := parser.expect("is"); err != nil {
+			return err
+		}
+		if _, err := parser.expectKind(abnfQuoted, "a quoted value"); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("the %s declaration is not supported", keyword)
+	}
+	return parser.expect(";")
+}
+
+func (parser *abnfParser) parseRule(scope Scope) (*Rule, error) {
+	name, err := parser.expectKind(abnfRef, "a rule name")
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(name.text, "<") {
+		return nil, fmt.Errorf("a rule must be named, not %s", name)
+	}
+	rule := &Rule{ID: name.text, Scope: scope}
+	if err = parser.expect("="); err != nil {
+		return nil, err
+	}
+	if rule.Expansion, err = parser.parseAlternatives(); err != nil {
+		return nil, fmt.Errorf("rule %q: %s", rule.ID, err)
+	}
+	return rule, parser.expect(";")
+}
+
+func (parser *abnfParser) parseAlternatives() (Expansion, error) {
+	var oneOf OneOf
+	for {
+		alternative := Alternative{}
+		if token, err := parser.lexer.peek(); err != nil {
+			return nil, err
+		} else if token.kind == abnfPunct && token.text == "/" {
+			_, _ = parser.lexer.next()
+			weight, err := parser.expectKind(abnfWord, "a weight")
+			if err != nil {
+				return nil, err
+			}
+			if alternative.Weight, err = strconv.ParseFloat(weight.text, 64); err != nil {
+				return nil, fmt.Errorf("invalid weight %q", weight.text)
+			}
+			if err = parser.expect("/"); err != nil {
+				return nil, err
+			}
+		}
+		var err error
+		if alternative.Expansion, err = parser.parseSequence(); err != nil {
+			return nil, err
+		}
+		oneOf = append(oneOf, alternative)
+
+		token, err := parser.lexer.peek()
+		if err != nil {
+			return nil, err
+		}
+		if token.kind != abnfPunct || token.text != "|" {
+			break
+		}
+		_, _ = parser.lexer.next()
+	}
+	if len(oneOf) == 1 && oneOf[0].Weight == 0 {
+		return oneOf[0].Expansion, nil
+	}
+	return oneOf, nil
+}
+
+func (parser *abnfParser) parseSequence() (Expansion, error) {
+	var sequence Sequence
+	for {
+		token, err := parser.lexer.peek()
+		if err != nil {
+			return nil, err
+		}
+		if token.kind == abnfEOF || (token.kind == abnfPunct && strings.Contains("|)];", token.text)) {
+			break
+		}
+		item, err := parser.parseItem()
+		if err != nil {
+			return nil, err
+		}
+		sequence = append(sequence, item)
+	}
+	switch len(sequence) {
+	case 0:
+		return nil, fmt.Errorf("empty expansion")
+	case 1:
+		return sequence[0], nil
+	}
+	return sequence, nil
+}
+
+func (parser *abnfParser) parseItem() (Expansion, error) {
+	item, err := parser.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		token, err := parser.lexer.peek()
+		if err != nil {
+			return nil, err
+		}
+		if token.kind != abnfPunct || token.text != "<" {
+			return item, nil
+		}
+		_, _ = parser.lexer.next()
+		bounds, err := parser.expectKind(abnfWord, "a repeat")
+		if err != nil {
+			return nil, err
+		}
+		repeat := Repeat{Expansion: item}
+		if repeat.Min, repeat.Max, err = parseRepeat(bounds.text); err != nil {
+			return nil, err
+		}
+		if token, err = parser.lexer.peek(); err == nil && token.kind == abnfPunct && token.text == "/" {
+			_, _ = parser.lexer.next()
+			probability, err := parser.expectKind(abnfWord, "a repeat probability")
+			if err != nil {
+				return nil, err
+			}
+			if repeat.Probability, err = strconv.ParseFloat(probability.text, 64); err != nil {
+				return nil, fmt.Errorf("invalid repeat probability %q", probability.text)
+			}
+			if err = parser.expect("/"); err != nil {
+				return nil, err
+			}
+		}
+		if err = parser.expect(">"); err != nil {
+			return nil, err
+		}
+		item = repeat
+	}
+}
+
+func (parser *abnfParser) parseAtom() (Expansion, error) {
+	token, err := parser.lexer.next()
+	if err != nil {
+		return nil, err
+	}
+	switch token.kind {
+	case abnfWord, abnfQuoted:
+		text := strings.Join(strings.Fields(token.text), " ")
+		if text == "" {
+			return nil, fmt.Errorf("empty token")
+		}
+		return Token{Text: text}, nil
+	case abnfTag:
+		return Tag{Content: token.text}, nil
+	case abnfRef:
+		switch {
+		case token.text == SpecialNull || token.text == SpecialVoid || token.text == SpecialGarbage:
+			return RuleRef{Special: token.text}, nil
+		case strings.HasPrefix(token.text, "<#"):
+			return RuleRef{Rule: strings.TrimSuffix(token.text[2:], ">")}, nil
+		case strings.HasPrefix(token.text, "<"):
+			return RuleRef{URI: strings.TrimSuffix(token.text[1:], ">")}, nil
+		}
+		return RuleRef{Rule: token.text}, nil
+	case abnfPunct:
+		switch token.text {
+		case "(":
+			group, err := parser.parseAlternatives()
+			if err != nil {
+				return nil, err
+			}
+			return group, parser.expect(")")
+		case "[":
+			optional, err := parser.parseAlternatives()
+			if err != nil {
+				return nil, err
+			}
+			return Optional(optional), parser.expect("]")
+		case "!":
+			return nil, fmt.Errorf("language attachments are not supported")
+		}
+	}
+	return nil, fmt.Errorf("unexpected %s", token)
+}
+
+// WriteABNF : Writes the grammar in the ABNF form
+func (grammar *Grammar) WriteABNF(writer io.Writer) error {
+	out := bufio.NewWriter(writer)
+	_, _ = fmt.Fprintf(out, "#ABNF 1.0 UTF-8;\n")
+	if grammar.Language != "" {
+		_, _ = fmt.Fprintf(out, "language %s;\n", grammar.Language)
+	}
+	mode := grammar.Mode
+	if mode == "" {
+		mode = "voice"
+	}
+	_, _ = fmt.Fprintf(out, "mode %s;\n", mode)
+	if grammar.Root != "" {
+		_, _ = fmt.Fprintf(out, "root $%s;\n", grammar.Root)
+	}
+	if grammar.TagFormat != "" {
+		_, _ = fmt.Fprintf(out, "tag-format <%s>;\n", grammar.TagFormat)
+	}
+	for _, rule := range grammar.Rules {
+		expansion, err := formatABNF(rule.Expansion, abnfTop)
+		if err != nil {
+			return fmt.Errorf("rule %q: %s", rule.ID, err)
+		}
+		scope := ""
+		if rule.Scope == Public {
+			scope = "public "
+		}
+		_, _ = fmt.Fprintf(out, "\n%s$%s = %s;\n", scope, rule.ID, expansion)
+	}
+	return out.Flush()
+}
+
+// The contexts an expansion is written in, which decide whether it needs parentheses
+const (
+	abnfTop = iota
+	abnfInSequence
+	abnfRepeated
+)
+
+func formatABNF(expansion Expansion, context int) (string, error) {
+	switch expansion := expansion.(type) {
+	case Token:
+		if expansion.Text == "" || strings.IndexFunc(expansion.Text, func(r rune) bool {
+			return unicode.IsSpace(r) || strings.ContainsRune(abnfPunctuation+`{}$"#`, r)
+		}) >= 0 {
+			return strconv.Quote(expansion.Text), nil
+		}
+		return expansion.Text, nil
+	case RuleRef:
+		switch {
+		case expansion.Special != "":
+			return "$" + expansion.Special, nil
+		case expansion.URI != "":
+			return "$<" + expansion.URI + ">", nil
+		}
+		return "$" + expansion.Rule, nil
+	case Tag:
+		if strings.Contains(expansion.Content, "}") {
+			return "{!{ " + expansion.Content + " }!}", nil
+		}
+		return "{" + expansion.Content + "}", nil
+	case Sequence:
+		switch len(expansion) {
+		case 0:
+			return "$" + SpecialNull, nil
+		case 1:
+			return formatABNF(expansion[0], context)
+		}
+		elements := make([]string, len(expansion))
+		for i, element := range expansion {
+			var err error
+			if elements[i], err = formatABNF(element, abnfInSequence); err != nil {
+				return "", err
+			}
+		}
+		return parenthesize(strings.Join(elements, " "), context == abnfRepeated), nil
+	case OneOf:
+		weighted := false
+		for _, alternative := range expansion {
+			weighted = weighted || alternative.Weight != 0
+		}
+		if len(expansion) == 1 && !weighted {
+			return formatABNF(expansion[0].Expansion, context)
+		}
+		alternatives := make([]string, len(expansion))
+		for i, alternative := range expansion {
+			formatted, err := formatABNF(alternative.Expansion, abnfInSequence)
+			if err != nil {
+				return "", err
+			}
+			if weighted {
+				weight := alternative.Weight
+				if weight == 0 {
+					weight = 1
+				}
+				formatted = "/" + strconv.FormatFloat(weight, 'g', -1, 64) + "/ " + formatted
+			}
+			alternatives[i] = formatted
+		}
+		return parenthesize(strings.Join(alternatives, " | "), context != abnfTop), nil
+	case Repeat:
+		if expansion.Min == 0 && expansion.Max == 1 && expansion.Probability == 0 {
+			content, err := formatABNF(expansion.Expansion, abnfTop)
+			return "[" + content + "]", err
+		}
+		content, err := formatABNF(expansion.Expansion, abnfRepeated)
+		if err != nil {
+			return "", err
+		}
+		repeat := formatRepeat(expansion)
+		if expansion.Probability != 0 {
+			repeat += " /" + strconv.FormatFloat(expansion.Probability, 'g', -1, 64) + "/"
+		}
+		return parenthesize(content+" <"+repeat+">", context == abnfRepeated), nil
+	}
+	return "", fmt.Errorf("expansion of the unknown type %T", expansion)
+}
+
+func parenthesize(text string, needed bool) string {
+	if needed {
+		return "(" + text + ")"
+	}
+	return text
+}
@@ -0,0 +1,291 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package grammar reads, writes, builds and validates the SRGS grammars that SpeechToTextV1.AddGrammar uploads, in
+// their ABNF (`application/srgs`) and XML (`application/srgs+xml`) forms. A grammar read in one form can be written in
+// the other, and Validate reports the errors that would otherwise be found only when the service analyzes the grammar.
+//
+// Grammars can also be built in code, for example from the options of a menu:
+//
+//	menu := grammar.New("en-US", "menu")
+//	menu.AddRule("menu", grammar.Public, grammar.Seq(
+//		grammar.Optional(grammar.Words("I want")),
+//		grammar.Choice(grammar.Words("billing"), grammar.Words("technical support"), grammar.Ref("agent")),
+//	))
+//	menu.AddRule("agent", grammar.Private, grammar.Choice(grammar.Words("an agent"), grammar.Words("a person")))
+//	if err := menu.Validate(); err != nil {
+//		...
+//	}
+//	content, _ := menu.Marshal(grammar.ContentTypeXML)
+package grammar
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+)
+
+// The content types of the two forms of a grammar
+const (
+	ContentTypeABNF = "application/srgs"
+	ContentTypeXML  = "application/srgs+xml"
+)
+
+// Grammar : An SRGS grammar
+type Grammar struct {
+	// The language of the grammar, such as `en-US`.
+	Language string
+
+	// The mode of the grammar. Empty means `voice`, the only mode that the service supports.
+	Mode string
+
+	// The ID of the rule that the grammar recognizes.
+	Root string
+
+	// The format of the content of tags, such as `semantics/1.0`.
+	TagFormat string
+
+	// The rules of the grammar, in order.
+	Rules []*Rule
+}
+
+// Scope : Whether a rule can be referenced from other grammars
+type Scope string
+
+// The scopes of rules
+const (
+	Private Scope = "private"
+	Public  Scope = "public"
+)
+
+// Rule : A named expansion of a grammar
+type Rule struct {
+	ID    string
+	Scope Scope
+
+	Expansion Expansion
+}
+
+// Expansion : What a rule or a part of it matches: a Token, RuleRef, Sequence, OneOf, Repeat or Tag
+type Expansion interface {
+	isExpansion()
+}
+
+// Token : A word, or words that are matched as one token, like "New York"
+type Token struct {
+	Text string
+}
+
+// RuleRef : A reference to a rule of the grammar, to a rule of another grammar by URI, or to a special rule
+type RuleRef struct {
+	// The ID of a rule of the grammar.
+	Rule string
+
+	// The URI of a rule of another grammar.
+	URI string
+
+	// One of SpecialNull, SpecialVoid and SpecialGarbage.
+	Special string
+}
+
+// The special rules
+const (
+	// Matches nothing, so that it is always matched.
+	SpecialNull = "NULL"
+
+	// Can never be matched.
+	SpecialVoid = "VOID"
+
+	// Matches any speech.
+	SpecialGarbage = "GARBAGE"
+)
+
+// Sequence : Expansions that are matched one after the other
+type Sequence []Expansion
+
+// OneOf : Alternative expansions, one of which is matched
+type OneOf []Alternative
+
+// Alternative : An expansion of a OneOf
+type Alternative struct {
+	// The relative weight of the alternative. Zero means the default weight of 1.
+	Weight float64
+
+	Expansion Expansion
+}
+
+// Unbounded is the Max of a Repeat that can be matched any number of times.
+const Unbounded = -1
+
+// Repeat : An expansion that is matched a number of times
+type Repeat struct {
+	Expansion Expansion
+
+	Min int
+
+	// The most times the expansion is matched, or Unbounded.
+	Max int
+
+	// The probability that the expansion is matched once more, between 0 and 1. Zero means it is not given.
+	Probability float64
+}
+
+// Tag : Semantic content that is returned when its position in a rule is matched
+type Tag struct {
+	Content string
+}
+
+func (Token) isExpansion()    {}
+func (RuleRef) isExpansion()  {}
+func (Sequence) isExpansion() {}
+func (OneOf) isExpansion()    {}
+func (Repeat) isExpansion()   {}
+func (Tag) isExpansion()      {}
+
+// New : Creates a grammar with no rules
+func New(language string, root string) *Grammar {
+	return &Grammar{Language: language, Root: root}
+}
+
+// AddRule : Adds a rule to the grammar and returns it
+func (grammar *Grammar) AddRule(id string, scope Scope, expansion Expansion) *Rule {
+	rule := &Rule{ID: id, Scope: scope, Expansion: expansion}
+	grammar.Rules = append(grammar.Rules, rule)
+	return rule
+}
+
+// Rule : Returns the rule with the given ID, or nil
+func (grammar *Grammar) Rule(id string) *Rule {
+	for _, rule := range grammar.Rules {
+		if rule.ID == id {
+			return rule
+		}
+	}
+	return nil
+}
+
+// Words : Returns a Token for every word of text, in a Sequence when there are several
+func Words(text string) Expansion {
+	words := strings.Fields(text)
+	if len(words) == 1 {
+		return Token{Text: words[0]}
+	}
+	sequence := make(Sequence, len(words))
+	for i, word := range words {
+		sequence[i] = Token{Text: word}
+	}
+	return sequence
+}
+
+// Seq : Returns a Sequence of the expansions
+func Seq(expansions ...Expansion) Sequence {
+	return Sequence(expansions)
+}
+
+// Choice : Returns a OneOf of the expansions, with the default weight
+func Choice(expansions ...Expansion) OneOf {
+	oneOf := make(OneOf, len(expansions))
+	for i, expansion := range expansions {
+		oneOf[i] = Alternative{Expansion: expansion}
+	}
+	return oneOf
+}
+
+// Optional : Returns a Repeat that matches the expansion at most once
+func Optional(expansion Expansion) Repeat {
+	return Repeat{Expansion: expansion, Min: 0, Max: 1}
+}
+
+// Repeated : Returns a Repeat that matches the expansion from min to max times; max can be Unbounded
+func Repeated(expansion Expansion, min int, max int) Repeat {
+	return Repeat{Expansion: expansion, Min: min, Max: max}
+}
+
+// Ref : Returns a reference to the rule of the grammar with the given ID
+func Ref(id string) RuleRef {
+	return RuleRef{Rule: id}
+}
+
+// Parse : Reads a grammar in the form given by its content type
+func Parse(reader io.Reader, contentType string) (*Grammar, error) {
+	switch mediaType(contentType) {
+	case ContentTypeABNF:
+		return ParseABNF(reader)
+	case ContentTypeXML:
+		return ParseXML(reader)
+	default:
+		return nil, fmt.Errorf("%q is not a grammar content type; use %s or %s", contentType, ContentTypeABNF, ContentTypeXML)
+	}
+}
+
+// Marshal : Writes the grammar in the form given by a content type
+func (grammar *Grammar) Marshal(contentType string) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	var err error
+	switch mediaType(contentType) {
+	case ContentTypeABNF:
+		err = grammar.WriteABNF(buffer)
+	case ContentTypeXML:
+		err = grammar.WriteXML(buffer)
+	default:
+		err = fmt.Errorf("%q is not a grammar content type; use %s or %s", contentType, ContentTypeABNF, ContentTypeXML)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func mediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
+
+// formatRepeat : Formats the bounds of a repeat as in SRGS: "n", "n-m" or "n-"
+func formatRepeat(repeat Repeat) string {
+	switch {
+	case repeat.Max == Unbounded:
+		return fmt.Sprintf("%d-", repeat.Min)
+	case repeat.Max == repeat.Min:
+		return fmt.Sprintf("%d", repeat.Min)
+	default:
+		return fmt.Sprintf("%d-%d", repeat.Min, repeat.Max)
+	}
+}
+
+// parseRepeat : Parses the bounds of a repeat as in SRGS
+func parseRepeat(bounds string) (min int, max int, err error) {
+	parts := strings.SplitN(strings.TrimSpace(bounds), "-", 2)
+	if _, err = fmt.Sscanf(parts[0], "%d", &min); err != nil || fmt.Sprint(min) != parts[0] {
+		return 0, 0, fmt.Errorf("invalid repeat %q", bounds)
+	}
+	switch {
+	case len(parts) == 1:
+		max = min
+	case parts[1] == "":
+		max = Unbounded
+	default:
+		if _, err = fmt.Sscanf(parts[1], "%d", &max); err != nil || fmt.Sprint(max) != parts[1] {
+			return 0, 0, fmt.Errorf("invalid repeat %q", bounds)
+		}
+	}
+	return min, max, nil
+}
@@ -0,0 +1,215 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grammar_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1/grammar"
+)
+
+const pizzaABNF = `#ABNF 1.0 UTF-8;
+language en-US;
+mode voice;
+root $order;
+tag-format <semantics/1.0>;
+
+// An order of one or more pizzas
+public $order = [I would like] $quantity $pizza <1-> /* then */ {out = "order"} [please];
+$quantity = /2/ one | /1/ two | "a couple of";
+$pizza = (large | small) pizza <0-1 /0.3/>;
+`
+
+var _ = Describe(`Grammar`, func() {
+	pizza := func() *grammar.Grammar {
+		return &grammar.Grammar{
+			Language:  "en-US",
+			Mode:      "voice",
+			Root:      "order",
+			TagFormat: "semantics/1.0",
+			Rules: []*grammar.Rule{
+				{ID: "order", Scope: grammar.Public, Expansion: grammar.Seq(
+					grammar.Optional(grammar.Words("I would like")),
+					grammar.Ref("quantity"),
+					grammar.Repeated(grammar.Ref("pizza"), 1, grammar.Unbounded),
+					grammar.Tag{Content: `out = "order"`},
+					grammar.Optional(grammar.Words("please")),
+				)},
+				{ID: "quantity", Scope: grammar.Private, Expansion: grammar.OneOf{
+					{Weight: 2, Expansion: grammar.Token{Text: "one"}},
+					{Weight: 1, Expansion: grammar.Token{Text: "two"}},
+					{Expansion: grammar.Token{Text: "a couple of"}},
+				}},
+				{ID: "pizza", Scope: grammar.Private, Expansion: grammar.Seq(
+					grammar.Choice(grammar.Words("large"), grammar.Words("small")),
+					grammar.Repeat{Expansion: grammar.Token{Text: "pizza"}, Min: 0, Max: 1, Probability: 0.3},
+				)},
+			},
+		}
+	}
+
+	It(`Reads the XML form and writes the ABNF form`, func() {
+		file, err := os.Open("../../resources/confirm-grammar.xml")
+		Expect(err).To(BeNil())
+		defer file.Close()
+		confirmations, err := grammar.Parse(file, "application/srgs+xml; charset=utf-8")
+		Expect(err).To(BeNil())
+		Expect(confirmations.Language).To(Equal("en-US"))
+		Expect(confirmations.Root).To(Equal("confirmations"))
+		Expect(confirmations.Rules).To(HaveLen(1))
+		Expect(confirmations.Rules[0].Expansion).To(HaveLen(12))
+		Expect(confirmations.Validate()).To(Succeed())
+
+		abnf, err := confirmations.Marshal(grammar.ContentTypeABNF)
+		Expect(err).To(BeNil())
+		Expect(string(abnf)).To(Equal(`#ABNF 1.0 UTF-8;
+language en-US;
+mode voice;
+root $confirmations;
+
+$confirmations = affirmative | nah | no | nope | yeah | yep | yes | yup | fine | negative | OK | sure;
+`))
+		parsed, err := grammar.ParseABNF(bytes.NewReader(abnf))
+		Expect(err).To(BeNil())
+		parsed.Mode = ""
+		Expect(parsed).To(Equal(confirmations))
+	})
+
+	It(`Reads the ABNF form and converts it to the XML form and back`, func() {
+		parsed, err := grammar.ParseABNF(strings.NewReader(pizzaABNF))
+		Expect(err).To(BeNil())
+		Expect(parsed).To(Equal(pizza()))
+		Expect(parsed.Validate()).To(Succeed())
+
+		xml, err := parsed.Marshal(grammar.ContentTypeXML)
+		Expect(err).To(BeNil())
+		fromXML, err := grammar.ParseXML(bytes.NewReader(xml))
+		Expect(err).To(BeNil())
+		Expect(fromXML).To(Equal(pizza()))
+
+		abnf, err := fromXML.Marshal(grammar.ContentTypeABNF)
+		Expect(err).To(BeNil())
+		Expect(string(abnf)).To(Equal(`#ABNF 1.0 UTF-8;
+language en-US;
+mode voice;
+root $order;
+tag-format <semantics/1.0>;
+
+public $order = [I would like] $quantity $pizza <1-> {out = "order"} [please];
+
+$quantity = /2/ one | /1/ two | /1/ "a couple of";
+
+$pizza = (large | small) pizza <0-1 /0.3/>;
+`))
+	})
+
+	It(`Builds grammars in code`, func() {
+		menu := grammar.New("en-US", "menu")
+		menu.AddRule("menu", grammar.Public, grammar.Seq(
+			grammar.Optional(grammar.Words("I want")),
+			grammar.Choice(grammar.Words("billing"), grammar.Words("technical support"), grammar.Ref("agent")),
+		))
+		menu.AddRule("agent", grammar.Private, grammar.Choice(grammar.Words("an agent"), grammar.Token{Text: "a person"}))
+		Expect(menu.Validate()).To(Succeed())
+		Expect(menu.Rule("agent").Scope).To(Equal(grammar.Private))
+
+		xml, err := menu.Marshal(grammar.ContentTypeXML)
+		Expect(err).To(BeNil())
+		Expect(string(xml)).To(Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<grammar xmlns="http://www.w3.org/2001/06/grammar" version="1.0" xml:lang="en-US" mode="voice" root="menu">
+  <rule id="menu" scope="public">
+    <item repeat="0-1">
+      I
+      want
+    </item>
+    <one-of>
+      <item>billing</item>
+      <item>
+        technical
+        support
+      </item>
+      <item><ruleref uri="#agent"/></item>
+    </one-of>
+  </rule>
+  <rule id="agent" scope="private">
+    <one-of>
+      <item>
+        an
+        agent
+      </item>
+      <item><token>a person</token></item>
+    </one-of>
+  </rule>
+</grammar>
+`))
+	})
+
+	table.DescribeTable(`Validate reports what the service would reject`,
+		func(abnf string, problems ...string) {
+			parsed, err := grammar.ParseABNF(strings.NewReader("#ABNF 1.0;\n" + abnf))
+			Expect(err).To(BeNil())
+			err = parsed.Validate()
+			Expect(err).To(BeAssignableToTypeOf(&grammar.ValidationError{}))
+			Expect(err.(*grammar.ValidationError).Problems).To(Equal(problems))
+		},
+		table.Entry(`no root`, `$a = yes;`, "the grammar has no root rule"),
+		table.Entry(`an undefined root`, `root $b; $a = yes;`, `the root rule "b" is not defined`),
+		table.Entry(`undefined and external references`, `root $a; $a = $b | $<other.grxml#rule>;`,
+			`rule "a" references the undefined rule "b"`,
+			`rule "a" references "other.grxml#rule" in another grammar, which is not supported`),
+		table.Entry(`rules defined twice`, `root $a; $a = yes; public $a = no;`, `rule "a" is defined more than once`),
+		table.Entry(`reserved rule IDs`, `root $a; $a = yes; $VOID = no;`, `rule ID "VOID" is reserved for a special rule`),
+		table.Entry(`DTMF grammars`, `mode dtmf; root $a; $a = 1 | 2;`, `mode "dtmf" is not supported; only voice grammars are`),
+		table.Entry(`invalid repeats`, `root $a; $a = yes <3-2> no <0-1 /1.5/>;`,
+			`rule "a" has the invalid repeat 3-2`, `rule "a" has a repeat with the invalid probability 1.5`),
+		table.Entry(`left recursion`, `root $a; $a = $b more; $b = [maybe] $a | one;`,
+			`rule "a" is left-recursive: a -> b -> a`),
+	)
+
+	It(`Allows recursion that is not on the left`, func() {
+		parsed, err := grammar.ParseABNF(strings.NewReader("#ABNF 1.0; root $digits; $digits = digit [$digits];"))
+		Expect(err).To(BeNil())
+		Expect(parsed.Validate()).To(Succeed())
+	})
+
+	table.DescribeTable(`Reports syntax errors with their position`,
+		func(contentType string, content string, message string) {
+			_, err := grammar.Parse(strings.NewReader(content), contentType)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal(message))
+		},
+		table.Entry(`an ABNF grammar without a header`, grammar.ContentTypeABNF, `root $a; $a = yes;`,
+			`invalid ABNF grammar: line 1: the grammar must start with an #ABNF header: expected '#ABNF' but found 'root'`),
+		table.Entry(`an unterminated ABNF rule`, grammar.ContentTypeABNF, "#ABNF 1.0;\nroot $a;\n$a = yes",
+			`invalid ABNF grammar: line 3: expected ';' but found the end of the grammar`),
+		table.Entry(`an ABNF language attachment`, grammar.ContentTypeABNF, "#ABNF 1.0;\n$a = oui!fr-FR;",
+			`invalid ABNF grammar: line 2: rule "a": language attachments are not supported`),
+		table.Entry(`an XML rule reference without a target`, grammar.ContentTypeXML,
+			`<grammar root="a"><rule id="a"><ruleref/></rule></grammar>`,
+			`invalid XML grammar: rule "a": a ruleref element needs either a uri or a special attribute`),
+		table.Entry(`an XML one-of with text`, grammar.ContentTypeXML,
+			`<grammar root="a"><rule id="a"><one-of>yes</one-of></rule></grammar>`,
+			`invalid XML grammar: rule "a": unexpected text "yes" in a one-of element`),
+		table.Entry(`another content type`, "text/plain", `yes`,
+			`"text/plain" is not a grammar content type; use application/srgs or application/srgs+xml`),
+	)
+})
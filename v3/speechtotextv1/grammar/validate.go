@@ -0,0 +1,276 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grammar
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/validation"
+)
+
+// ValidationError : The problems that Validate found in a grammar
+type ValidationError = validation.Error
+
+// Validate : Checks that the grammar is one the service can compile: it is a voice grammar, its root and every rule it
+// references are defined, its rule IDs are valid and unique, its expansions are well formed, it does not reference
+// other grammars, and none of its rules is left-recursive. It returns a *ValidationError that lists every problem
+// found.
+func (grammar *Grammar) Validate() error {
+	validator := &validator{grammar: grammar, rules: map[string]*Rule{}}
+	validator.validate()
+	return validator.problems.Err("grammar")
+}
+
+type validator struct {
+	grammar  *Grammar
+	rules    map[string]*Rule
+	problems validation.Problems
+}
+
+func (validator *validator) validate() {
+	grammar := validator.grammar
+	if grammar.Mode != "" && grammar.Mode != "voice" {
+		validator.problems.Add("mode %q is not supported; only voice grammars are", grammar.Mode)
+	}
+
+	for _, rule := range grammar.Rules {
+		switch {
+		case !validRuleID(rule.ID):
+			validator.problems.Add("%q is not a valid rule ID", rule.ID)
+		case rule.ID == SpecialNull || rule.ID == SpecialVoid || rule.ID == SpecialGarbage:
+			validator.problems.Add("rule ID %q is reserved for a special rule", rule.ID)
+		case validator.rules[rule.ID] != nil:
+			validator.problems.Add("rule %q is defined more than once", rule.ID)
+		}
+		if rule.Scope != "" && rule.Scope != Public && rule.Scope != Private {
+			validator.problems.Add("rule %q has the invalid scope %q", rule.ID, rule.Scope)
+		}
+		if validator.rules[rule.ID] == nil {
+			validator.rules[rule.ID] = rule
+		}
+	}
+	switch {
+	case grammar.Root == "":
+		validator.problems.Add("the grammar has no root rule")
+	case validator.rules[grammar.Root] == nil:
+		validator.problems.Add("the root rule %q is not defined", grammar.Root)
+	}
+
+	for _, rule := range grammar.Rules {
+		if isEmpty(rule.Expansion) {
+			validator.problems.Add("rule %q is empty", rule.ID)
+			continue
+		}
+		validator.validateExpansion(rule, rule.Expansion)
+	}
+	if len(validator.problems) == 0 {
+		validator.checkLeftRecursion()
+	}
+}
+
+func (validator *validator) validateExpansion(rule *Rule, expansion Expansion) {
+	switch expansion := expansion.(type) {
+	case Token:
+		if strings.TrimSpace(expansion.Text) == "" {
+			validator.problems.Add("rule %q has an empty token", rule.ID)
+		} else if strings.ContainsRune(expansion.Text, '"') {
+			validator.problems.Add("rule %q has the token %q, which contains a double quote", rule.ID, expansion.Text)
+		}
+	case RuleRef:
+		switch {
+		case expansion.Special != "":
+			if expansion.Special != SpecialNull && expansion.Special != SpecialVoid && expansion.Special != SpecialGarbage {
+				validator.problems.Add("rule %q references the unknown special rule %q", rule.ID, expansion.Special)
+			}
+		case expansion.URI != "":
+			validator.problems.Add("rule %q references %q in another grammar, which is not supported", rule.ID, expansion.URI)
+		case validator.rules[expansion.Rule] == nil:
+			validator.problems.Add("rule %q references the undefined rule %q", rule.ID, expansion.Rule)
+		}
+	case Sequence:
+		for _, element := range expansion {
+			validator.validateExpansion(rule, element)
+		}
+	case OneOf:
+		if len(expansion) == 0 {
+			validator.problems.Add("rule %q has a one-of with no alternatives", rule.ID)
+		}
+		for _, alternative := range expansion {
+			if alternative.Weight < 0 || math.IsNaN(alternative.Weight) || math.IsInf(alternative.Weight, 0) {
+				validator.problems.Add("rule %q has an alternative with the invalid weight %v", rule.ID, alternative.Weight)
+			}
+			if isEmpty(alternative.Expansion) {
+				validator.problems.Add("rule %q has an empty alternative", rule.ID)
+				continue
+			}
+			validator.validateExpansion(rule, alternative.Expansion)
+		}
+	case Repeat:
+		if expansion.Min < 0 || (expansion.Max != Unbounded && expansion.Max < expansion.Min) {
+			validator.problems.Add("rule %q has the invalid repeat %s", rule.ID, formatRepeat(expansion))
+		}
+		if expansion.Probability < 0 || expansion.Probability > 1 {
+			validator.problems.Add("rule %q has a repeat with the invalid probability %v", rule.ID, expansion.Probability)
+		}
+		if isEmpty(expansion.Expansion) {
+			validator.problems.Add("rule %q repeats nothing", rule.ID)
+			return
+		}
+		validator.validateExpansion(rule, expansion.Expansion)
+	case Tag:
+	case nil:
+		validator.problems.Add("rule %q has an empty expansion", rule.ID)
+	default:
+		validator.problems.Add("rule %q has an expansion of the unknown type %T", rule.ID, expansion)
+	}
+}
+
+// checkLeftRecursion : Reports the rules that can reference themselves before matching any token, which the service
+// cannot compile
+func (validator *validator) checkLeftRecursion() {
+	// A rule is nullable when it can match without matching a token
+	nullable := map[string]bool{}
+	for changed := true; changed; {
+		changed = false
+		for _, rule := range validator.grammar.Rules {
+			if !nullable[rule.ID] && canMatchNothing(rule.Expansion, nullable) {
+				nullable[rule.ID] = true
+				changed = true
+			}
+		}
+	}
+
+	leftRefs := map[string][]string{}
+	for _, rule := range validator.grammar.Rules {
+		leftRefs[rule.ID] = leftReferences(rule.Expansion, nullable, nil)
+	}
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	reported := map[string]bool{}
+	var visit func(id string, path []string)
+	visit = func(id string, path []string) {
+		switch state[id] {
+		case visiting:
+			for i, on := range path {
+				if on == id && !reported[id] {
+					cycle := append(append([]string(nil), path[i:]...), id)
+					for _, member := range cycle {
+						reported[member] = true
+					}
+					validator.problems.Add("rule %q is left-recursive: %s", id, strings.Join(cycle, " -> "))
+				}
+			}
+			return
+		case visited:
+			return
+		}
+		state[id] = visiting
+		for _, ref := range leftRefs[id] {
+			visit(ref, append(path, id))
+		}
+		state[id] = visited
+	}
+	for _, rule := range validator.grammar.Rules {
+		visit(rule.ID, nil)
+	}
+}
+
+// canMatchNothing : Reports whether an expansion can be matched without matching a token
+func canMatchNothing(expansion Expansion, nullable map[string]bool) bool {
+	switch expansion := expansion.(type) {
+	case Token:
+		return false
+	case RuleRef:
+		return expansion.Special == SpecialNull || nullable[expansion.Rule]
+	case Sequence:
+		for _, element := range expansion {
+			if !canMatchNothing(element, nullable) {
+				return false
+			}
+		}
+		return true
+	case OneOf:
+		for _, alternative := range expansion {
+			if canMatchNothing(alternative.Expansion, nullable) {
+				return true
+			}
+		}
+		return false
+	case Repeat:
+		return expansion.Min == 0 || canMatchNothing(expansion.Expansion, nullable)
+	default:
+		return true
+	}
+}
+
+// leftReferences : Appends the rules that an expansion can reference before matching a token
+func leftReferences(expansion Expansion, nullable map[string]bool, refs []string) []string {
+	switch expansion := expansion.(type) {
+	case RuleRef:
+		if expansion.Rule != "" && expansion.Special == "" && expansion.URI == "" {
+			refs = append(refs, expansion.Rule)
+		}
+	case Sequence:
+		for _, element := range expansion {
+			refs = leftReferences(element, nullable, refs)
+			if !canMatchNothing(element, nullable) {
+				break
+			}
+		}
+	case OneOf:
+		for _, alternative := range expansion {
+			refs = leftReferences(alternative.Expansion, nullable, refs)
+		}
+	case Repeat:
+		refs = leftReferences(expansion.Expansion, nullable, refs)
+	}
+	return refs
+}
+
+// isEmpty : Reports whether an expansion has nothing in it
+func isEmpty(expansion Expansion) bool {
+	switch expansion := expansion.(type) {
+	case nil:
+		return true
+	case Sequence:
+		return len(expansion) == 0
+	}
+	return false
+}
+
+// validRuleID : Reports whether id can name a rule in both forms of a grammar: an XML name without colons, which also
+// leaves out the characters that ABNF reserves
+func validRuleID(id string) bool {
+	if id == "" {
+		return false
+	}
+	for i, r := range id {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,464 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grammar
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The namespaces of SRGS elements and of the xml:lang attribute
+const (
+	srgsNamespace = "http://www.w3.org/2001/06/grammar"
+	xmlNamespace  = "http://www.w3.org/XML/1998/namespace"
+)
+
+// ParseXML : Reads a grammar in the XML form. Elements without a namespace are taken to be SRGS elements, as in
+// grammars written for the service without one.
+func ParseXML(reader io.Reader) (*Grammar, error) {
+	parser := &xmlParser{decoder: xml.NewDecoder(reader)}
+	grammar, err := parser.parseGrammar()
+	if err != nil {
+		return nil, fmt.Errorf("invalid XML grammar: %s", err)
+	}
+	return grammar, nil
+}
+
+type xmlParser struct {
+	decoder *xml.Decoder
+}
+
+func (parser *xmlParser) parseGrammar() (*Grammar, error) {
+	var start xml.StartElement
+	for {
+		token, err := parser.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		if element, ok := token.(xml.StartElement); ok {
+			start = element
+			break
+		}
+	}
+	if err := checkElement(start, "grammar"); err != nil {
+		return nil, err
+	}
+
+	grammar := &Grammar{}
+	for _, attr := range start.Attr {
+		switch {
+		case attr.Name.Local == "lang" && (attr.Name.Space == "xml" || attr.Name.Space == xmlNamespace):
+			grammar.Language = attr.Value
+		case attr.Name.Space != "":
+		case attr.Name.Local == "version":
+			if attr.Value != "1.0" {
+				return nil, fmt.Errorf("version %q is not supported", attr.Value)
+			}
+		case attr.Name.Local == "mode":
+			grammar.Mode = attr.Value
+		case attr.Name.Local == "root":
+			grammar.Root = attr.Value
+		case attr.Name.Local == "tag-format":
+			grammar.TagFormat = attr.Value
+		}
+	}
+
+	for {
+		token, err := parser.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.EndElement:
+			return grammar, nil
+		case xml.CharData:
+			if len(bytes.TrimSpace(token)) > 0 {
+				return nil, fmt.Errorf("unexpected text %q in the grammar element", bytes.TrimSpace(token))
+			}
+		case xml.StartElement:
+			switch token.Name.Local {
+			case "rule":
+				if err = checkElement(token, "rule"); err != nil {
+					return nil, err
+				}
+				rule := &Rule{Scope: Private}
+				for _, attr := range token.Attr {
+					switch attr.Name.Local {
+					case "id":
+						rule.ID = attr.Value
+					case "scope":
+						rule.Scope = Scope(attr.Value)
+					}
+				}
+				if rule.Expansion, err = parser.parseContent(); err != nil {
+					return nil, fmt.Errorf("rule %q: %s", rule.ID, err)
+				}
+				grammar.Rules = append(grammar.Rules, rule)
+			case "meta", "metadata":
+				if err = parser.decoder.Skip(); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, fmt.Errorf("the %s element is not supported", token.Name.Local)
+			}
+		}
+	}
+}
+
+// parseContent : Parses the content of an element up to its end, as one expansion
+func (parser *xmlParser) parseContent() (Expansion, error) {
+	var sequence Sequence
+	for {
+		token, err := parser.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.EndElement:
+			if len(sequence) == 1 {
+				return sequence[0], nil
+			}
+			return sequence, nil
+		case xml.CharData:
+			tokens, err := splitTokens(string(token))
+			if err != nil {
+				return nil, err
+			}
+			for _, text := range tokens {
+				sequence = append(sequence, Token{Text: text})
+			}
+		case xml.StartElement:
+			if err = checkElement(token, token.Name.Local); err != nil {
+				return nil, err
+			}
+			switch token.Name.Local {
+			case "token":
+				text, err := parser.parseText()
+				if err != nil {
+					return nil, err
+				}
+				sequence = append(sequence, Token{Text: strings.Join(strings.Fields(text), " ")})
+			case "tag":
+				text, err := parser.parseText()
+				if err != nil {
+					return nil, err
+				}
+				sequence = append(sequence, Tag{Content: strings.TrimSpace(text)})
+			case "ruleref":
+				ref, err := parseRuleRef(token)
+				if err != nil {
+					return nil, err
+				}
+				if err = parser.decoder.Skip(); err != nil {
+					return nil, err
+				}
+				sequence = append(sequence, ref)
+			case "item":
+				item, _, err := parser.parseItem(token)
+				if err != nil {
+					return nil, err
+				}
+				// An item without a repeat only groups its content
+				if group, ok := item.(Sequence); ok {
+					sequence = append(sequence, group...)
+				} else {
+					sequence = append(sequence, item)
+				}
+			case "one-of":
+				oneOf, err := parser.parseOneOf()
+				if err != nil {
+					return nil, err
+				}
+				sequence = append(sequence, oneOf)
+			case "example":
+				if err = parser.decoder.Skip(); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, fmt.Errorf("the %s element is not supported", token.Name.Local)
+			}
+		}
+	}
+}
+
+// parseItem : Parses an item element and returns its content, repeated as the item says, and its weight
+func (parser *xmlParser) parseItem(start xml.StartElement) (Expansion, float64, error) {
+	var weight float64
+	var repeat *Repeat
+	for _, attr := range start.Attr {
+		var err error
+		switch attr.Name.Local {
+		case "weight":
+			if weight, err = strconv.ParseFloat(strings.TrimSpace(attr.Value), 64); err != nil {
+				return nil, 0, fmt.Errorf("invalid weight %q", attr.Value)
+			}
+		case "repeat":
+			if repeat == nil {
+				repeat = &Repeat{}
+			}
+			if repeat.Min, repeat.Max, err = parseRepeat(attr.Value); err != nil {
+				return nil, 0, err
+			}
+		case "repeat-prob":
+			if repeat == nil {
+				repeat = &Repeat{}
+			}
+			if repeat.Probability, err = strconv.ParseFloat(strings.TrimSpace(attr.Value), 64); err != nil {
+				return nil, 0, fmt.Errorf("invalid repeat probability %q", attr.Value)
+			}
+		}
+	}
+	content, err := parser.parseContent()
+	if err != nil {
+		return nil, 0, err
+	}
+	if repeat == nil {
+		return content, weight, nil
+	}
+	repeat.Expansion = content
+	return *repeat, weight, nil
+}
+
+// parseOneOf : Parses the items of a one-of element
+func (parser *xmlParser) parseOneOf() (OneOf, error) {
+	oneOf := OneOf{}
+	for {
+		token, err := parser.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.EndElement:
+			return oneOf, nil
+		case xml.CharData:
+			if len(bytes.TrimSpace(token)) > 0 {
+				return nil, fmt.Errorf("unexpected text %q in a one-of element", bytes.TrimSpace(token))
+			}
+		case xml.StartElement:
+			if err = checkElement(token, "item"); err != nil {
+				return nil, fmt.Errorf("a one-of element can contain only items: %s", err)
+			}
+			content, weight, err := parser.parseItem(token)
+			if err != nil {
+				return nil, err
+			}
+			oneOf = append(oneOf, Alternative{Weight: weight, Expansion: content})
+		}
+	}
+}
+
+// parseText : Reads the text of an element that can contain nothing else
+func (parser *xmlParser) parseText() (string, error) {
+	text := new(strings.Builder)
+	for {
+		token, err := parser.decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		switch token := token.(type) {
+		case xml.EndElement:
+			return text.String(), nil
+		case xml.CharData:
+			text.Write(token)
+		case xml.StartElement:
+			return "", fmt.Errorf("unexpected %s element in text", token.Name.Local)
+		}
+	}
+}
+
+func parseRuleRef(start xml.StartElement) (RuleRef, error) {
+	var ref RuleRef
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "uri":
+			if strings.HasPrefix(attr.Value, "#") {
+				ref.Rule = attr.Value[1:]
+			} else {
+				ref.URI = attr.Value
+			}
+		case "special":
+			ref.Special = attr.Value
+		}
+	}
+	if (ref.Special == "") == (ref.Rule == "" && ref.URI == "") {
+		return ref, fmt.Errorf("a ruleref element needs either a uri or a special attribute")
+	}
+	return ref, nil
+}
+
+// checkElement : Checks that an element is the SRGS element with the given name
+func checkElement(element xml.StartElement, name string) error {
+	if element.Name.Local != name || (element.Name.Space != "" && element.Name.Space != srgsNamespace) {
+		return fmt.Errorf("expected a %s element but found %s", name, strings.TrimSpace(element.Name.Space+" "+element.Name.Local))
+	}
+	return nil
+}
+
+// splitTokens : Splits text into tokens at white space, keeping text in double quotes as one token
+func splitTokens(text string) ([]string, error) {
+	var tokens []string
+	for {
+		text = strings.TrimLeft(text, " \t\r\n")
+		if text == "" {
+			return tokens, nil
+		}
+		if text[0] == '"' {
+			end := strings.IndexByte(text[1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quoted token %s", text)
+			}
+			if token := strings.Join(strings.Fields(text[1:1+end]), " "); token != "" {
+				tokens = append(tokens, token)
+			}
+			text = text[end+2:]
+			continue
+		}
+		end := strings.IndexAny(text, " \t\r\n\"")
+		if end < 0 {
+			end = len(text)
+		}
+		tokens = append(tokens, text[:end])
+		text = text[end:]
+	}
+}
+
+// WriteXML : Writes the grammar in the XML form
+func (grammar *Grammar) WriteXML(writer io.Writer) error {
+	out := &xmlWriter{writer: bufio.NewWriter(writer)}
+	out.line(0, `<?xml version="1.0" encoding="UTF-8"?>`)
+	header := `<grammar xmlns="` + srgsNamespace + `" version="1.0" xml:lang="` + escapeXML(grammar.Language) + `"`
+	mode := grammar.Mode
+	if mode == "" {
+		mode = "voice"
+	}
+	header += ` mode="` + escapeXML(mode) + `"`
+	if grammar.Root != "" {
+		header += ` root="` + escapeXML(grammar.Root) + `"`
+	}
+	if grammar.TagFormat != "" {
+		header += ` tag-format="` + escapeXML(grammar.TagFormat) + `"`
+	}
+	out.line(0, header+">")
+	for _, rule := range grammar.Rules {
+		scope := rule.Scope
+		if scope == "" {
+			scope = Private
+		}
+		out.line(1, `<rule id="`+escapeXML(rule.ID)+`" scope="`+escapeXML(string(scope))+`">`)
+		if err := out.expansion(2, rule.Expansion); err != nil {
+			return fmt.Errorf("rule %q: %s", rule.ID, err)
+		}
+		out.line(1, "</rule>")
+	}
+	out.line(0, "</grammar>")
+	return out.writer.Flush()
+}
+
+type xmlWriter struct {
+	writer *bufio.Writer
+}
+
+func (out *xmlWriter) line(depth int, text string) {
+	_, _ = out.writer.WriteString(strings.Repeat("  ", depth) + text + "\n")
+}
+
+func (out *xmlWriter) expansion(depth int, expansion Expansion) error {
+	if leaf, ok := leafXML(expansion); ok {
+		out.line(depth, leaf)
+		return nil
+	}
+	switch expansion := expansion.(type) {
+	case Sequence:
+		if len(expansion) == 0 {
+			out.line(depth, `<ruleref special="NULL"/>`)
+		}
+		for _, element := range expansion {
+			if err := out.expansion(depth, element); err != nil {
+				return err
+			}
+		}
+	case OneOf:
+		out.line(depth, "<one-of>")
+		for _, alternative := range expansion {
+			attrs := ""
+			if alternative.Weight != 0 {
+				attrs = ` weight="` + strconv.FormatFloat(alternative.Weight, 'g', -1, 64) + `"`
+			}
+			if err := out.item(depth+1, attrs, alternative.Expansion); err != nil {
+				return err
+			}
+		}
+		out.line(depth, "</one-of>")
+	case Repeat:
+		attrs := ` repeat="` + formatRepeat(expansion) + `"`
+		if expansion.Probability != 0 {
+			attrs += ` repeat-prob="` + strconv.FormatFloat(expansion.Probability, 'g', -1, 64) + `"`
+		}
+		return out.item(depth, attrs, expansion.Expansion)
+	default:
+		return fmt.Errorf("expansion of the unknown type %T", expansion)
+	}
+	return nil
+}
+
+// item : Writes an item element, on one line when its content is a single token, reference or tag
+func (out *xmlWriter) item(depth int, attrs string, content Expansion) error {
+	if leaf, ok := leafXML(content); ok {
+		out.line(depth, "<item"+attrs+">"+leaf+"</item>")
+		return nil
+	}
+	out.line(depth, "<item"+attrs+">")
+	if err := out.expansion(depth+1, content); err != nil {
+		return err
+	}
+	out.line(depth, "</item>")
+	return nil
+}
+
+// leafXML : Returns the XML of a token, rule reference or tag
+func leafXML(expansion Expansion) (string, bool) {
+	switch expansion := expansion.(type) {
+	case Token:
+		if strings.ContainsAny(expansion.Text, " \t\r\n\"") || expansion.Text == "" {
+			return "<token>" + escapeXML(expansion.Text) + "</token>", true
+		}
+		return escapeXML(expansion.Text), true
+	case RuleRef:
+		switch {
+		case expansion.Special != "":
+			return `<ruleref special="` + escapeXML(expansion.Special) + `"/>`, true
+		case expansion.URI != "":
+			return `<ruleref uri="` + escapeXML(expansion.URI) + `"/>`, true
+		default:
+			return `<ruleref uri="#` + escapeXML(expansion.Rule) + `"/>`, true
+		}
+	case Tag:
+		return "<tag>" + escapeXML(expansion.Content) + "</tag>", true
+	}
+	return "", false
+}
+
+func escapeXML(text string) string {
+	escaped := new(strings.Builder)
+	_ = xml.EscapeText(escaped, []byte(text))
+	return escaped.String()
+}
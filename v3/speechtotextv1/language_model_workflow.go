@@ -0,0 +1,759 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1/grammar"
+)
+
+// LanguageModelSpec : The desired state of a custom language model, for ApplyLanguageModelSpec
+type LanguageModelSpec struct {
+	// The name of the model. The model is identified by its name and base model.
+	Name string
+
+	// The name of the base model.
+	BaseModelName string
+
+	// The dialect of the model, used only when the model is created.
+	Dialect string
+
+	// The description of the model, used only when the model is created.
+	Description string
+
+	// The corpora of the model, identified by name. A corpus that exists is uploaded again, replacing it, only when its
+	// content changed according to ContentHashes.
+	Corpora []CorpusSpec
+
+	// The custom words of the model, identified by word.
+	Words []WordSpec
+
+	// The grammars of the model, identified by name. A grammar that exists is uploaded again, replacing it, only when
+	// its content changed according to ContentHashes.
+	Grammars []GrammarSpec
+
+	// Records the hashes of the corpora and grammars that were uploaded, since the service does not return their
+	// content. A corpus or grammar that exists but has no recorded hash, or whose hash differs, is uploaded again. When
+	// nil, a corpus or grammar that exists is never uploaded again; give it a new name to replace its content.
+	ContentHashes ContentHashStore
+
+	// Deletes corpora, grammars and custom words that the model has but the spec does not list. Words that were added
+	// only by corpora or grammars are never deleted.
+	Prune bool
+
+	// The customization weight to train the model with.
+	CustomizationWeight *float64
+}
+
+// CorpusSpec : A corpus of a LanguageModelSpec
+type CorpusSpec struct {
+	// The name of the corpus.
+	Name string
+
+	// Opens the plain text content of the corpus. It is called to hash the content and for every upload attempt.
+	Open func() (io.ReadCloser, error)
+}
+
+// CorpusFromFile : Builds a CorpusSpec that uploads the file at path
+func CorpusFromFile(name string, path string) CorpusSpec {
+	return CorpusSpec{Name: name, Open: openFile(path)}
+}
+
+// WordSpec : A custom word of a LanguageModelSpec
+type WordSpec struct {
+	// The word as it appears in audio.
+	Word string
+
+	// The pronunciations of the word. When empty, the pronunciation the model has is kept.
+	SoundsLike []string
+
+	// The spelling of the word in transcripts. When empty, the word is spelled as is.
+	DisplayAs string
+}
+
+// GrammarSpec : A grammar of a LanguageModelSpec
+type GrammarSpec struct {
+	// The name of the grammar.
+	Name string
+
+	// The format of the grammar: `application/srgs` or `application/srgs+xml`.
+	ContentType string
+
+	// Opens the content of the grammar. It is called to validate and hash the content and for every upload attempt.
+	Open func() (io.ReadCloser, error)
+
+	// Uploads the grammar without parsing and validating it first; see grammar.Grammar.Validate.
+	SkipValidation bool
+}
+
+// GrammarFromFile : Builds a GrammarSpec that uploads the file at path
+func GrammarFromFile(name string, contentType string, path string) GrammarSpec {
+	return GrammarSpec{Name: name, ContentType: contentType, Open: openFile(path)}
+}
+
+// GrammarFromSRGS : Builds a GrammarSpec that uploads a grammar built with the grammar package, in its XML form
+func GrammarFromSRGS(name string, srgs *grammar.Grammar) GrammarSpec {
+	return GrammarSpec{
+		Name:        name,
+		ContentType: grammar.ContentTypeXML,
+		Open: func() (io.ReadCloser, error) {
+			content, err := srgs.Marshal(grammar.ContentTypeXML)
+			if err != nil {
+				return nil, err
+			}
+			return ioutil.NopCloser(bytes.NewReader(content)), nil
+		},
+	}
+}
+
+func openFile(path string) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		return os.Open(path)
+	}
+}
+
+// ContentHashStore : Remembers the SHA-256 hashes of the corpora and grammars that ApplyLanguageModelSpec uploaded, so
+// that a later run can tell whether their content changed. Keys identify the model and the resource.
+type ContentHashStore interface {
+	// ContentHash returns the hash recorded for key, or an empty string when there is none.
+	ContentHash(key string) (string, error)
+
+	// SetContentHash records the hash for key.
+	SetContentHash(key string, hash string) error
+}
+
+// NewFileContentHashStore : Instantiate a ContentHashStore that keeps the hashes in a JSON file, which is created when
+// the first hash is recorded
+func NewFileContentHashStore(path string) ContentHashStore {
+	return &fileContentHashStore{path: path}
+}
+
+type fileContentHashStore struct {
+	lock sync.Mutex
+	path string
+}
+
+func (store *fileContentHashStore) load() (map[string]string, error) {
+	hashes := make(map[string]string)
+	content, err := ioutil.ReadFile(store.path)
+	if os.IsNotExist(err) {
+		return hashes, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(content, &hashes); err != nil {
+		return nil, fmt.Errorf("%s: %s", store.path, err)
+	}
+	return hashes, nil
+}
+
+func (store *fileContentHashStore) ContentHash(key string) (string, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	hashes, err := store.load()
+	if err != nil {
+		return "", err
+	}
+	return hashes[key], nil
+}
+
+func (store *fileContentHashStore) SetContentHash(key string, hash string) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	hashes, err := store.load()
+	if err != nil {
+		return err
+	}
+	hashes[key] = hash
+	content, err := json.MarshalIndent(hashes, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(store.path, content, 0644)
+}
+
+// LanguageModelChanges : What ApplyLanguageModelSpec changed
+type LanguageModelChanges struct {
+	// The model after the changes.
+	Model *LanguageModel
+
+	// Whether the model was created.
+	Created bool
+
+	AddedCorpora    []string
+	UpdatedCorpora  []string
+	DeletedCorpora  []string
+	AddedGrammars   []string
+	UpdatedGrammars []string
+	DeletedGrammars []string
+	AddedWords      []string
+	UpdatedWords    []string
+	DeletedWords    []string
+
+	// Whether the model was trained.
+	Trained bool
+
+	// The warnings the service returned for training.
+	TrainingWarnings []TrainingWarning
+}
+
+// ApplyLanguageModelSpec : Brings a custom language model to the state described by spec and trains it. The model is
+// created if it does not exist; otherwise only the corpora, grammars and words that differ from the spec are added,
+// replaced or deleted, and the model is trained only if it changed or has untrained data, so that applying the same spec again
+// does nothing.
+//
+// The method waits for the service to analyze every corpus and grammar, retries requests that the service rejects
+// while the model is busy, and waits for training to finish.
+func (speechToText *SpeechToTextV1) ApplyLanguageModelSpec(ctx context.Context, spec *LanguageModelSpec, options *CustomizationWorkflowOptions) (*LanguageModelChanges, error) {
+	if err := core.ValidateNotNil(spec, "spec cannot be nil"); err != nil {
+		return nil, err
+	}
+	if spec.Name == "" || spec.BaseModelName == "" {
+		return nil, fmt.Errorf("the spec must name the model and its base model")
+	}
+	workflow := &languageModelWorkflow{
+		service: speechToText,
+		spec:    spec,
+		options: options.withDefaults(),
+		changes: &LanguageModelChanges{},
+	}
+	if err := workflow.run(ctx); err != nil {
+		return workflow.changes, err
+	}
+	return workflow.changes, nil
+}
+
+type languageModelWorkflow struct {
+	service         *SpeechToTextV1
+	spec            *LanguageModelSpec
+	options         CustomizationWorkflowOptions
+	changes         *LanguageModelChanges
+	customizationID string
+}
+
+func (workflow *languageModelWorkflow) run(ctx context.Context) error {
+	if err := workflow.validateGrammars(); err != nil {
+		return err
+	}
+	if err := workflow.findOrCreate(ctx); err != nil {
+		return err
+	}
+	if _, err := workflow.waitForModel(ctx); err != nil {
+		return err
+	}
+	if err := workflow.syncCorpora(ctx); err != nil {
+		return err
+	}
+	if err := workflow.syncGrammars(ctx); err != nil {
+		return err
+	}
+	if err := workflow.syncWords(ctx); err != nil {
+		return err
+	}
+
+	changes := workflow.changes
+	changed := len(changes.AddedCorpora)+len(changes.UpdatedCorpora)+len(changes.DeletedCorpora)+
+		len(changes.AddedGrammars)+len(changes.UpdatedGrammars)+len(changes.DeletedGrammars)+
+		len(changes.AddedWords)+len(changes.UpdatedWords)+len(changes.DeletedWords) > 0
+	model, err := workflow.waitForModel(ctx)
+	if err != nil {
+		return err
+	}
+	status := core.StringNilMapper(model.Status)
+	if (changed || status == LanguageModelStatusReadyConst || status == LanguageModelStatusFailedConst) &&
+		status != LanguageModelStatusPendingConst {
+		if err = workflow.train(ctx); err != nil {
+			return err
+		}
+		if model, err = workflow.waitForModel(ctx); err != nil {
+			return err
+		}
+		if core.StringNilMapper(model.Status) == LanguageModelStatusFailedConst {
+			changes.Model = model
+			return fmt.Errorf("training of custom language model %s failed: %s", workflow.customizationID,
+				core.StringNilMapper(model.Error))
+		}
+	}
+	changes.Model = model
+	return nil
+}
+
+// findOrCreate : Looks the model up by name and base model, and creates it when there is none
+func (workflow *languageModelWorkflow) findOrCreate(ctx context.Context) error {
+	service, spec := workflow.service, workflow.spec
+	listOptions := service.NewListLanguageModelsOptions()
+	if language := strings.SplitN(spec.BaseModelName, "_", 2)[0]; language != spec.BaseModelName {
+		listOptions.SetLanguage(language)
+	}
+	models, _, err := service.ListLanguageModelsWithContext(ctx, listOptions)
+	if err != nil {
+		return err
+	}
+	var matches []string
+	for _, model := range models.Customizations {
+		if core.StringNilMapper(model.Name) == spec.Name && core.StringNilMapper(model.BaseModelName) == spec.BaseModelName {
+			matches = append(matches, core.StringNilMapper(model.CustomizationID))
+		}
+	}
+	switch len(matches) {
+	case 1:
+		workflow.customizationID = matches[0]
+		return nil
+	case 0:
+	default:
+		return fmt.Errorf("%d custom language models are named %q: %s", len(matches), spec.Name, strings.Join(matches, ", "))
+	}
+
+	createOptions := service.NewCreateLanguageModelOptions(spec.Name, spec.BaseModelName)
+	if spec.Dialect != "" {
+		createOptions.SetDialect(spec.Dialect)
+	}
+	if spec.Description != "" {
+		createOptions.SetDescription(spec.Description)
+	}
+	model, _, err := service.CreateLanguageModelWithContext(ctx, createOptions)
+	if err != nil {
+		return err
+	}
+	workflow.customizationID = core.StringNilMapper(model.CustomizationID)
+	workflow.changes.Created = true
+	return nil
+}
+
+// waitForModel : Waits until the model is not training or upgrading and returns it
+func (workflow *languageModelWorkflow) waitForModel(ctx context.Context) (*LanguageModel, error) {
+	getOptions := workflow.service.NewGetLanguageModelOptions(workflow.customizationID)
+	for {
+		model, _, err := workflow.service.GetLanguageModelWithContext(ctx, getOptions)
+		if err != nil {
+			return nil, err
+		}
+		switch core.StringNilMapper(model.Status) {
+		case LanguageModelStatusTrainingConst, LanguageModelStatusUpgradingConst:
+		default:
+			return model, nil
+		}
+		if err = workflow.options.pause(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (workflow *languageModelWorkflow) syncCorpora(ctx context.Context) error {
+	service, customizationID := workflow.service, workflow.customizationID
+	corpora, _, err := service.ListCorporaWithContext(ctx, service.NewListCorporaOptions(customizationID))
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, corpus := range corpora.Corpora {
+		existing[core.StringNilMapper(corpus.Name)] = true
+	}
+	wanted := make(map[string]bool)
+	for _, corpus := range workflow.spec.Corpora {
+		wanted[corpus.Name] = true
+	}
+
+	if workflow.spec.Prune {
+		for _, name := range sortedKeys(existing) {
+			if wanted[name] {
+				continue
+			}
+			err = workflow.options.retryBusy(ctx, func() (*core.DetailedResponse, error) {
+				return service.DeleteCorpusWithContext(ctx, service.NewDeleteCorpusOptions(customizationID, name))
+			})
+			if err != nil {
+				return err
+			}
+			workflow.changes.DeletedCorpora = append(workflow.changes.DeletedCorpora, name)
+		}
+	}
+
+	for _, corpus := range workflow.spec.Corpora {
+		if existing[corpus.Name] && workflow.spec.ContentHashes == nil {
+			continue
+		}
+		if corpus.Open == nil {
+			return fmt.Errorf("corpus %q has no content", corpus.Name)
+		}
+		key := customizationID + "/corpora/" + corpus.Name
+		hash, changed, err := workflow.contentChanged(key, corpus.Open)
+		if err != nil {
+			return fmt.Errorf("corpus %q: %s", corpus.Name, err)
+		}
+		if existing[corpus.Name] && !changed {
+			continue
+		}
+		err = workflow.options.retryBusy(ctx, func() (*core.DetailedResponse, error) {
+			file, err := corpus.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer file.Close()
+			addOptions := service.NewAddCorpusOptions(customizationID, corpus.Name, file)
+			if existing[corpus.Name] {
+				addOptions.SetAllowOverwrite(true)
+			}
+			return service.AddCorpusWithContext(ctx, addOptions)
+		})
+		if err != nil {
+			return err
+		}
+		if existing[corpus.Name] {
+			workflow.changes.UpdatedCorpora = append(workflow.changes.UpdatedCorpora, corpus.Name)
+		} else {
+			workflow.changes.AddedCorpora = append(workflow.changes.AddedCorpora, corpus.Name)
+		}
+
+		// The service analyzes one corpus at a time
+		for {
+			status, _, err := service.GetCorpusWithContext(ctx, service.NewGetCorpusOptions(customizationID, corpus.Name))
+			if err != nil {
+				return err
+			}
+			if core.StringNilMapper(status.Status) == CorpusStatusAnalyzedConst {
+				break
+			}
+			if core.StringNilMapper(status.Status) == CorpusStatusUndeterminedConst {
+				return fmt.Errorf("corpus %q could not be analyzed: %s", corpus.Name, core.StringNilMapper(status.Error))
+			}
+			if err = workflow.options.pause(ctx); err != nil {
+				return err
+			}
+		}
+		if err = workflow.recordContentHash(key, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (workflow *languageModelWorkflow) syncGrammars(ctx context.Context) error {
+	service, customizationID := workflow.service, workflow.customizationID
+	grammars, _, err := service.ListGrammarsWithContext(ctx, service.NewListGrammarsOptions(customizationID))
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, grammar := range grammars.Grammars {
+		existing[core.StringNilMapper(grammar.Name)] = true
+	}
+	wanted := make(map[string]bool)
+	for _, grammar := range workflow.spec.Grammars {
+		wanted[grammar.Name] = true
+	}
+
+	if workflow.spec.Prune {
+		for _, name := range sortedKeys(existing) {
+			if wanted[name] {
+				continue
+			}
+			err = workflow.options.retryBusy(ctx, func() (*core.DetailedResponse, error) {
+				return service.DeleteGrammarWithContext(ctx, service.NewDeleteGrammarOptions(customizationID, name))
+			})
+			if err != nil {
+				return err
+			}
+			workflow.changes.DeletedGrammars = append(workflow.changes.DeletedGrammars, name)
+		}
+	}
+
+	for _, grammar := range workflow.spec.Grammars {
+		if existing[grammar.Name] && workflow.spec.ContentHashes == nil {
+			continue
+		}
+		if grammar.Open == nil {
+			return fmt.Errorf("grammar %q has no content", grammar.Name)
+		}
+		key := customizationID + "/grammars/" + grammar.Name
+		hash, changed, err := workflow.contentChanged(key, grammar.Open)
+		if err != nil {
+			return fmt.Errorf("grammar %q: %s", grammar.Name, err)
+		}
+		if existing[grammar.Name] && !changed {
+			continue
+		}
+		err = workflow.options.retryBusy(ctx, func() (*core.DetailedResponse, error) {
+			file, err := grammar.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer file.Close()
+			addOptions := service.NewAddGrammarOptions(customizationID, grammar.Name, file, grammar.ContentType)
+			if existing[grammar.Name] {
+				addOptions.SetAllowOverwrite(true)
+			}
+			return service.AddGrammarWithContext(ctx, addOptions)
+		})
+		if err != nil {
+			return err
+		}
+		if existing[grammar.Name] {
+			workflow.changes.UpdatedGrammars = append(workflow.changes.UpdatedGrammars, grammar.Name)
+		} else {
+			workflow.changes.AddedGrammars = append(workflow.changes.AddedGrammars, grammar.Name)
+		}
+
+		for {
+			status, _, err := service.GetGrammarWithContext(ctx, service.NewGetGrammarOptions(customizationID, grammar.Name))
+			if err != nil {
+				return err
+			}
+			if core.StringNilMapper(status.Status) == GrammarStatusAnalyzedConst {
+				break
+			}
+			if core.StringNilMapper(status.Status) == GrammarStatusUndeterminedConst {
+				return fmt.Errorf("grammar %q could not be analyzed: %s", grammar.Name, core.StringNilMapper(status.Error))
+			}
+			if err = workflow.options.pause(ctx); err != nil {
+				return err
+			}
+		}
+		if err = workflow.recordContentHash(key, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// contentChanged : Hashes the content of a corpus or grammar and reports whether the hash differs from the one recorded
+// when it was last uploaded. Without a ContentHashStore, content is never hashed and never counts as changed.
+func (workflow *languageModelWorkflow) contentChanged(key string, open func() (io.ReadCloser, error)) (string, bool, error) {
+	store := workflow.spec.ContentHashes
+	if store == nil {
+		return "", false, nil
+	}
+	file, err := open()
+	if err != nil {
+		return "", false, err
+	}
+	defer file.Close()
+	digest := sha256.New()
+	if _, err = io.Copy(digest, file); err != nil {
+		return "", false, err
+	}
+	hash := hex.EncodeToString(digest.Sum(nil))
+	recorded, err := store.ContentHash(key)
+	if err != nil {
+		return "", false, err
+	}
+	return hash, hash != recorded, nil
+}
+
+// recordContentHash : Records the hash of a corpus or grammar that was uploaded
+func (workflow *languageModelWorkflow) recordContentHash(key string, hash string) error {
+	if workflow.spec.ContentHashes == nil {
+		return nil
+	}
+	return workflow.spec.ContentHashes.SetContentHash(key, hash)
+}
+
+// validateGrammars : Parses and validates the grammars of the spec before the model is changed, so that a grammar the
+// service would fail to analyze is reported without uploading anything
+func (workflow *languageModelWorkflow) validateGrammars() error {
+	for _, spec := range workflow.spec.Grammars {
+		if spec.SkipValidation || spec.Open == nil {
+			continue
+		}
+		file, err := spec.Open()
+		if err != nil {
+			return fmt.Errorf("grammar %q: %s", spec.Name, err)
+		}
+		parsed, err := grammar.Parse(file, spec.ContentType)
+		file.Close()
+		if err == nil {
+			err = parsed.Validate()
+		}
+		if err != nil {
+			return fmt.Errorf("grammar %q: %s", spec.Name, err)
+		}
+	}
+	return nil
+}
+
+func (workflow *languageModelWorkflow) syncWords(ctx context.Context) error {
+	service, customizationID := workflow.service, workflow.customizationID
+	existing, err := workflow.userWords(ctx)
+	if err != nil {
+		return err
+	}
+
+	var customWords []CustomWord
+	var changed []WordSpec
+	var added, updated []string
+	wanted := make(map[string]bool)
+	for _, spec := range workflow.spec.Words {
+		wanted[spec.Word] = true
+		word, ok := existing[spec.Word]
+		if ok && wordMatchesSpec(word, spec) {
+			continue
+		}
+		customWord := CustomWord{Word: core.StringPtr(spec.Word), SoundsLike: spec.SoundsLike}
+		if spec.DisplayAs != "" {
+			customWord.DisplayAs = core.StringPtr(spec.DisplayAs)
+		}
+		customWords = append(customWords, customWord)
+		changed = append(changed, spec)
+		if ok {
+			updated = append(updated, spec.Word)
+		} else {
+			added = append(added, spec.Word)
+		}
+	}
+
+	if workflow.spec.Prune {
+		names := make(map[string]bool, len(existing))
+		for name := range existing {
+			names[name] = true
+		}
+		for _, name := range sortedKeys(names) {
+			if wanted[name] {
+				continue
+			}
+			err = workflow.options.retryBusy(ctx, func() (*core.DetailedResponse, error) {
+				return service.DeleteWordWithContext(ctx, service.NewDeleteWordOptions(customizationID, name))
+			})
+			if err != nil {
+				return err
+			}
+			workflow.changes.DeletedWords = append(workflow.changes.DeletedWords, name)
+		}
+	}
+
+	if len(customWords) == 0 {
+		return nil
+	}
+	err = workflow.options.retryBusy(ctx, func() (*core.DetailedResponse, error) {
+		return service.AddWordsWithContext(ctx, service.NewAddWordsOptions(customizationID, customWords))
+	})
+	if err != nil {
+		return err
+	}
+	workflow.changes.AddedWords = append(workflow.changes.AddedWords, added...)
+	workflow.changes.UpdatedWords = append(workflow.changes.UpdatedWords, updated...)
+
+	// Words are added asynchronously; wait until all of them are listed as specified and report the ones the service
+	// rejected
+	for {
+		if existing, err = workflow.userWords(ctx); err != nil {
+			return err
+		}
+		pending := false
+		var rejected []string
+		for _, spec := range changed {
+			word, ok := existing[spec.Word]
+			if !ok {
+				pending = true
+				continue
+			}
+			for _, wordError := range word.Error {
+				rejected = append(rejected, fmt.Sprintf("%s: %s", spec.Word, core.StringNilMapper(wordError.Element)))
+			}
+			if len(word.Error) == 0 && !wordMatchesSpec(word, spec) {
+				pending = true
+			}
+		}
+		if len(rejected) > 0 {
+			return fmt.Errorf("the service rejected custom words: %s", strings.Join(rejected, "; "))
+		}
+		if !pending {
+			return nil
+		}
+		if err = workflow.options.pause(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// userWords : Lists the words that were added to the model directly rather than by corpora or grammars
+func (workflow *languageModelWorkflow) userWords(ctx context.Context) (map[string]Word, error) {
+	service := workflow.service
+	listOptions := service.NewListWordsOptions(workflow.customizationID).SetWordType(ListWordsOptionsWordTypeUserConst)
+	words, _, err := service.ListWordsWithContext(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]Word, len(words.Words))
+	for _, word := range words.Words {
+		existing[core.StringNilMapper(word.Word)] = word
+	}
+	return existing, nil
+}
+
+// wordMatchesSpec : Reports whether a word of the model already has the pronunciations and spelling of the spec
+func wordMatchesSpec(word Word, spec WordSpec) bool {
+	displayAs := spec.DisplayAs
+	if displayAs == "" {
+		displayAs = spec.Word
+	}
+	if core.StringNilMapper(word.DisplayAs) != displayAs {
+		return false
+	}
+	if len(spec.SoundsLike) == 0 {
+		return true
+	}
+	have := append([]string(nil), word.SoundsLike...)
+	want := append([]string(nil), spec.SoundsLike...)
+	sort.Strings(have)
+	sort.Strings(want)
+	return reflect.DeepEqual(have, want)
+}
+
+func (workflow *languageModelWorkflow) train(ctx context.Context) error {
+	service := workflow.service
+	trainOptions := service.NewTrainLanguageModelOptions(workflow.customizationID)
+	if workflow.spec.CustomizationWeight != nil {
+		trainOptions.SetCustomizationWeight(*workflow.spec.CustomizationWeight)
+	}
+	var result *TrainingResponse
+	err := workflow.options.retryBusy(ctx, func() (response *core.DetailedResponse, err error) {
+		result, response, err = service.TrainLanguageModelWithContext(ctx, trainOptions)
+		return
+	})
+	if err != nil {
+		return err
+	}
+	workflow.changes.Trained = true
+	if result != nil {
+		workflow.changes.TrainingWarnings = result.Warnings
+	}
+	return nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
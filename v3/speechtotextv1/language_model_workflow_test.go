@@ -0,0 +1,375 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/fakeservice"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1/grammar"
+)
+
+// languageModelService emulates the customization interface for a single custom language model. Corpora and grammars
+// are analyzed on the first check after they are added, and training completes on the first check after it starts.
+// Added words are listed only after wordsLag listings of the words. Requests that change the model fail while busy is
+// positive.
+type languageModelService struct {
+	*fakeservice.Server
+	created  bool
+	status   string
+	corpora  map[string]string
+	grammars map[string]string
+	words    map[string]speechtotextv1.CustomWord
+	busy     int
+
+	wordsLag   int
+	lagging    int
+	addedWords []speechtotextv1.CustomWord
+}
+
+func newLanguageModelService() *languageModelService {
+	service := &languageModelService{
+		Server:   fakeservice.NewServer(),
+		corpora:  map[string]string{},
+		grammars: map[string]string{},
+		words:    map[string]speechtotextv1.CustomWord{},
+	}
+	model := func() map[string]interface{} {
+		return map[string]interface{}{"customization_id": "cust1", "name": "meetings", "base_model_name": "en-US_Telephony", "status": service.status}
+	}
+	service.Intercept(func(call *fakeservice.Call) bool {
+		if call.Method == http.MethodGet || call.URL.Path == "/v1/customizations" || service.busy == 0 {
+			return false
+		}
+		service.busy--
+		call.Error(http.StatusConflict, "The custom model is busy")
+		return true
+	})
+
+	service.Handle(http.MethodGet, "/v1/customizations", func(call *fakeservice.Call) {
+		customizations := []interface{}{}
+		if service.created {
+			customizations = append(customizations, model())
+		}
+		call.JSON(http.StatusOK, map[string]interface{}{"customizations": customizations})
+	})
+	service.Handle(http.MethodPost, "/v1/customizations", func(call *fakeservice.Call) {
+		service.created = true
+		service.status = "pending"
+		call.JSON(http.StatusCreated, model())
+	})
+	service.Handle(http.MethodGet, "/v1/customizations/{id}", func(call *fakeservice.Call) {
+		if service.status == "training" {
+			service.status = "available"
+		}
+		call.JSON(http.StatusOK, model())
+	})
+	service.Handle(http.MethodPost, "/v1/customizations/{id}/train", func(call *fakeservice.Call) {
+		service.status = "training"
+		call.JSON(http.StatusOK, map[string]interface{}{})
+	})
+
+	for kind, resources := range map[string]map[string]string{"corpora": service.corpora, "grammars": service.grammars} {
+		kind, resources := kind, resources
+		service.Handle(http.MethodGet, "/v1/customizations/{id}/"+kind, func(call *fakeservice.Call) {
+			list := []interface{}{}
+			for _, name := range sortedNames(resources) {
+				list = append(list, map[string]interface{}{"name": name, "status": resources[name], "total_words": 1, "out_of_vocabulary_words": 0})
+			}
+			call.JSON(http.StatusOK, map[string]interface{}{kind: list})
+		})
+		service.Handle(http.MethodPost, "/v1/customizations/{id}/"+kind+"/{name}", func(call *fakeservice.Call) {
+			name := call.Params["name"]
+			if _, exists := resources[name]; exists && call.URL.Query().Get("allow_overwrite") != "true" {
+				call.Error(http.StatusBadRequest, "Resource already exists")
+				return
+			}
+			content, _ := ioutil.ReadAll(call.Body)
+			if strings.Contains(string(content), "corrupt") {
+				resources[name] = "being_processed_invalid"
+			} else {
+				resources[name] = "being_processed"
+			}
+			service.status = "ready"
+			call.JSON(http.StatusCreated, map[string]interface{}{})
+		})
+		service.Handle(http.MethodGet, "/v1/customizations/{id}/"+kind+"/{name}", func(call *fakeservice.Call) {
+			name := call.Params["name"]
+			status := resources[name]
+			switch status {
+			case "being_processed":
+				resources[name] = "analyzed"
+			case "being_processed_invalid":
+				resources[name] = "undetermined"
+				status = "being_processed"
+			}
+			call.JSON(http.StatusOK, map[string]interface{}{"name": name, "status": status, "total_words": 1, "out_of_vocabulary_words": 0, "error": "Analysis of corpus failed"})
+		})
+		service.Handle(http.MethodDelete, "/v1/customizations/{id}/"+kind+"/{name}", func(call *fakeservice.Call) {
+			delete(resources, call.Params["name"])
+			call.JSON(http.StatusOK, map[string]interface{}{})
+		})
+	}
+
+	service.Handle(http.MethodGet, "/v1/customizations/{id}/words", func(call *fakeservice.Call) {
+		if service.lagging > 0 {
+			service.lagging--
+		} else {
+			for _, word := range service.addedWords {
+				service.words[*word.Word] = word
+			}
+			service.addedWords = nil
+		}
+		list := []interface{}{}
+		for _, word := range service.sortedWords() {
+			customWord := service.words[word]
+			displayAs := word
+			if customWord.DisplayAs != nil {
+				displayAs = *customWord.DisplayAs
+			}
+			soundsLike := customWord.SoundsLike
+			if soundsLike == nil {
+				soundsLike = []string{}
+			}
+			list = append(list, map[string]interface{}{"word": word, "sounds_like": soundsLike, "display_as": displayAs, "count": 1, "source": []string{"user"}})
+		}
+		call.JSON(http.StatusOK, map[string]interface{}{"words": list})
+	})
+	service.Handle(http.MethodPost, "/v1/customizations/{id}/words", func(call *fakeservice.Call) {
+		var body struct {
+			Words []speechtotextv1.CustomWord `json:"words"`
+		}
+		Expect(json.NewDecoder(call.Body).Decode(&body)).To(Succeed())
+		service.addedWords = append(service.addedWords, body.Words...)
+		service.lagging = service.wordsLag
+		service.status = "ready"
+		call.JSON(http.StatusCreated, map[string]interface{}{})
+	})
+	service.Handle(http.MethodDelete, "/v1/customizations/{id}/words/{word}", func(call *fakeservice.Call) {
+		delete(service.words, call.Params["word"])
+		call.JSON(http.StatusOK, map[string]interface{}{})
+	})
+	return service
+}
+
+func (service *languageModelService) sortedWords() []string {
+	var words []string
+	for word := range service.words {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words
+}
+
+func sortedNames(set map[string]string) []string {
+	var names []string
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func textContent(text string) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader(text)), nil
+	}
+}
+
+var _ = Describe(`ApplyLanguageModelSpec`, func() {
+	var service *languageModelService
+	var speechToText *speechtotextv1.SpeechToTextV1
+	var spec *speechtotextv1.LanguageModelSpec
+	options := &speechtotextv1.CustomizationWorkflowOptions{PollInterval: time.Millisecond}
+	BeforeEach(func() {
+		service = newLanguageModelService()
+		var err error
+		speechToText, err = speechtotextv1.NewSpeechToTextV1(&speechtotextv1.SpeechToTextV1Options{
+			URL:           service.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		spec = &speechtotextv1.LanguageModelSpec{
+			Name:          "meetings",
+			BaseModelName: "en-US_Telephony",
+			Corpora:       []speechtotextv1.CorpusSpec{{Name: "minutes", Open: textContent("the quarterly review")}},
+			Grammars: []speechtotextv1.GrammarSpec{
+				{Name: "yesno", ContentType: "application/srgs", Open: textContent("#ABNF 1.0; root $yesno; $yesno = yes | no;")},
+			},
+			Words: []speechtotextv1.WordSpec{
+				{Word: "IEEE", SoundsLike: []string{"I. triple E."}},
+				{Word: "HHonors", DisplayAs: "HHonors", SoundsLike: []string{"hilton honors"}},
+			},
+			Prune: true,
+		}
+	})
+	AfterEach(func() {
+		service.Close()
+	})
+
+	It(`Creates, populates and trains a new model`, func() {
+		service.busy = 2
+		changes, err := speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(changes.Created).To(BeTrue())
+		Expect(changes.AddedCorpora).To(Equal([]string{"minutes"}))
+		Expect(changes.AddedGrammars).To(Equal([]string{"yesno"}))
+		Expect(changes.AddedWords).To(Equal([]string{"IEEE", "HHonors"}))
+		Expect(changes.Trained).To(BeTrue())
+		Expect(*changes.Model.Status).To(Equal(speechtotextv1.LanguageModelStatusAvailableConst))
+		Expect(service.Requests()).To(Equal([]string{
+			"POST /v1/customizations",
+			"POST /v1/customizations/cust1/corpora/minutes", "POST /v1/customizations/cust1/corpora/minutes", "POST /v1/customizations/cust1/corpora/minutes",
+			"POST /v1/customizations/cust1/grammars/yesno",
+			"POST /v1/customizations/cust1/words",
+			"POST /v1/customizations/cust1/train",
+		}))
+	})
+
+	It(`Does nothing when the model matches the spec`, func() {
+		_, err := speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		service.ClearRequests()
+
+		changes, err := speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(changes.Created).To(BeFalse())
+		Expect(changes.Trained).To(BeFalse())
+		Expect(service.Requests()).To(BeEmpty())
+	})
+
+	It(`Applies only the differences`, func() {
+		_, err := speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		service.ClearRequests()
+
+		spec.Corpora = []speechtotextv1.CorpusSpec{{Name: "minutes-2", Open: textContent("the annual review")}}
+		spec.Grammars = nil
+		spec.Words = []speechtotextv1.WordSpec{
+			{Word: "IEEE", SoundsLike: []string{"I. triple E.", "eye triple e"}},
+		}
+		changes, err := speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(changes.AddedCorpora).To(Equal([]string{"minutes-2"}))
+		Expect(changes.DeletedCorpora).To(Equal([]string{"minutes"}))
+		Expect(changes.DeletedGrammars).To(Equal([]string{"yesno"}))
+		Expect(changes.UpdatedWords).To(Equal([]string{"IEEE"}))
+		Expect(changes.DeletedWords).To(Equal([]string{"HHonors"}))
+		Expect(changes.Trained).To(BeTrue())
+		Expect(service.Requests()).To(Equal([]string{
+			"DELETE /v1/customizations/cust1/corpora/minutes",
+			"POST /v1/customizations/cust1/corpora/minutes-2",
+			"DELETE /v1/customizations/cust1/grammars/yesno",
+			"DELETE /v1/customizations/cust1/words/HHonors",
+			"POST /v1/customizations/cust1/words",
+			"POST /v1/customizations/cust1/train",
+		}))
+	})
+
+	It(`Waits until updated words are listed as specified`, func() {
+		_, err := speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+
+		service.wordsLag = 2
+		spec.Words[0].SoundsLike = []string{"I. triple E.", "eye triple e"}
+		changes, err := speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(changes.UpdatedWords).To(Equal([]string{"IEEE"}))
+		Expect(service.addedWords).To(BeEmpty())
+		Expect(service.words["IEEE"].SoundsLike).To(Equal([]string{"I. triple E.", "eye triple e"}))
+	})
+
+	It(`Replaces corpora and grammars whose content changed`, func() {
+		dir, err := ioutil.TempDir("", "language-model")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		spec.ContentHashes = speechtotextv1.NewFileContentHashStore(filepath.Join(dir, "hashes.json"))
+
+		_, err = speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		service.ClearRequests()
+
+		changes, err := speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(changes.Trained).To(BeFalse())
+		Expect(service.Requests()).To(BeEmpty())
+
+		spec.Corpora[0].Open = textContent("the quarterly review and the annual review")
+		spec.Grammars[0].Open = textContent("#ABNF 1.0; root $yesno; $yesno = yes | no | maybe;")
+		changes, err = speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(changes.AddedCorpora).To(BeEmpty())
+		Expect(changes.UpdatedCorpora).To(Equal([]string{"minutes"}))
+		Expect(changes.UpdatedGrammars).To(Equal([]string{"yesno"}))
+		Expect(changes.Trained).To(BeTrue())
+		Expect(service.Requests()).To(Equal([]string{
+			"POST /v1/customizations/cust1/corpora/minutes?allow_overwrite=true",
+			"POST /v1/customizations/cust1/grammars/yesno?allow_overwrite=true",
+			"POST /v1/customizations/cust1/train",
+		}))
+
+		// Resources of an existing model that have no recorded hash are replaced once
+		service.ClearRequests()
+		spec.ContentHashes = speechtotextv1.NewFileContentHashStore(filepath.Join(dir, "other.json"))
+		changes, err = speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(changes.UpdatedCorpora).To(Equal([]string{"minutes"}))
+		Expect(changes.UpdatedGrammars).To(Equal([]string{"yesno"}))
+		service.ClearRequests()
+		_, err = speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(service.Requests()).To(BeEmpty())
+	})
+
+	It(`Validates grammars before changing the model`, func() {
+		menu := grammar.New("en-US", "menu")
+		menu.AddRule("menu", grammar.Public, grammar.Choice(grammar.Words("billing"), grammar.Words("support")))
+		spec.Grammars = []speechtotextv1.GrammarSpec{
+			speechtotextv1.GrammarFromSRGS("menu", menu),
+			{Name: "broken", ContentType: "application/srgs", Open: textContent("#ABNF 1.0; root $menu; $menu = $options;")},
+		}
+		_, err := speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal(`grammar "broken": invalid grammar: rule "menu" references the undefined rule "options"`))
+		Expect(service.Requests()).To(BeEmpty())
+
+		spec.Grammars = spec.Grammars[:1]
+		changes, err := speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).To(BeNil())
+		Expect(changes.AddedGrammars).To(Equal([]string{"menu"}))
+	})
+
+	It(`Reports corpora that the service cannot analyze`, func() {
+		spec.Corpora = []speechtotextv1.CorpusSpec{{Name: "broken", Open: textContent("corrupt")}}
+		_, err := speechToText.ApplyLanguageModelSpec(context.Background(), spec, options)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring(`corpus "broken" could not be analyzed`))
+	})
+})
@@ -0,0 +1,256 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/jsontuple"
+)
+
+// WordTimestamp : The start and end time of a word, in seconds from the start of the audio. It is encoded as the
+// `["word", start, end]` tuple that the service returns in the `timestamps` field of an alternative.
+type WordTimestamp struct {
+	Word      string
+	StartTime float64
+	EndTime   float64
+}
+
+// MarshalJSON : Encodes the word timestamp as a `["word", start, end]` tuple
+func (timestamp WordTimestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{timestamp.Word, timestamp.StartTime, timestamp.EndTime})
+}
+
+// UnmarshalJSON : Decodes a `["word", start, end]` tuple
+func (timestamp *WordTimestamp) UnmarshalJSON(data []byte) error {
+	return jsontuple.Unmarshal(data, "word timestamp", &timestamp.Word, &timestamp.StartTime, &timestamp.EndTime)
+}
+
+// WordConfidence : The confidence score of a word, between 0.0 and 1.0. It is encoded as the `["word", confidence]`
+// tuple that the service returns in the `word_confidence` field of an alternative.
+type WordConfidence struct {
+	Word       string
+	Confidence float64
+}
+
+// MarshalJSON : Encodes the word confidence as a `["word", confidence]` tuple
+func (confidence WordConfidence) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{confidence.Word, confidence.Confidence})
+}
+
+// UnmarshalJSON : Decodes a `["word", confidence]` tuple
+func (confidence *WordConfidence) UnmarshalJSON(data []byte) error {
+	return jsontuple.Unmarshal(data, "word confidence", &confidence.Word, &confidence.Confidence)
+}
+
+// GetWordTimestamps : Returns the word timestamps of the alternative, or nil if they were not requested
+func (alternative *SpeechRecognitionAlternative) GetWordTimestamps() ([]WordTimestamp, error) {
+	var timestamps []WordTimestamp
+	if err := convertGeneric(alternative.Timestamps, &timestamps); err != nil {
+		return nil, fmt.Errorf("invalid timestamps: %s", err)
+	}
+	return timestamps, nil
+}
+
+// SetWordTimestamps : Replaces the word timestamps of the alternative. They are stored in the generic form that
+// decoding a service response produces.
+func (alternative *SpeechRecognitionAlternative) SetWordTimestamps(timestamps []WordTimestamp) {
+	if timestamps == nil {
+		alternative.Timestamps = nil
+		return
+	}
+	generic := make([]interface{}, len(timestamps))
+	for i, timestamp := range timestamps {
+		generic[i] = []interface{}{timestamp.Word, timestamp.StartTime, timestamp.EndTime}
+	}
+	alternative.Timestamps = generic
+}
+
+// GetWordConfidences : Returns the word confidence scores of the alternative, or nil if they were not requested
+func (alternative *SpeechRecognitionAlternative) GetWordConfidences() ([]WordConfidence, error) {
+	var confidences []WordConfidence
+	if err := convertGeneric(alternative.WordConfidence, &confidences); err != nil {
+		return nil, fmt.Errorf("invalid word_confidence: %s", err)
+	}
+	return confidences, nil
+}
+
+// SetWordConfidences : Replaces the word confidence scores of the alternative. They are stored in the generic form
+// that decoding a service response produces.
+func (alternative *SpeechRecognitionAlternative) SetWordConfidences(confidences []WordConfidence) {
+	if confidences == nil {
+		alternative.WordConfidence = nil
+		return
+	}
+	generic := make([]interface{}, len(confidences))
+	for i, confidence := range confidences {
+		generic[i] = []interface{}{confidence.Word, confidence.Confidence}
+	}
+	alternative.WordConfidence = generic
+}
+
+// convertGeneric : Converts a value decoded into interface{} to a typed value by way of its JSON encoding
+func convertGeneric(value interface{}, target interface{}) error {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// SpeakerLabel : A speaker label with its required fields checked and dereferenced
+type SpeakerLabel struct {
+	From       float64 `json:"from"`
+	To         float64 `json:"to"`
+	Speaker    int64   `json:"speaker"`
+	Confidence float64 `json:"confidence"`
+	Final      bool    `json:"final"`
+}
+
+// GetSpeakerLabel : Returns the speaker label, or an error if a required field is missing
+func (speakerLabel *SpeakerLabelsResult) GetSpeakerLabel() (SpeakerLabel, error) {
+	if speakerLabel.From == nil || speakerLabel.To == nil || speakerLabel.Speaker == nil ||
+		speakerLabel.Confidence == nil || speakerLabel.Final == nil {
+		return SpeakerLabel{}, fmt.Errorf("speaker label is missing a required field")
+	}
+	return SpeakerLabel{
+		From:       widenFloat32(*speakerLabel.From),
+		To:         widenFloat32(*speakerLabel.To),
+		Speaker:    *speakerLabel.Speaker,
+		Confidence: widenFloat32(*speakerLabel.Confidence),
+		Final:      *speakerLabel.Final,
+	}, nil
+}
+
+// widenFloat32 : Converts a float32 to the float64 with the same shortest decimal representation, so that 0.1 stays
+// 0.1 rather than becoming 0.10000000149011612
+func widenFloat32(value float32) float64 {
+	widened, _ := strconv.ParseFloat(strconv.FormatFloat(float64(value), 'g', -1, 32), 64)
+	return widened
+}
+
+// GetSpeakerLabels : Returns the speaker labels of the results, or nil if they were not requested
+func (results *SpeechRecognitionResults) GetSpeakerLabels() ([]SpeakerLabel, error) {
+	if results.SpeakerLabels == nil {
+		return nil, nil
+	}
+	speakerLabels := make([]SpeakerLabel, len(results.SpeakerLabels))
+	for i := range results.SpeakerLabels {
+		speakerLabel, err := results.SpeakerLabels[i].GetSpeakerLabel()
+		if err != nil {
+			return nil, fmt.Errorf("speaker_labels[%d]: %s", i, err)
+		}
+		speakerLabels[i] = speakerLabel
+	}
+	return speakerLabels, nil
+}
+
+// KeywordMatch : A keyword match with its required fields checked and dereferenced
+type KeywordMatch struct {
+	NormalizedText string  `json:"normalized_text"`
+	StartTime      float64 `json:"start_time"`
+	EndTime        float64 `json:"end_time"`
+	Confidence     float64 `json:"confidence"`
+}
+
+// GetKeywordMatch : Returns the keyword match, or an error if a required field is missing
+func (keywordResult *KeywordResult) GetKeywordMatch() (KeywordMatch, error) {
+	if keywordResult.NormalizedText == nil || keywordResult.StartTime == nil || keywordResult.EndTime == nil ||
+		keywordResult.Confidence == nil {
+		return KeywordMatch{}, fmt.Errorf("keyword result is missing a required field")
+	}
+	return KeywordMatch{
+		NormalizedText: *keywordResult.NormalizedText,
+		StartTime:      *keywordResult.StartTime,
+		EndTime:        *keywordResult.EndTime,
+		Confidence:     *keywordResult.Confidence,
+	}, nil
+}
+
+// GetKeywordMatches : Returns the keyword matches of the result by keyword, or nil if keywords were not requested
+func (result *SpeechRecognitionResult) GetKeywordMatches() (map[string][]KeywordMatch, error) {
+	if result.KeywordsResult == nil {
+		return nil, nil
+	}
+	matches := make(map[string][]KeywordMatch, len(result.KeywordsResult))
+	for keyword, keywordResults := range result.KeywordsResult {
+		keywordMatches := make([]KeywordMatch, len(keywordResults))
+		for i := range keywordResults {
+			match, err := keywordResults[i].GetKeywordMatch()
+			if err != nil {
+				return nil, fmt.Errorf("keywords_result[%q][%d]: %s", keyword, i, err)
+			}
+			keywordMatches[i] = match
+		}
+		matches[keyword] = keywordMatches
+	}
+	return matches, nil
+}
+
+// WordAlternativeSpan : The alternative hypotheses for a span of audio, with the required fields checked and
+// dereferenced
+type WordAlternativeSpan struct {
+	StartTime    float64                 `json:"start_time"`
+	EndTime      float64                 `json:"end_time"`
+	Alternatives []WordAlternativeChoice `json:"alternatives"`
+}
+
+// WordAlternativeChoice : One hypothesis of a WordAlternativeSpan
+type WordAlternativeChoice struct {
+	Confidence float64 `json:"confidence"`
+	Word       string  `json:"word"`
+}
+
+// GetWordAlternativeSpan : Returns the word alternatives, or an error if a required field is missing
+func (wordAlternatives *WordAlternativeResults) GetWordAlternativeSpan() (WordAlternativeSpan, error) {
+	if wordAlternatives.StartTime == nil || wordAlternatives.EndTime == nil || wordAlternatives.Alternatives == nil {
+		return WordAlternativeSpan{}, fmt.Errorf("word alternatives are missing a required field")
+	}
+	span := WordAlternativeSpan{
+		StartTime:    *wordAlternatives.StartTime,
+		EndTime:      *wordAlternatives.EndTime,
+		Alternatives: make([]WordAlternativeChoice, len(wordAlternatives.Alternatives)),
+	}
+	for i, alternative := range wordAlternatives.Alternatives {
+		if alternative.Confidence == nil || alternative.Word == nil {
+			return WordAlternativeSpan{}, fmt.Errorf("word alternative %d is missing a required field", i)
+		}
+		span.Alternatives[i] = WordAlternativeChoice{Confidence: *alternative.Confidence, Word: *alternative.Word}
+	}
+	return span, nil
+}
+
+// GetWordAlternativeSpans : Returns the word alternatives of the result, or nil if they were not requested
+func (result *SpeechRecognitionResult) GetWordAlternativeSpans() ([]WordAlternativeSpan, error) {
+	if result.WordAlternatives == nil {
+		return nil, nil
+	}
+	spans := make([]WordAlternativeSpan, len(result.WordAlternatives))
+	for i := range result.WordAlternatives {
+		span, err := result.WordAlternatives[i].GetWordAlternativeSpan()
+		if err != nil {
+			return nil, fmt.Errorf("word_alternatives[%d]: %s", i, err)
+		}
+		spans[i] = span
+	}
+	return spans, nil
+}
@@ -0,0 +1,201 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"encoding/json"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+)
+
+// The response of the Recognize operation tests
+const generatedRecognizeFixture = `{"results": [{"final": false, "alternatives": [{"transcript": "Transcript", "confidence": 0, "timestamps": ["Timestamps"], "word_confidence": ["WordConfidence"]}], "keywords_result": {"mapKey": [{"normalized_text": "NormalizedText", "start_time": 9, "end_time": 7, "confidence": 0}]}, "word_alternatives": [{"start_time": 9, "end_time": 7, "alternatives": [{"confidence": 0, "word": "Word"}]}], "end_of_utterance": "end_of_data"}], "result_index": 11, "speaker_labels": [{"from": 4, "to": 2, "speaker": 7, "confidence": 10, "final": false}]}`
+
+// A response as the service returns it with timestamps, word confidence, keywords, word alternatives and speaker
+// labels requested
+const serviceRecognizeFixture = `{
+	"result_index": 0,
+	"results": [{
+		"final": true,
+		"alternatives": [{
+			"transcript": "several tornadoes touch down ",
+			"confidence": 0.96,
+			"timestamps": [["several", 1.0, 1.51], ["tornadoes", 1.51, 2.15], ["touch", 2.15, 2.5], ["down", 2.5, 2.82]],
+			"word_confidence": [["several", 1.0], ["tornadoes", 0.91], ["touch", 1.0], ["down", 0.98]]
+		}],
+		"keywords_result": {
+			"tornadoes": [{"normalized_text": "tornadoes", "start_time": 1.51, "end_time": 2.15, "confidence": 0.91}]
+		},
+		"word_alternatives": [
+			{"start_time": 1.51, "end_time": 2.15, "alternatives": [{"confidence": 0.91, "word": "tornadoes"}, {"confidence": 0.09, "word": "tornados"}]}
+		]
+	}],
+	"speaker_labels": [
+		{"from": 1.0, "to": 1.51, "speaker": 0, "confidence": 0.55, "final": true},
+		{"from": 1.51, "to": 2.15, "speaker": 1, "confidence": 0.3, "final": true}
+	]
+}`
+
+func unmarshalRecognizeFixture(fixture string) *speechtotextv1.SpeechRecognitionResults {
+	var raw map[string]json.RawMessage
+	Expect(json.Unmarshal([]byte(fixture), &raw)).To(Succeed())
+	var results *speechtotextv1.SpeechRecognitionResults
+	Expect(speechtotextv1.UnmarshalSpeechRecognitionResults(raw, &results)).To(Succeed())
+	return results
+}
+
+// fixtureField extracts a nested field of a fixture as JSON, following object keys and array indices
+func fixtureField(fixture string, path ...interface{}) string {
+	var value interface{}
+	Expect(json.Unmarshal([]byte(fixture), &value)).To(Succeed())
+	for _, step := range path {
+		switch key := step.(type) {
+		case string:
+			value = value.(map[string]interface{})[key]
+		case int:
+			value = value.([]interface{})[key]
+		}
+	}
+	data, err := json.Marshal(value)
+	Expect(err).To(BeNil())
+	return string(data)
+}
+
+func marshalJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	Expect(err).To(BeNil())
+	return string(data)
+}
+
+var _ = Describe(`Typed recognition result accessors`, func() {
+	Describe(`With a service response`, func() {
+		var results *speechtotextv1.SpeechRecognitionResults
+		BeforeEach(func() {
+			results = unmarshalRecognizeFixture(serviceRecognizeFixture)
+		})
+
+		It(`Decodes word timestamps and confidences`, func() {
+			alternative := &results.Results[0].Alternatives[0]
+			timestamps, err := alternative.GetWordTimestamps()
+			Expect(err).To(BeNil())
+			Expect(timestamps).To(HaveLen(4))
+			Expect(timestamps[1]).To(Equal(speechtotextv1.WordTimestamp{Word: "tornadoes", StartTime: 1.51, EndTime: 2.15}))
+
+			confidences, err := alternative.GetWordConfidences()
+			Expect(err).To(BeNil())
+			Expect(confidences[3]).To(Equal(speechtotextv1.WordConfidence{Word: "down", Confidence: 0.98}))
+		})
+
+		It(`Round-trips every typed field through JSON`, func() {
+			alternative := &results.Results[0].Alternatives[0]
+			timestamps, err := alternative.GetWordTimestamps()
+			Expect(err).To(BeNil())
+			Expect(marshalJSON(timestamps)).To(MatchJSON(fixtureField(serviceRecognizeFixture, "results", 0, "alternatives", 0, "timestamps")))
+
+			confidences, err := alternative.GetWordConfidences()
+			Expect(err).To(BeNil())
+			Expect(marshalJSON(confidences)).To(MatchJSON(fixtureField(serviceRecognizeFixture, "results", 0, "alternatives", 0, "word_confidence")))
+
+			keywords, err := results.Results[0].GetKeywordMatches()
+			Expect(err).To(BeNil())
+			Expect(marshalJSON(keywords)).To(MatchJSON(fixtureField(serviceRecognizeFixture, "results", 0, "keywords_result")))
+
+			spans, err := results.Results[0].GetWordAlternativeSpans()
+			Expect(err).To(BeNil())
+			Expect(marshalJSON(spans)).To(MatchJSON(fixtureField(serviceRecognizeFixture, "results", 0, "word_alternatives")))
+
+			speakerLabels, err := results.GetSpeakerLabels()
+			Expect(err).To(BeNil())
+			Expect(marshalJSON(speakerLabels)).To(MatchJSON(fixtureField(serviceRecognizeFixture, "speaker_labels")))
+		})
+
+		It(`Stores typed timestamps and confidences in the decoded form`, func() {
+			alternative := &results.Results[0].Alternatives[0]
+			original := marshalJSON(alternative)
+
+			timestamps, _ := alternative.GetWordTimestamps()
+			confidences, _ := alternative.GetWordConfidences()
+			alternative.SetWordTimestamps(timestamps)
+			alternative.SetWordConfidences(confidences)
+			Expect(marshalJSON(alternative)).To(MatchJSON(original))
+			Expect(alternative.Timestamps.([]interface{})[0]).To(Equal([]interface{}{"several", 1.0, 1.51}))
+
+			alternative.SetWordTimestamps(nil)
+			Expect(alternative.Timestamps).To(BeNil())
+		})
+	})
+
+	Describe(`With the generated fixture`, func() {
+		var results *speechtotextv1.SpeechRecognitionResults
+		BeforeEach(func() {
+			results = unmarshalRecognizeFixture(generatedRecognizeFixture)
+		})
+
+		It(`Rejects timestamps and confidences that are not tuples`, func() {
+			_, err := results.Results[0].Alternatives[0].GetWordTimestamps()
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("invalid timestamps"))
+
+			_, err = results.Results[0].Alternatives[0].GetWordConfidences()
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("invalid word_confidence"))
+		})
+
+		It(`Round-trips keywords, word alternatives and speaker labels through JSON`, func() {
+			keywords, err := results.Results[0].GetKeywordMatches()
+			Expect(err).To(BeNil())
+			Expect(marshalJSON(keywords)).To(MatchJSON(fixtureField(generatedRecognizeFixture, "results", 0, "keywords_result")))
+
+			spans, err := results.Results[0].GetWordAlternativeSpans()
+			Expect(err).To(BeNil())
+			Expect(marshalJSON(spans)).To(MatchJSON(fixtureField(generatedRecognizeFixture, "results", 0, "word_alternatives")))
+
+			speakerLabels, err := results.GetSpeakerLabels()
+			Expect(err).To(BeNil())
+			Expect(marshalJSON(speakerLabels)).To(MatchJSON(fixtureField(generatedRecognizeFixture, "speaker_labels")))
+		})
+	})
+
+	It(`Returns nil for fields that were not requested`, func() {
+		result := &speechtotextv1.SpeechRecognitionResult{
+			Final:        core.BoolPtr(true),
+			Alternatives: []speechtotextv1.SpeechRecognitionAlternative{{Transcript: core.StringPtr("hello")}},
+		}
+		timestamps, err := result.Alternatives[0].GetWordTimestamps()
+		Expect(err).To(BeNil())
+		Expect(timestamps).To(BeNil())
+		keywords, err := result.GetKeywordMatches()
+		Expect(err).To(BeNil())
+		Expect(keywords).To(BeNil())
+		spans, err := result.GetWordAlternativeSpans()
+		Expect(err).To(BeNil())
+		Expect(spans).To(BeNil())
+	})
+
+	It(`Reports missing required fields`, func() {
+		speakerLabel := &speechtotextv1.SpeakerLabelsResult{From: core.Float32Ptr(1)}
+		_, err := speakerLabel.GetSpeakerLabel()
+		Expect(err).ToNot(BeNil())
+
+		keyword := &speechtotextv1.KeywordResult{NormalizedText: core.StringPtr("tornadoes")}
+		_, err = keyword.GetKeywordMatch()
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,323 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// RecognitionJobManagerOptions : Controls how a RecognitionJobManager submits and polls jobs. Zero values select the
+// defaults.
+type RecognitionJobManagerOptions struct {
+	// The maximum number of requests to the service that the manager makes at the same time, across all of its jobs.
+	// Defaults to 4.
+	Workers int
+
+	// The delay before a job is first checked, doubled after every check that finds it unfinished. Defaults to 2
+	// seconds.
+	InitialPollInterval time.Duration
+
+	// The upper bound of the delay between checks. Defaults to 30 seconds.
+	MaxPollInterval time.Duration
+
+	// Deletes jobs once their results have been retrieved, instead of leaving them on the service until their time to
+	// live expires.
+	DeleteCompleted bool
+
+	// The number of consecutive checks of a job that may fail with a network error or a 429 or 5xx status before
+	// waiting for the job fails. Failed checks are retried after the poll interval, which keeps doubling. Defaults to 5.
+	MaxCheckRetries int
+}
+
+func (options *RecognitionJobManagerOptions) withDefaults() RecognitionJobManagerOptions {
+	withDefaults := RecognitionJobManagerOptions{}
+	if options != nil {
+		withDefaults = *options
+	}
+	if withDefaults.Workers <= 0 {
+		withDefaults.Workers = 4
+	}
+	if withDefaults.InitialPollInterval <= 0 {
+		withDefaults.InitialPollInterval = 2 * time.Second
+	}
+	if withDefaults.MaxPollInterval <= 0 {
+		withDefaults.MaxPollInterval = 30 * time.Second
+	}
+	if withDefaults.MaxPollInterval < withDefaults.InitialPollInterval {
+		withDefaults.MaxPollInterval = withDefaults.InitialPollInterval
+	}
+	if withDefaults.MaxCheckRetries <= 0 {
+		withDefaults.MaxCheckRetries = 5
+	}
+	return withDefaults
+}
+
+// RecognitionJobFailedError : The service reported a job as failed
+type RecognitionJobFailedError struct {
+	ID       string
+	Warnings []string
+}
+
+func (err *RecognitionJobFailedError) Error() string {
+	if len(err.Warnings) == 0 {
+		return fmt.Sprintf("recognition job %s failed", err.ID)
+	}
+	return fmt.Sprintf("recognition job %s failed: %s", err.ID, strings.Join(err.Warnings, "; "))
+}
+
+// RecognitionJobManager : Submits asynchronous recognition jobs and waits for their results. The manager polls every
+// job with an exponential backoff, and can be told about job events received on a callback URL with Notify so that
+// the results are retrieved without waiting for the next check. Jobs are tracked until a check finds them completed
+// or failed. A manager is safe for concurrent use by many jobs.
+type RecognitionJobManager struct {
+	service *SpeechToTextV1
+	options RecognitionJobManagerOptions
+	workers chan struct{}
+
+	lock sync.Mutex
+	jobs map[string]*RecognitionJobHandle
+}
+
+// RecognitionJobHandle : A job submitted through a RecognitionJobManager
+type RecognitionJobHandle struct {
+	manager *RecognitionJobManager
+	wake    chan struct{}
+
+	// The ID of the job.
+	ID string
+
+	lock sync.Mutex
+	job  *RecognitionJob
+}
+
+// NewRecognitionJobManager : Instantiate a RecognitionJobManager for the service
+func NewRecognitionJobManager(speechToText *SpeechToTextV1, options *RecognitionJobManagerOptions) *RecognitionJobManager {
+	settings := options.withDefaults()
+	return &RecognitionJobManager{
+		service: speechToText,
+		options: settings,
+		workers: make(chan struct{}, settings.Workers),
+		jobs:    make(map[string]*RecognitionJobHandle),
+	}
+}
+
+// acquire : Waits for a free worker. The worker must be given back with release.
+func (manager *RecognitionJobManager) acquire(ctx context.Context) error {
+	select {
+	case manager.workers <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (manager *RecognitionJobManager) release() {
+	<-manager.workers
+}
+
+// Submit : Creates a job and returns a handle to wait for it
+func (manager *RecognitionJobManager) Submit(ctx context.Context, createJobOptions *CreateJobOptions) (*RecognitionJobHandle, error) {
+	if err := manager.acquire(ctx); err != nil {
+		return nil, err
+	}
+	job, _, err := manager.service.CreateJobWithContext(ctx, createJobOptions)
+	manager.release()
+	if err != nil {
+		return nil, err
+	}
+	return manager.Track(job)
+}
+
+// Track : Returns a handle for a job that was created elsewhere, for example by another process
+func (manager *RecognitionJobManager) Track(job *RecognitionJob) (*RecognitionJobHandle, error) {
+	if job == nil || job.ID == nil {
+		return nil, fmt.Errorf("the job has no ID")
+	}
+	handle := &RecognitionJobHandle{
+		manager: manager,
+		wake:    make(chan struct{}, 1),
+		ID:      *job.ID,
+		job:     job,
+	}
+	manager.lock.Lock()
+	manager.jobs[handle.ID] = handle
+	manager.lock.Unlock()
+	return handle, nil
+}
+
+// Run : Creates a job and waits for its results
+func (manager *RecognitionJobManager) Run(ctx context.Context, createJobOptions *CreateJobOptions) (*SpeechRecognitionResults, error) {
+	handle, err := manager.Submit(ctx, createJobOptions)
+	if err != nil {
+		return nil, err
+	}
+	return handle.Wait(ctx)
+}
+
+// Notify : Makes a job that is being waited for check its status immediately. Notifications for jobs that the
+// manager does not track are ignored.
+func (manager *RecognitionJobManager) Notify(id string) {
+	manager.lock.Lock()
+	handle := manager.jobs[id]
+	manager.lock.Unlock()
+	if handle == nil {
+		return
+	}
+	select {
+	case handle.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Jobs : Returns the IDs of the jobs that the manager tracks, in no particular order
+func (manager *RecognitionJobManager) Jobs() []string {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+	ids := make([]string, 0, len(manager.jobs))
+	for id := range manager.jobs {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (manager *RecognitionJobManager) forget(id string) {
+	manager.lock.Lock()
+	delete(manager.jobs, id)
+	manager.lock.Unlock()
+}
+
+// Job : Returns the status of the job as of the last check
+func (handle *RecognitionJobHandle) Job() *RecognitionJob {
+	handle.lock.Lock()
+	defer handle.lock.Unlock()
+	return handle.job
+}
+
+// Wait : Polls the job until it completes and returns its results merged into a single SpeechRecognitionResults. A
+// failed job is reported as a *RecognitionJobFailedError. Checks that fail with a network error or a 429 or 5xx status
+// are retried up to MaxCheckRetries times in a row. The job keeps running on the service when the context is done
+// first.
+func (handle *RecognitionJobHandle) Wait(ctx context.Context) (*SpeechRecognitionResults, error) {
+	manager := handle.manager
+	interval := manager.options.InitialPollInterval
+	job := handle.Job()
+	// Only CheckJob returns the results; a job from CreateJob or Track is checked once more when it has completed.
+	checked := false
+	failures := 0
+	for {
+		status := core.StringNilMapper(job.Status)
+		switch {
+		case status == RecognitionJobStatusCompletedConst && checked:
+			manager.forget(handle.ID)
+			results := MergeSpeechRecognitionResults(job.Results...)
+			if manager.options.DeleteCompleted {
+				if err := handle.Delete(ctx); err != nil {
+					return results, err
+				}
+			}
+			return results, nil
+		case status == RecognitionJobStatusFailedConst:
+			manager.forget(handle.ID)
+			return nil, &RecognitionJobFailedError{ID: handle.ID, Warnings: job.Warnings}
+		}
+
+		if status != RecognitionJobStatusCompletedConst || failures > 0 {
+			timer := time.NewTimer(interval)
+			select {
+			case <-timer.C:
+			case <-handle.wake:
+				timer.Stop()
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			}
+			if interval *= 2; interval > manager.options.MaxPollInterval {
+				interval = manager.options.MaxPollInterval
+			}
+		}
+
+		next, transient, err := handle.check(ctx)
+		if err != nil {
+			if !transient || failures >= manager.options.MaxCheckRetries {
+				return nil, err
+			}
+			failures++
+			continue
+		}
+		job, checked, failures = next, true, 0
+	}
+}
+
+// check : Retrieves the status of the job, and stops tracking it once it has completed or failed, or the service no
+// longer has it. Errors that a later
+// check may not run into are flagged transient.
+func (handle *RecognitionJobHandle) check(ctx context.Context) (job *RecognitionJob, transient bool, err error) {
+	manager := handle.manager
+	if err = manager.acquire(ctx); err != nil {
+		return
+	}
+	job, response, err := manager.service.CheckJobWithContext(ctx, manager.service.NewCheckJobOptions(handle.ID))
+	manager.release()
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			manager.forget(handle.ID)
+		}
+		return nil, isTransientFailure(ctx, response, err), err
+	}
+	handle.lock.Lock()
+	handle.job = job
+	handle.lock.Unlock()
+	switch core.StringNilMapper(job.Status) {
+	case RecognitionJobStatusCompletedConst, RecognitionJobStatusFailedConst:
+		manager.forget(handle.ID)
+	}
+	return job, false, nil
+}
+
+// isTransientFailure : Reports whether a request failed because of the network or of a 429 or 5xx status, rather than
+// because of the request or the context
+func isTransientFailure(ctx context.Context, response *core.DetailedResponse, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if response == nil {
+		var netErr net.Error
+		return errors.As(err, &netErr)
+	}
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError
+}
+
+// Delete : Deletes the job and its results from the service
+func (handle *RecognitionJobHandle) Delete(ctx context.Context) error {
+	manager := handle.manager
+	if err := manager.acquire(ctx); err != nil {
+		return err
+	}
+	_, err := manager.service.DeleteJobWithContext(ctx, manager.service.NewDeleteJobOptions(handle.ID))
+	manager.release()
+	manager.forget(handle.ID)
+	return err
+}
@@ -0,0 +1,234 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/fakeservice"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+)
+
+// jobService emulates the asynchronous interface. Every job is reported as processing for a number of checks and
+// then takes its final status. Checks of a job fail with the statuses queued in failures first.
+type jobService struct {
+	*fakeservice.Server
+	checks    int
+	statuses  map[string]string
+	remaining map[string]int
+	failures  map[string][]int
+	deleted   []string
+}
+
+func newJobService() *jobService {
+	service := &jobService{
+		Server:    fakeservice.NewServer(),
+		statuses:  map[string]string{},
+		remaining: map[string]int{},
+		failures:  map[string][]int{},
+	}
+	// Hold every request briefly so that concurrent requests overlap
+	service.Handle(http.MethodPost, "/v1/recognitions", func(call *fakeservice.Call) {
+		id := fmt.Sprintf("job%d", len(service.statuses)+1)
+		service.statuses[id] = "completed"
+		if call.URL.Query().Get("model") == "fail" {
+			service.statuses[id] = "failed"
+		}
+		service.remaining[id] = 2
+		call.JSON(http.StatusCreated, fmt.Sprintf(`{"id": "%s", "status": "waiting", "created": "2026-01-01T00:00:00.000Z"}`, id))
+	}).Delay(5 * time.Millisecond)
+	service.Handle(http.MethodGet, "/v1/recognitions/{id}", func(call *fakeservice.Call) {
+		id := call.Params["id"]
+		service.checks++
+		if failures := service.failures[id]; len(failures) > 0 {
+			service.failures[id] = failures[1:]
+			call.Error(failures[0], http.StatusText(failures[0]))
+			return
+		}
+		if service.remaining[id] > 0 {
+			service.remaining[id]--
+			call.JSON(http.StatusOK, fmt.Sprintf(`{"id": "%s", "status": "processing", "created": "2026-01-01T00:00:00.000Z"}`, id))
+			return
+		}
+		if service.statuses[id] == "failed" {
+			call.JSON(http.StatusOK, fmt.Sprintf(`{"id": "%s", "status": "failed", "created": "2026-01-01T00:00:00.000Z", "warnings": ["audio is corrupt"]}`, id))
+			return
+		}
+		call.JSON(http.StatusOK, fmt.Sprintf(`{"id": "%s", "status": "completed", "created": "2026-01-01T00:00:00.000Z", "results": [{"result_index": 0, "results": [{"final": true, "alternatives": [{"transcript": "%s "}]}]}]}`, id, id))
+	}).Delay(5 * time.Millisecond)
+	service.Handle(http.MethodDelete, "/v1/recognitions/{id}", func(call *fakeservice.Call) {
+		service.deleted = append(service.deleted, call.Params["id"])
+		call.Response.WriteHeader(http.StatusNoContent)
+	}).Delay(5 * time.Millisecond)
+	return service
+}
+
+var _ = Describe(`RecognitionJobManager`, func() {
+	var service *jobService
+	var speechToText *speechtotextv1.SpeechToTextV1
+	BeforeEach(func() {
+		service = newJobService()
+		var err error
+		speechToText, err = speechtotextv1.NewSpeechToTextV1(&speechtotextv1.SpeechToTextV1Options{
+			URL:           service.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		service.Close()
+	})
+
+	It(`Polls a job until it completes and deletes it`, func() {
+		manager := speechtotextv1.NewRecognitionJobManager(speechToText, &speechtotextv1.RecognitionJobManagerOptions{
+			InitialPollInterval: time.Millisecond,
+			DeleteCompleted:     true,
+		})
+		results, err := manager.Run(context.Background(), speechToText.NewCreateJobOptions(CreateMockReader("audio")))
+		Expect(err).To(BeNil())
+		Expect(results.Results).To(HaveLen(1))
+		Expect(*results.Results[0].Alternatives[0].Transcript).To(Equal("job1 "))
+		Expect(service.checks).To(Equal(3))
+		Expect(service.deleted).To(Equal([]string{"job1"}))
+		Expect(manager.Jobs()).To(BeEmpty())
+	})
+
+	It(`Retries checks that fail with a transient error`, func() {
+		manager := speechtotextv1.NewRecognitionJobManager(speechToText, &speechtotextv1.RecognitionJobManagerOptions{
+			InitialPollInterval: time.Millisecond,
+		})
+		handle, err := manager.Submit(context.Background(), speechToText.NewCreateJobOptions(CreateMockReader("audio")))
+		Expect(err).To(BeNil())
+		Expect(manager.Jobs()).To(Equal([]string{"job1"}))
+		service.Do(func() {
+			service.failures["job1"] = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusBadGateway}
+		})
+
+		results, err := handle.Wait(context.Background())
+		Expect(err).To(BeNil())
+		Expect(*results.Results[0].Alternatives[0].Transcript).To(Equal("job1 "))
+		Expect(service.checks).To(Equal(6))
+		Expect(manager.Jobs()).To(BeEmpty())
+	})
+
+	It(`Fails once checks keep failing`, func() {
+		manager := speechtotextv1.NewRecognitionJobManager(speechToText, &speechtotextv1.RecognitionJobManagerOptions{
+			InitialPollInterval: time.Millisecond,
+			MaxCheckRetries:     2,
+		})
+		handle, err := manager.Submit(context.Background(), speechToText.NewCreateJobOptions(CreateMockReader("audio")))
+		Expect(err).To(BeNil())
+		service.Do(func() {
+			service.failures["job1"] = []int{500, 500, 500, 500}
+		})
+
+		_, err = handle.Wait(context.Background())
+		Expect(err).ToNot(BeNil())
+		Expect(service.checks).To(Equal(3))
+	})
+
+	It(`Does not retry checks that the service rejects`, func() {
+		manager := speechtotextv1.NewRecognitionJobManager(speechToText, &speechtotextv1.RecognitionJobManagerOptions{
+			InitialPollInterval: time.Millisecond,
+		})
+		handle, err := manager.Submit(context.Background(), speechToText.NewCreateJobOptions(CreateMockReader("audio")))
+		Expect(err).To(BeNil())
+		service.Do(func() {
+			service.failures["job1"] = []int{http.StatusNotFound}
+		})
+
+		_, err = handle.Wait(context.Background())
+		Expect(err).ToNot(BeNil())
+		Expect(service.checks).To(Equal(1))
+		Expect(manager.Jobs()).To(BeEmpty())
+	})
+
+	It(`Reports failed jobs`, func() {
+		manager := speechtotextv1.NewRecognitionJobManager(speechToText, &speechtotextv1.RecognitionJobManagerOptions{
+			InitialPollInterval: time.Millisecond,
+		})
+		createJobOptions := speechToText.NewCreateJobOptions(CreateMockReader("audio")).SetModel("fail")
+		_, err := manager.Run(context.Background(), createJobOptions)
+		var failedErr *speechtotextv1.RecognitionJobFailedError
+		Expect(errors.As(err, &failedErr)).To(BeTrue())
+		Expect(failedErr.ID).To(Equal("job1"))
+		Expect(err.Error()).To(ContainSubstring("audio is corrupt"))
+	})
+
+	It(`Stops waiting when the context is done`, func() {
+		manager := speechtotextv1.NewRecognitionJobManager(speechToText, &speechtotextv1.RecognitionJobManagerOptions{
+			InitialPollInterval: time.Hour,
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := manager.Run(ctx, speechToText.NewCreateJobOptions(CreateMockReader("audio")))
+		Expect(err).To(Equal(context.DeadlineExceeded))
+	})
+
+	It(`Checks a job immediately when notified`, func() {
+		manager := speechtotextv1.NewRecognitionJobManager(speechToText, &speechtotextv1.RecognitionJobManagerOptions{
+			InitialPollInterval: time.Hour,
+		})
+		handle, err := manager.Submit(context.Background(), speechToText.NewCreateJobOptions(CreateMockReader("audio")))
+		Expect(err).To(BeNil())
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := handle.Wait(context.Background())
+			done <- err
+		}()
+		for i := 0; i < 3; i++ {
+			Eventually(func() (checks int) {
+				manager.Notify(handle.ID)
+				service.Do(func() { checks = service.checks })
+				return
+			}).Should(BeNumerically(">", i))
+		}
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(*handle.Job().Status).To(Equal(speechtotextv1.RecognitionJobStatusCompletedConst))
+	})
+
+	It(`Bounds the number of concurrent requests`, func() {
+		manager := speechtotextv1.NewRecognitionJobManager(speechToText, &speechtotextv1.RecognitionJobManagerOptions{
+			Workers:             2,
+			InitialPollInterval: time.Millisecond,
+		})
+		var wg sync.WaitGroup
+		transcripts := make([]string, 6)
+		for i := range transcripts {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				results, err := manager.Run(context.Background(), speechToText.NewCreateJobOptions(CreateMockReader("audio")))
+				Expect(err).To(BeNil())
+				transcripts[i] = *results.Results[0].Alternatives[0].Transcript
+			}(i)
+		}
+		wg.Wait()
+		Expect(transcripts).To(ConsistOf("job1 ", "job2 ", "job3 ", "job4 ", "job5 ", "job6 "))
+		Expect(service.MaxInFlight()).To(BeNumerically("<=", 2))
+	})
+})
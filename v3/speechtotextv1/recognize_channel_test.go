@@ -0,0 +1,84 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"context"
+	"io"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1/sttest"
+)
+
+var _ = Describe(`RecognizeUsingWebsocketWithContext`, func() {
+	var testServer *sttest.Server
+	var speechToTextService *speechtotextv1.SpeechToTextV1
+
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`Delivers decoded results on a channel`, func() {
+		testServer = sttest.NewTranscribingServer(sttest.FinalResult(0, "hello"), sttest.FinalResult(1, "world"))
+		speechToTextService = newWebsocketService(testServer)
+
+		recognizeOptions := speechToTextService.NewRecognizeUsingWebsocketOptions(CreateMockReader("This is a mock file."), "audio/l16;rate=16000")
+		results, errs := speechToTextService.RecognizeUsingWebsocketWithContext(context.Background(), recognizeOptions)
+		var transcripts []string
+		for result := range results {
+			transcripts = append(transcripts, *result.Results[0].Alternatives[0].Transcript)
+		}
+		Expect(<-errs).To(BeNil())
+		Expect(transcripts).To(Equal([]string{"hello", "world"}))
+	})
+	It(`Stops and returns the context error when the context is cancelled`, func() {
+		stopped := make(chan struct{})
+		testServer = sttest.NewServer(func(conn *sttest.Conn) {
+			defer GinkgoRecover()
+			defer close(stopped)
+			_, err := conn.ReadStart()
+			Expect(err).To(BeNil())
+			Expect(conn.SendListening()).To(Succeed())
+			_, err = conn.ReadAudio()
+			Expect(err).To(BeNil())
+		})
+		speechToTextService = newWebsocketService(testServer)
+
+		audio, writer := io.Pipe()
+		defer writer.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		recognizeOptions := speechToTextService.NewRecognizeUsingWebsocketOptions(audio, "audio/l16;rate=16000")
+		results, errs := speechToTextService.RecognizeUsingWebsocketWithContext(ctx, recognizeOptions)
+		Eventually(testServer.Connections).Should(Equal(1))
+		cancel()
+
+		Eventually(stopped).Should(BeClosed())
+		Eventually(results).Should(BeClosed())
+		Expect(<-errs).To(Equal(context.Canceled))
+	})
+	It(`Returns validation errors on the error channel`, func() {
+		testServer = sttest.NewTranscribingServer()
+		speechToTextService = newWebsocketService(testServer)
+
+		results, errs := speechToTextService.RecognizeUsingWebsocketWithContext(context.Background(), nil)
+		Expect(<-errs).ToNot(BeNil())
+		Eventually(results).Should(BeClosed())
+		Expect(testServer.Connections()).To(Equal(0))
+	})
+})
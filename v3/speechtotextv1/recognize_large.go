@@ -0,0 +1,158 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/workerpool"
+)
+
+// RecognizeLargeOptions : Controls how RecognizeLarge splits audio. Zero values select the defaults.
+type RecognizeLargeOptions struct {
+	// The largest piece, in bytes, including its header. Defaults to 90 MB, below the 100 MB that the service accepts
+	// in a request.
+	MaxPieceSize int64
+
+	// The longest piece. Shorter pieces are recognized faster, because more of them are recognized at the same time.
+	// Defaults to 10 minutes.
+	MaxPieceDuration time.Duration
+
+	// How far before the limits of a piece to look for silence to cut it at. Defaults to 30 seconds.
+	SearchWindow time.Duration
+
+	// The level, relative to full scale, below which 16-bit linear audio counts as silence. Defaults to 0.01, about
+	// -40 dBFS.
+	SilenceThreshold float64
+
+	// The maximum number of pieces that are recognized at the same time. Defaults to 4.
+	Workers int
+}
+
+func (options *RecognizeLargeOptions) withDefaults() RecognizeLargeOptions {
+	withDefaults := RecognizeLargeOptions{}
+	if options != nil {
+		withDefaults = *options
+	}
+	if withDefaults.MaxPieceSize <= 0 || withDefaults.MaxPieceSize > maxAudioResourceSize {
+		withDefaults.MaxPieceSize = 90 * 1024 * 1024
+	}
+	if withDefaults.MaxPieceDuration <= 0 {
+		withDefaults.MaxPieceDuration = 10 * time.Minute
+	}
+	if withDefaults.SearchWindow <= 0 {
+		withDefaults.SearchWindow = 30 * time.Second
+	}
+	if withDefaults.SilenceThreshold <= 0 {
+		withDefaults.SilenceThreshold = 0.01
+	}
+	if withDefaults.Workers <= 0 {
+		withDefaults.Workers = 4
+	}
+	return withDefaults
+}
+
+// RecognizeLarge : Recognizes audio of any size with the HTTP Recognize method. The audio is split into pieces that
+// fit the limits of a request, cut where it is silent when that can be found near the limits, and the pieces are
+// recognized concurrently with the other options of recognizeOptions. WAV, FLAC and raw audio can be split; silence is
+// detected in 16-bit linear audio, and in FLAC audio where the encoder stored silent frames as constant values.
+//
+// The results of the pieces are joined in order, with the times of words, keywords, word alternatives and speaker
+// labels offset by the start of their piece. Speakers are identified separately in every piece, so the same speaker
+// can have different numbers in different pieces. The audio and processing metrics are left out of the joined
+// results, and warnings are kept once each. The first piece that fails cancels the others, and the error tells the
+// time range of the piece. The audio of recognizeOptions is read as the pieces are needed and closed.
+func (speechToText *SpeechToTextV1) RecognizeLarge(ctx context.Context, recognizeOptions *RecognizeOptions, options *RecognizeLargeOptions) (*SpeechRecognitionResults, error) {
+	if err := core.ValidateNotNil(recognizeOptions, "recognizeOptions cannot be nil"); err != nil {
+		return nil, err
+	}
+	if err := core.ValidateStruct(recognizeOptions, "recognizeOptions"); err != nil {
+		return nil, err
+	}
+	settings := options.withDefaults()
+	defer recognizeOptions.Audio.Close()
+	splitter, err := newAudioSplitter(recognizeOptions.Audio, core.StringNilMapper(recognizeOptions.ContentType), settings)
+	if err != nil {
+		return nil, err
+	}
+
+	// A worker is taken before the next piece is read, so that at most Workers pieces are in memory
+	pool := workerpool.New(ctx, settings.Workers)
+	var pieces []*audioPiece
+	var pieceResults []*SpeechRecognitionResults
+	var lock sync.Mutex
+	for pool.Acquire() {
+		piece, err := splitter.next()
+		if err != nil {
+			pool.Release()
+			if err != io.EOF {
+				pool.Fail(err)
+			}
+			break
+		}
+		lock.Lock()
+		pieces = append(pieces, piece)
+		pieceResults = append(pieceResults, nil)
+		lock.Unlock()
+
+		pool.Go(func(ctx context.Context) error {
+			pieceOptions := *recognizeOptions
+			pieceOptions.Audio = ioutil.NopCloser(bytes.NewReader(piece.content))
+			pieceOptions.ContentType = core.StringPtr(piece.contentType)
+			result, _, err := speechToText.RecognizeWithContext(ctx, &pieceOptions)
+			if err == nil && result == nil {
+				err = fmt.Errorf("no results")
+			}
+			if err != nil {
+				return fmt.Errorf("recognizing the audio from %s: %s", time.Duration(piece.start*float64(time.Second)), err)
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			pieceResults[piece.index] = result
+			return nil
+		})
+	}
+	if err := pool.Wait(); err != nil {
+		return nil, err
+	}
+	return joinSpeechRecognitionResults(pieces, pieceResults), nil
+}
+
+// joinSpeechRecognitionResults : Joins the results of the pieces of audio, in order
+func joinSpeechRecognitionResults(pieces []*audioPiece, pieceResults []*SpeechRecognitionResults) *SpeechRecognitionResults {
+	joined := &SpeechRecognitionResults{ResultIndex: core.Int64Ptr(0)}
+	warnings := map[string]bool{}
+	for i, result := range pieceResults {
+		offsetSpeechRecognitionResults(result, pieces[i].start, 0)
+		joined.Results = append(joined.Results, result.Results...)
+		joined.SpeakerLabels = append(joined.SpeakerLabels, result.SpeakerLabels...)
+		for _, warning := range result.Warnings {
+			if !warnings[warning] {
+				warnings[warning] = true
+				joined.Warnings = append(joined.Warnings, warning)
+			}
+		}
+	}
+	return joined
+}
@@ -0,0 +1,319 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/fakeservice"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+)
+
+// recognizedPiece : A piece of audio that recognizeLargeService received
+type recognizedPiece struct {
+	contentType string
+	size        int
+	duration    float64
+}
+
+// recognizeLargeService : A fake Recognize method that checks the pieces of audio it receives and recognizes each as
+// one word, "piece", that lasts the whole piece and is spoken by speaker 0. It understands the 8 kHz 16-bit mono
+// audio that the tests build.
+type recognizeLargeService struct {
+	*fakeservice.Server
+	pieces []recognizedPiece
+	fail   bool
+}
+
+func newRecognizeLargeService() *recognizeLargeService {
+	service := &recognizeLargeService{Server: fakeservice.NewServer()}
+	service.Handle(http.MethodPost, "/v1/recognize", func(call *fakeservice.Call) {
+		Expect(call.URL.Query().Get("model")).To(Equal("en-US_NarrowbandModel"))
+		body, err := ioutil.ReadAll(call.Body)
+		Expect(err).To(BeNil())
+		piece := recognizedPiece{contentType: call.Header.Get("Content-Type"), size: len(body)}
+		switch piece.contentType {
+		case "audio/l16;rate=8000":
+			piece.duration = float64(len(body)) / 16000
+		case "audio/wav":
+			Expect(string(body[:4])).To(Equal("RIFF"))
+			Expect(binary.LittleEndian.Uint32(body[4:])).To(BeEquivalentTo(len(body) - 8))
+			Expect(binary.LittleEndian.Uint32(body[40:])).To(BeEquivalentTo(len(body) - 44))
+			piece.duration = float64(len(body)-44) / 16000
+		case "audio/flac":
+			piece.duration = float64(countFlacFrames(body)) * 0.1
+		default:
+			Fail("unexpected content type " + piece.contentType)
+		}
+
+		service.pieces = append(service.pieces, piece)
+		if service.fail && len(service.pieces) == 2 {
+			call.Error(http.StatusBadRequest, "Stream was 0 bytes but needs to be at least 100 bytes.")
+			return
+		}
+		call.JSON(http.StatusOK, map[string]interface{}{
+			"result_index": 0,
+			"results": []interface{}{map[string]interface{}{
+				"final": true,
+				"alternatives": []interface{}{map[string]interface{}{
+					"transcript": "piece ",
+					"timestamps": []interface{}{[]interface{}{"piece", 0, piece.duration}},
+				}},
+			}},
+			"speaker_labels": []interface{}{map[string]interface{}{
+				"from": 0, "to": piece.duration, "speaker": 0, "confidence": 1, "final": true,
+			}},
+			"warnings": []string{"Unknown arguments: none."},
+		})
+	}).Delay(20 * time.Millisecond)
+	return service
+}
+
+// speechAndPauses : Builds 8 kHz 16-bit mono audio of periods of 1.2 seconds: 0.9 seconds of a tone and 0.3 seconds
+// of silence
+func speechAndPauses(periods int) []byte {
+	audio := make([]byte, periods*9600*2)
+	for i := 0; i < periods*9600; i++ {
+		if i%9600 < 7200 {
+			sample := int16(10000 * math.Sin(2*math.Pi*440*float64(i)/8000))
+			binary.LittleEndian.PutUint16(audio[2*i:], uint16(sample))
+		}
+	}
+	return audio
+}
+
+// isPause : Reports whether a time of speechAndPauses audio is in a pause
+func isPause(seconds float64) bool {
+	inPeriod := math.Mod(seconds, 1.2)
+	return inPeriod > 0.9 && inPeriod < 1.2
+}
+
+// flacSpeechAndPauses : Builds a FLAC stream of the audio of speechAndPauses, in frames of 0.1 seconds. Tones are
+// stored in verbatim subframes and silence in constant subframes.
+func flacSpeechAndPauses(periods int) []byte {
+	streamInfo := make([]byte, 34)
+	binary.BigEndian.PutUint16(streamInfo[0:], 800)
+	binary.BigEndian.PutUint16(streamInfo[2:], 800)
+	streamInfo[10], streamInfo[11], streamInfo[12] = 8000>>12, 8000>>4&0xff, 8000&0x0f<<4|15>>4
+	streamInfo[13] = 15 & 0x0f << 4
+	stream := append([]byte("fLaC\x80\x00\x00\x22"), streamInfo...)
+
+	pcm := speechAndPauses(periods)
+	for frame := 0; frame < periods*12; frame++ {
+		// Fixed block size, 800 samples from the end of the header, 8 kHz, mono, 16 bits
+		header := []byte{0xff, 0xf8, 0x74, 0x08}
+		if frame < 0x80 {
+			header = append(header, byte(frame))
+		} else {
+			header = append(header, 0xc0|byte(frame>>6), 0x80|byte(frame&0x3f))
+		}
+		header = append(header, 799>>8, 799&0xff)
+		data := append(header, flacCRC8(header))
+		if frame%12 >= 9 {
+			data = append(data, 0x00, 0x00, 0x00)
+		} else {
+			data = append(data, 0x02)
+			for i := 0; i < 800; i++ {
+				sample := binary.LittleEndian.Uint16(pcm[(frame*800+i)*2:])
+				data = append(data, byte(sample>>8), byte(sample))
+			}
+		}
+		crc := flacCRC16(data)
+		stream = append(append(stream, data...), byte(crc>>8), byte(crc))
+	}
+	return stream
+}
+
+// countFlacFrames : Checks a FLAC stream of the frames that flacSpeechAndPauses builds, numbered from zero, and
+// counts them
+func countFlacFrames(stream []byte) int {
+	Expect(string(stream[:4])).To(Equal("fLaC"))
+	Expect(stream[4]).To(Equal(byte(0x80)))
+	Expect(binary.BigEndian.Uint32(stream[22:26])).To(BeZero())
+	frames := 0
+	for offset := 42; offset < len(stream); frames++ {
+		frame := stream[offset:]
+		Expect(frame[:4]).To(Equal([]byte{0xff, 0xf8, 0x74, 0x08}))
+		number, numberLen := int(frame[4]), 1
+		if frame[4]&0xe0 == 0xc0 {
+			number, numberLen = int(frame[4]&0x1f)<<6|int(frame[5]&0x3f), 2
+		}
+		Expect(number).To(Equal(frames))
+		headerLen := 4 + numberLen + 2
+		Expect(frame[headerLen]).To(Equal(flacCRC8(frame[:headerLen])))
+		length := headerLen + 1 + 1 + 1600 + 2
+		if frame[headerLen+1] == 0x00 {
+			length = headerLen + 1 + 3 + 2
+		}
+		Expect(binary.BigEndian.Uint16(frame[length-2:])).To(Equal(flacCRC16(frame[:length-2])))
+		offset += length
+	}
+	return frames
+}
+
+func flacCRC8(data []byte) byte {
+	crc := byte(0)
+	for _, b := range data {
+		crc ^= b
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+func flacCRC16(data []byte) uint16 {
+	crc := uint16(0)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x8005
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+var _ = Describe(`RecognizeLarge`, func() {
+	var service *recognizeLargeService
+	var speechToText *speechtotextv1.SpeechToTextV1
+	BeforeEach(func() {
+		service = newRecognizeLargeService()
+		var err error
+		speechToText, err = speechtotextv1.NewSpeechToTextV1(&speechtotextv1.SpeechToTextV1Options{
+			URL:           service.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		service.Close()
+	})
+
+	recognize := func(audio []byte, contentType string, options *speechtotextv1.RecognizeLargeOptions) (*speechtotextv1.SpeechRecognitionResults, error) {
+		recognizeOptions := speechToText.NewRecognizeOptions(ioutil.NopCloser(bytes.NewReader(audio))).
+			SetModel("en-US_NarrowbandModel")
+		if contentType != "" {
+			recognizeOptions.SetContentType(contentType)
+		}
+		return speechToText.RecognizeLarge(context.Background(), recognizeOptions, options)
+	}
+
+	// expectJoined : Checks that the joined results have a word for every piece, in order, that together cover the
+	// audio, and that the pieces were cut in pauses
+	expectJoined := func(results *speechtotextv1.SpeechRecognitionResults, duration float64) {
+		Expect(*results.ResultIndex).To(BeZero())
+		Expect(results.Warnings).To(Equal([]string{"Unknown arguments: none."}))
+		Expect(results.Results).To(HaveLen(len(service.pieces)))
+		Expect(results.SpeakerLabels).To(HaveLen(len(service.pieces)))
+		end := 0.0
+		for i, result := range results.Results {
+			timestamps, err := result.Alternatives[0].GetWordTimestamps()
+			Expect(err).To(BeNil())
+			Expect(timestamps[0].StartTime).To(BeNumerically("~", end, 1e-6))
+			Expect(float64(*results.SpeakerLabels[i].From)).To(BeNumerically("~", end, 1e-3))
+			if i > 0 {
+				Expect(isPause(end)).To(BeTrue(), fmt.Sprintf("piece %d starts at %.3f seconds", i, end))
+			}
+			end = timestamps[0].EndTime
+			Expect(float64(*results.SpeakerLabels[i].To)).To(BeNumerically("~", end, 1e-3))
+		}
+		Expect(end).To(BeNumerically("~", duration, 1e-6))
+	}
+
+	It(`Splits raw audio in pauses and joins the results`, func() {
+		results, err := recognize(speechAndPauses(10), "audio/l16;rate=8000",
+			&speechtotextv1.RecognizeLargeOptions{MaxPieceDuration: 3 * time.Second, SearchWindow: time.Second})
+		Expect(err).To(BeNil())
+		Expect(len(service.pieces)).To(BeNumerically(">=", 4))
+		for _, piece := range service.pieces {
+			Expect(piece.duration).To(BeNumerically("<=", 3))
+		}
+		expectJoined(results, 12)
+		Expect(service.MaxInFlight()).To(BeNumerically(">", 1))
+	})
+
+	It(`Splits WAV audio to the size limit with a header for every piece`, func() {
+		audio := wavAudio(8000, 1, 0)
+		audio = append(audio, speechAndPauses(10)...)
+		binary.LittleEndian.PutUint32(audio[4:], uint32(len(audio)-8))
+		binary.LittleEndian.PutUint32(audio[40:], uint32(len(audio)-44))
+		results, err := recognize(audio, "", &speechtotextv1.RecognizeLargeOptions{MaxPieceSize: 44 + 48000})
+		Expect(err).To(BeNil())
+		Expect(len(service.pieces)).To(BeNumerically(">=", 4))
+		for _, piece := range service.pieces {
+			Expect(piece.contentType).To(Equal("audio/wav"))
+			Expect(piece.size).To(BeNumerically("<=", 44+48000))
+		}
+		expectJoined(results, 12)
+	})
+
+	It(`Splits FLAC audio between silent frames and numbers the frames of every piece from zero`, func() {
+		results, err := recognize(flacSpeechAndPauses(15), "",
+			&speechtotextv1.RecognizeLargeOptions{MaxPieceDuration: 3 * time.Second, SearchWindow: time.Second})
+		Expect(err).To(BeNil())
+		Expect(len(service.pieces)).To(BeNumerically(">=", 6))
+		for _, piece := range service.pieces {
+			Expect(piece.contentType).To(Equal("audio/flac"))
+			Expect(piece.duration).To(BeNumerically("<=", 3))
+		}
+		expectJoined(results, 18)
+	})
+
+	It(`Keeps a short end of the audio in the last piece`, func() {
+		audio := speechAndPauses(3)[:3500*16]
+		results, err := recognize(audio, "audio/l16;rate=8000",
+			&speechtotextv1.RecognizeLargeOptions{MaxPieceDuration: 3 * time.Second, SearchWindow: 100 * time.Millisecond})
+		Expect(err).To(BeNil())
+		Expect(service.pieces).To(HaveLen(1))
+		Expect(service.pieces[0].duration).To(BeNumerically("~", 3.5, 1e-6))
+		Expect(results.Results).To(HaveLen(1))
+	})
+
+	It(`Reports the piece that failed`, func() {
+		service.fail = true
+		_, err := recognize(speechAndPauses(10), "audio/l16;rate=8000",
+			&speechtotextv1.RecognizeLargeOptions{MaxPieceDuration: 3 * time.Second, SearchWindow: time.Second, Workers: 1})
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("recognizing the audio from 2.25s: "))
+		Expect(err.Error()).To(ContainSubstring("needs to be at least 100 bytes"))
+	})
+
+	It(`Rejects audio that cannot be split`, func() {
+		_, err := recognize([]byte("OggS\x00\x02"+string(make([]byte, 100))), "audio/ogg", nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("audio in the audio/ogg format cannot be split; use WAV, FLAC or raw audio"))
+		Expect(service.pieces).To(BeEmpty())
+	})
+})
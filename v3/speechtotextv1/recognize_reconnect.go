@@ -0,0 +1,490 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/gorilla/websocket"
+)
+
+// ReconnectOptions : Controls how RecognizeUsingWebsocketWithContext recovers from a failed connection. Zero values
+// select the defaults.
+//
+// After a failure the audio that has not been acknowledged by a final result is replayed on a new connection, and the
+// timestamps and result indices of the new connection are rebased so that the results read as one continuous
+// transcript. Word timestamps are required to locate the acknowledged audio, so they are always requested from the
+// service; they are removed from the results unless the Timestamps option is set.
+//
+// Reconnecting works only for raw audio (`audio/l16`, `audio/mulaw`, `audio/alaw` or `audio/basic`), whose byte rate
+// the content type gives: the position of a word cannot be mapped to a byte offset in compressed or containerized
+// audio, and such audio cannot be resumed mid-stream. Other content types are rejected before connecting.
+type ReconnectOptions struct {
+	// The maximum number of consecutive reconnection attempts. Defaults to 5.
+	MaxAttempts int
+
+	// The delay before the first reconnection attempt, doubled for every further attempt. Defaults to 1 second.
+	InitialBackoff time.Duration
+
+	// The upper bound of the delay between reconnection attempts. Defaults to 30 seconds.
+	MaxBackoff time.Duration
+
+	// Decides whether a connection error is worth reconnecting for. Defaults to IsReconnectableError.
+	ShouldReconnect func(error) bool
+
+	// The most audio that is kept for replay while the service has not acknowledged it with a final result. Older
+	// audio is dropped once the limit is reached, and a reconnection that would have to replay dropped audio fails with
+	// a *ReplayLimitError. Defaults to 2 minutes.
+	MaxUnacknowledgedAudio time.Duration
+}
+
+// ReplayLimitError : The audio that a new connection would have to replay was dropped because it exceeded the
+// MaxUnacknowledgedAudio reconnect option
+type ReplayLimitError struct {
+	// How much unacknowledged audio was dropped.
+	Dropped time.Duration
+
+	// The connection error that required the replay.
+	Err error
+}
+
+func (e *ReplayLimitError) Error() string {
+	return fmt.Sprintf("cannot reconnect after %s: %s of unacknowledged audio exceeded the replay limit", e.Err,
+		e.Dropped.Round(time.Millisecond))
+}
+
+func (e *ReplayLimitError) Unwrap() error {
+	return e.Err
+}
+
+// IsReconnectableError : Reports whether a new connection can be expected to recover from err. Network failures,
+// abnormal closes, handshakes rejected with 429 or 5xx statuses and service session timeouts are reconnectable;
+// authentication failures, invalid requests and other service errors are not.
+func IsReconnectableError(err error) bool {
+	var recognitionErr *RecognitionError
+	if errors.As(err, &recognitionErr) {
+		return strings.Contains(strings.ToLower(recognitionErr.Message), "session timed out")
+	}
+	var dialErr *WebsocketDialError
+	if errors.As(err, &dialErr) {
+		return dialErr.StatusCode == 0 || dialErr.StatusCode == 429 || dialErr.StatusCode >= 500
+	}
+	var closeErr *WebsocketCloseError
+	if errors.As(err, &closeErr) {
+		switch closeErr.Code {
+		case websocket.CloseUnsupportedData, websocket.CloseInvalidFramePayloadData, websocket.ClosePolicyViolation,
+			websocket.CloseMessageTooBig:
+			return false
+		}
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func (reconnect ReconnectOptions) withDefaults() ReconnectOptions {
+	if reconnect.MaxAttempts <= 0 {
+		reconnect.MaxAttempts = 5
+	}
+	if reconnect.InitialBackoff <= 0 {
+		reconnect.InitialBackoff = time.Second
+	}
+	if reconnect.MaxBackoff <= 0 {
+		reconnect.MaxBackoff = 30 * time.Second
+	}
+	if reconnect.ShouldReconnect == nil {
+		reconnect.ShouldReconnect = IsReconnectableError
+	}
+	if reconnect.MaxUnacknowledgedAudio <= 0 {
+		reconnect.MaxUnacknowledgedAudio = 2 * time.Minute
+	}
+	return reconnect
+}
+
+// recognizeWithReconnect : Runs recognition over as many connections as needed to deliver the complete transcript
+func (speechToText *SpeechToTextV1) recognizeWithReconnect(ctx context.Context, recognizeWSOptions *RecognizeUsingWebsocketOptions, pacer *audioPacer, results chan<- *WebsocketRecognitionResults, errs chan<- error) {
+	format, err := ParseRawAudioFormat(core.StringNilMapper(recognizeWSOptions.ContentType))
+	if err != nil {
+		errs <- fmt.Errorf("reconnect requires a raw audio content type: %s", err)
+		return
+	}
+	reconnect := recognizeWSOptions.Reconnect.withDefaults()
+
+	// Timestamps locate the acknowledged audio; they are removed from the results unless they were requested
+	startOptions := *recognizeWSOptions
+	startOptions.Timestamps = core.BoolPtr(true)
+	keepTimestamps := recognizeWSOptions.Timestamps != nil && *recognizeWSOptions.Timestamps
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	maxBuffer := int64(reconnect.MaxUnacknowledgedAudio.Seconds() * float64(format.ByteRate()))
+	audio := &unacknowledgedAudio{format: format, max: maxBuffer / int64(format.FrameSize()) * int64(format.FrameSize())}
+	chunks := readAudioChunks(ctx, recognizeWSOptions.Audio, pacer)
+
+	attempts := 0
+	backoff := reconnect.InitialBackoff
+	for {
+		fatal, err := speechToText.recognizeConnection(ctx, &startOptions, keepTimestamps, pacer, audio, chunks, results)
+		if err == nil {
+			return
+		}
+		if fatal || !reconnect.ShouldReconnect(err) {
+			errs <- err
+			return
+		}
+		if dropped := audio.takeDropped(); dropped > 0 {
+			errs <- &ReplayLimitError{Dropped: format.Duration(dropped), Err: err}
+			return
+		}
+		if audio.takeProgress() {
+			attempts = 0
+			backoff = reconnect.InitialBackoff
+		}
+		attempts++
+		if attempts > reconnect.MaxAttempts {
+			errs <- err
+			return
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			errs <- ctx.Err()
+			return
+		}
+		if backoff *= 2; backoff > reconnect.MaxBackoff {
+			backoff = reconnect.MaxBackoff
+		}
+	}
+}
+
+// recognizeConnection : Replays the unacknowledged audio on a new connection and continues with fresh audio until the
+// audio is exhausted or the connection fails. The replay is paced like fresh audio, and shares the rate limits of the
+// pacer with the audio that is read meanwhile. Errors that another connection cannot recover from are flagged fatal.
+func (speechToText *SpeechToTextV1) recognizeConnection(ctx context.Context, recognizeWSOptions *RecognizeUsingWebsocketOptions, keepTimestamps bool, pacer *audioPacer, audio *unacknowledgedAudio, chunks <-chan audioChunk, results chan<- *WebsocketRecognitionResults) (fatal bool, err error) {
+	replay, offset, indexBase := audio.snapshot()
+	callback := &reconnectCallback{
+		ctx:            ctx,
+		results:        results,
+		audio:          audio,
+		offset:         offset,
+		indexBase:      indexBase,
+		keepTimestamps: keepTimestamps,
+		failed:         make(chan struct{}),
+	}
+
+	session, err := speechToText.OpenRecognizeSession(recognizeWSOptions, callback)
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	for len(replay) > 0 {
+		n := pacer.chunkSize
+		if n > len(replay) {
+			n = len(replay)
+		}
+		if err = pacer.wait(ctx, n); err != nil {
+			_ = session.sendStop()
+			return true, err
+		}
+		if _, err = session.Write(replay[:n]); err != nil {
+			return false, callback.firstError(err)
+		}
+		replay = replay[n:]
+	}
+
+	for {
+		select {
+		case chunk, ok := <-chunks:
+			if !ok {
+				if err = session.Stop(); err != nil {
+					return false, callback.firstError(err)
+				}
+				return false, callback.firstError(nil)
+			}
+			if chunk.err != nil {
+				return true, chunk.err
+			}
+			audio.append(chunk.data)
+			if _, err = session.Write(chunk.data); err != nil {
+				return false, callback.firstError(err)
+			}
+		case <-callback.failed:
+			return false, callback.firstError(nil)
+		case <-session.Done():
+			return false, callback.firstError(io.ErrUnexpectedEOF)
+		case <-ctx.Done():
+			_ = session.sendStop()
+			return true, ctx.Err()
+		}
+	}
+}
+
+// audioChunk : A chunk of audio read from the caller's reader, or the error that ended reading
+type audioChunk struct {
+	data []byte
+	err  error
+}
+
+// readAudioChunks : Reads the audio independently of any connection. The channel is closed at the end of the audio.
+func readAudioChunks(ctx context.Context, reader io.Reader, pacer *audioPacer) <-chan audioChunk {
+	chunks := make(chan audioChunk)
+	go func() {
+		defer close(chunks)
+		for {
+			chunk := make([]byte, pacer.chunkSize)
+			bytesRead, err := reader.Read(chunk)
+			if bytesRead > 0 {
+				if pacer.wait(ctx, bytesRead) != nil {
+					return
+				}
+				select {
+				case chunks <- audioChunk{data: chunk[:bytesRead]}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				select {
+				case chunks <- audioChunk{err: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+		}
+	}()
+	return chunks
+}
+
+// unacknowledgedAudio : The audio that was sent after the end of the last word of the last final result, up to max
+// bytes of it
+type unacknowledgedAudio struct {
+	format RawAudioFormat
+	max    int64
+
+	lock     sync.Mutex
+	buffer   []byte
+	start    int64
+	finals   int64
+	progress bool
+
+	// The number of bytes dropped from the start of the buffer since the last acknowledgement
+	dropped int64
+}
+
+// append : Adds audio to the buffer and drops the oldest audio beyond the limit, whole frames at a time
+func (audio *unacknowledgedAudio) append(data []byte) {
+	audio.lock.Lock()
+	defer audio.lock.Unlock()
+	audio.buffer = append(audio.buffer, data...)
+	if excess := int64(len(audio.buffer)) - audio.max; audio.max > 0 && excess > 0 {
+		frameSize := int64(audio.format.FrameSize())
+		excess = (excess + frameSize - 1) / frameSize * frameSize
+		if excess > int64(len(audio.buffer)) {
+			excess = int64(len(audio.buffer))
+		}
+		audio.buffer = audio.buffer[excess:]
+		audio.start += excess
+		audio.dropped += excess
+	}
+}
+
+// snapshot : Returns the audio to replay, its position in seconds and the number of final results before it
+func (audio *unacknowledgedAudio) snapshot() (replay []byte, offset float64, finals int64) {
+	audio.lock.Lock()
+	defer audio.lock.Unlock()
+	replay = append([]byte(nil), audio.buffer...)
+	return replay, float64(audio.start) / float64(audio.format.ByteRate()), audio.finals
+}
+
+// acknowledge : Drops the audio up to end seconds once finals results are final
+func (audio *unacknowledgedAudio) acknowledge(finals int64, end float64) {
+	audio.lock.Lock()
+	defer audio.lock.Unlock()
+	if finals > audio.finals {
+		audio.finals = finals
+		audio.progress = true
+	}
+
+	frameSize := int64(audio.format.FrameSize())
+	acknowledged := int64(end*float64(audio.format.ByteRate())) / frameSize * frameSize
+	drop := acknowledged - audio.start
+	if drop >= 0 {
+		audio.dropped = 0
+	}
+	if drop <= 0 {
+		return
+	}
+	if drop > int64(len(audio.buffer)) {
+		drop = int64(len(audio.buffer))
+	}
+	audio.buffer = audio.buffer[drop:]
+	audio.start += drop
+}
+
+// takeProgress : Reports whether final results arrived since the last call
+func (audio *unacknowledgedAudio) takeProgress() bool {
+	audio.lock.Lock()
+	defer audio.lock.Unlock()
+	progress := audio.progress
+	audio.progress = false
+	return progress
+}
+
+// takeDropped : Returns the number of bytes dropped since the last acknowledgement and resets it
+func (audio *unacknowledgedAudio) takeDropped() int64 {
+	audio.lock.Lock()
+	defer audio.lock.Unlock()
+	dropped := audio.dropped
+	audio.dropped = 0
+	return dropped
+}
+
+// reconnectCallback : Rebases the results of one connection, acknowledges final audio and keeps the first error
+type reconnectCallback struct {
+	ctx            context.Context
+	results        chan<- *WebsocketRecognitionResults
+	audio          *unacknowledgedAudio
+	offset         float64
+	indexBase      int64
+	keepTimestamps bool
+
+	errLock sync.Mutex
+	err     error
+	failed  chan struct{}
+}
+
+func (callback *reconnectCallback) OnOpen() {}
+
+func (callback *reconnectCallback) OnClose() {}
+
+func (callback *reconnectCallback) OnData(resp *core.DetailedResponse) {
+	result := new(WebsocketRecognitionResults)
+	if err := json.Unmarshal(resp.Result.([]byte), result); err != nil {
+		callback.OnError(err)
+		return
+	}
+	offsetSpeechRecognitionResults(&result.SpeechRecognitionResults, callback.offset, callback.indexBase)
+
+	if result.ResultIndex != nil {
+		for i, speechResult := range result.Results {
+			if speechResult.Final == nil || !*speechResult.Final {
+				continue
+			}
+			end := 0.0
+			if len(speechResult.Alternatives) > 0 {
+				if timestamps, _ := speechResult.Alternatives[0].GetWordTimestamps(); len(timestamps) > 0 {
+					end = timestamps[len(timestamps)-1].EndTime
+				}
+			}
+			callback.audio.acknowledge(*result.ResultIndex+int64(i)+1, end)
+		}
+	}
+	if !callback.keepTimestamps {
+		for i := range result.Results {
+			for j := range result.Results[i].Alternatives {
+				result.Results[i].Alternatives[j].Timestamps = nil
+			}
+		}
+	}
+
+	select {
+	case callback.results <- result:
+	case <-callback.ctx.Done():
+	}
+}
+
+func (callback *reconnectCallback) OnError(err error) {
+	callback.errLock.Lock()
+	defer callback.errLock.Unlock()
+	if callback.err == nil {
+		callback.err = err
+		close(callback.failed)
+	}
+}
+
+// firstError : Returns the first error reported by the connection, or fallback if there was none
+func (callback *reconnectCallback) firstError(fallback error) error {
+	callback.errLock.Lock()
+	defer callback.errLock.Unlock()
+	if callback.err != nil {
+		return callback.err
+	}
+	return fallback
+}
+
+// offsetSpeechRecognitionResults : Shifts every time in the results by seconds and every result index by indexBase
+func offsetSpeechRecognitionResults(results *SpeechRecognitionResults, seconds float64, indexBase int64) {
+	if results.ResultIndex != nil {
+		results.ResultIndex = core.Int64Ptr(*results.ResultIndex + indexBase)
+	}
+	if seconds == 0 {
+		return
+	}
+
+	for i := range results.Results {
+		speechResult := &results.Results[i]
+		for j := range speechResult.Alternatives {
+			alternative := &speechResult.Alternatives[j]
+			if timestamps, err := alternative.GetWordTimestamps(); err == nil && timestamps != nil {
+				for k := range timestamps {
+					timestamps[k].StartTime += seconds
+					timestamps[k].EndTime += seconds
+				}
+				alternative.SetWordTimestamps(timestamps)
+			}
+		}
+		for _, keywordResults := range speechResult.KeywordsResult {
+			for j := range keywordResults {
+				offsetFloat64(keywordResults[j].StartTime, seconds)
+				offsetFloat64(keywordResults[j].EndTime, seconds)
+			}
+		}
+		for j := range speechResult.WordAlternatives {
+			offsetFloat64(speechResult.WordAlternatives[j].StartTime, seconds)
+			offsetFloat64(speechResult.WordAlternatives[j].EndTime, seconds)
+		}
+	}
+	for i := range results.SpeakerLabels {
+		if from := results.SpeakerLabels[i].From; from != nil {
+			*from += float32(seconds)
+		}
+		if to := results.SpeakerLabels[i].To; to != nil {
+			*to += float32(seconds)
+		}
+	}
+}
+
+func offsetFloat64(value *float64, seconds float64) {
+	if value != nil {
+		*value += seconds
+	}
+}
@@ -0,0 +1,163 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1/sttest"
+)
+
+var _ = Describe(`RecognizeUsingWebsocketWithContext reconnect`, func() {
+	var testServer *sttest.Server
+	var speechToTextService *speechtotextv1.SpeechToTextV1
+
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	// recognize : Runs recognition of audio with reconnect and returns the results and the error
+	recognize := func(recognizeOptions *speechtotextv1.RecognizeUsingWebsocketOptions) ([]*speechtotextv1.WebsocketRecognitionResults, error) {
+		results, errs := speechToTextService.RecognizeUsingWebsocketWithContext(context.Background(), recognizeOptions)
+		var received []*speechtotextv1.WebsocketRecognitionResults
+		for result := range results {
+			received = append(received, result)
+		}
+		return received, <-errs
+	}
+	newOptions := func(audio []byte) *speechtotextv1.RecognizeUsingWebsocketOptions {
+		recognizeOptions := speechToTextService.NewRecognizeUsingWebsocketOptions(ioutil.NopCloser(bytes.NewReader(audio)), "audio/l16;rate=16000")
+		recognizeOptions.SetPacing(speechtotextv1.RecognizeUsingWebsocketOptionsPacingNoneConst)
+		recognizeOptions.SetReconnect(&speechtotextv1.ReconnectOptions{InitialBackoff: time.Millisecond})
+		return recognizeOptions
+	}
+
+	It(`Reconnects and resumes after a dropped connection`, func() {
+		testServer = sttest.NewServer(func(conn *sttest.Conn) {
+			defer GinkgoRecover()
+			_, err := conn.ReadStart()
+			Expect(err).To(BeNil())
+			Expect(conn.SendListening()).To(Succeed())
+			if testServer.Connections() == 1 {
+				// Acknowledge the first quarter second, then drop the connection once all audio has arrived
+				_, err = conn.ReadAudioBytes(16000)
+				Expect(err).To(BeNil())
+				Expect(conn.SendJSON(json.RawMessage(`{"result_index": 0, "results": [{"final": true, "alternatives": [{"transcript": "hello", "timestamps": [["hello", 0.0, 0.25]]}]}]}`))).To(Succeed())
+				_, err = conn.ReadAudio()
+				Expect(err).To(BeNil())
+				Expect(conn.Abort()).To(Succeed())
+				return
+			}
+			audio, err := conn.ReadAudio()
+			Expect(err).To(BeNil())
+			Expect(audio).To(HaveLen(64000 - 8000))
+			Expect(conn.SendJSON(json.RawMessage(`{"result_index": 0, "results": [{"final": true, "alternatives": [{"transcript": "world", "timestamps": [["world", 0.5, 1.0]]}]}]}`))).To(Succeed())
+			Expect(conn.SendListening()).To(Succeed())
+		})
+		speechToTextService = newWebsocketService(testServer)
+
+		recognizeOptions := newOptions(make([]byte, 64000))
+		recognizeOptions.SetTimestamps(true)
+		received, err := recognize(recognizeOptions)
+		Expect(err).To(BeNil())
+		Expect(testServer.Connections()).To(Equal(2))
+		Expect(received).To(HaveLen(2))
+		Expect(*received[1].ResultIndex).To(Equal(int64(1)))
+		Expect(received[1].Results[0].Alternatives[0].Timestamps).To(Equal([]interface{}{[]interface{}{"world", 0.75, 1.25}}))
+	})
+	It(`Requests timestamps but removes them from the results unless they were asked for`, func() {
+		testServer = sttest.NewServer(func(conn *sttest.Conn) {
+			defer GinkgoRecover()
+			startMessage, err := conn.ReadStart()
+			Expect(err).To(BeNil())
+			Expect(startMessage).To(HaveKeyWithValue("timestamps", true))
+			Expect(conn.SendListening()).To(Succeed())
+			_, err = conn.ReadAudio()
+			Expect(err).To(BeNil())
+			Expect(conn.SendJSON(json.RawMessage(`{"result_index": 0, "results": [{"final": true, "alternatives": [{"transcript": "hello", "timestamps": [["hello", 0.0, 0.25]]}]}]}`))).To(Succeed())
+			Expect(conn.SendListening()).To(Succeed())
+		})
+		speechToTextService = newWebsocketService(testServer)
+
+		received, err := recognize(newOptions(make([]byte, 16000)))
+		Expect(err).To(BeNil())
+		Expect(received).To(HaveLen(1))
+		Expect(*received[0].Results[0].Alternatives[0].Transcript).To(Equal("hello"))
+		Expect(received[0].Results[0].Alternatives[0].Timestamps).To(BeNil())
+	})
+	It(`Fails instead of replaying audio beyond the replay limit`, func() {
+		testServer = sttest.NewServer(func(conn *sttest.Conn) {
+			defer GinkgoRecover()
+			_, err := conn.ReadStart()
+			Expect(err).To(BeNil())
+			Expect(conn.SendListening()).To(Succeed())
+			_, err = conn.ReadAudio()
+			Expect(err).To(BeNil())
+			Expect(conn.Abort()).To(Succeed())
+		})
+		speechToTextService = newWebsocketService(testServer)
+
+		recognizeOptions := newOptions(make([]byte, 64000))
+		recognizeOptions.Reconnect.MaxUnacknowledgedAudio = 500 * time.Millisecond
+		_, err := recognize(recognizeOptions)
+		Expect(err).To(BeAssignableToTypeOf(&speechtotextv1.ReplayLimitError{}))
+		Expect(err.(*speechtotextv1.ReplayLimitError).Dropped).To(Equal(1500 * time.Millisecond))
+		Expect(testServer.Connections()).To(Equal(1))
+	})
+	It(`Rejects audio that is not raw`, func() {
+		testServer = sttest.NewTranscribingServer()
+		speechToTextService = newWebsocketService(testServer)
+
+		recognizeOptions := newOptions(make([]byte, 16000))
+		recognizeOptions.SetContentType("audio/ogg;codecs=opus")
+		_, err := recognize(recognizeOptions)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("raw audio"))
+		Expect(testServer.Connections()).To(Equal(0))
+	})
+})
+
+var _ = Describe(`IsReconnectableError`, func() {
+	table.DescribeTable(`Classifies connection errors`,
+		func(err error, reconnectable bool) {
+			Expect(speechtotextv1.IsReconnectableError(err)).To(Equal(reconnectable))
+		},
+		table.Entry(`a session timeout`, &speechtotextv1.RecognitionError{Message: "Session timed out."}, true),
+		table.Entry(`another service error`, &speechtotextv1.RecognitionError{Message: "unable to transcode data stream"}, false),
+		table.Entry(`a network failure to connect`, &speechtotextv1.WebsocketDialError{Err: &net.OpError{Op: "dial", Err: errors.New("refused")}}, true),
+		table.Entry(`a throttled handshake`, &speechtotextv1.WebsocketDialError{StatusCode: http.StatusTooManyRequests}, true),
+		table.Entry(`an unavailable service`, &speechtotextv1.WebsocketDialError{StatusCode: http.StatusServiceUnavailable}, true),
+		table.Entry(`an unauthorized handshake`, &speechtotextv1.WebsocketDialError{StatusCode: http.StatusUnauthorized}, false),
+		table.Entry(`an abnormal close`, &speechtotextv1.WebsocketCloseError{Code: websocket.CloseAbnormalClosure}, true),
+		table.Entry(`a policy violation`, &speechtotextv1.WebsocketCloseError{Code: websocket.ClosePolicyViolation}, false),
+		table.Entry(`a truncated stream`, io.ErrUnexpectedEOF, true),
+		table.Entry(`a read error of the audio`, errors.New("read failed"), false),
+	)
+})
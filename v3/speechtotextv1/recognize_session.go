@@ -0,0 +1,320 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/gorilla/websocket"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/websocketerrors"
+)
+
+// RecognizeSession : A recognize websocket connection that stays open across utterances.
+//
+// Audio is pushed with Write as it becomes available. Stop ends the current utterance and waits for its final
+// results; Start begins a new utterance on the same connection. Close ends the session.
+type RecognizeSession struct {
+	conn     *websocket.Conn
+	options  *RecognizeUsingWebsocketOptions
+	callback RecognizeCallbackWrapper
+
+	// writeLock serializes writes, the connection supports a single concurrent writer
+	writeLock sync.Mutex
+
+	// stateLock guards the fields below it
+	stateLock sync.Mutex
+	started   bool
+	listening bool
+	stopping  bool
+	stopped   chan struct{}
+	closing   bool
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// OpenRecognizeSession : Opens a recognize websocket connection and starts the first utterance
+//
+// The Audio field of the options is ignored, audio is sent with Write. The callback receives the results of every
+// utterance on the session; OnClose is called once when the connection ends. The callback must not call Close.
+func (speechToText *SpeechToTextV1) OpenRecognizeSession(recognizeWSOptions *RecognizeUsingWebsocketOptions, callback RecognizeCallbackWrapper) (*RecognizeSession, error) {
+	if err := core.ValidateNotNil(recognizeWSOptions, "recognizeOptions cannot be nil"); err != nil {
+		return nil, err
+	}
+	if err := core.ValidateNotNil(callback, "callback cannot be nil"); err != nil {
+		return nil, err
+	}
+	dialURL, param, headers, err := speechToText.recognizeDialParams(recognizeWSOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := dialRecognize(dialURL, param, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &RecognizeSession{
+		conn:     conn,
+		options:  recognizeWSOptions,
+		callback: callback,
+		done:     make(chan struct{}),
+	}
+	callback.OnOpen()
+	go session.readResults()
+
+	if err := session.Start(); err != nil {
+		session.Close()
+		return nil, err
+	}
+	return session, nil
+}
+
+// Start : Sends the start message for a new utterance
+//
+// The first utterance is started by OpenRecognizeSession; call Start again after Stop to reuse the connection.
+func (session *RecognizeSession) Start() error {
+	session.stateLock.Lock()
+	if session.closing {
+		session.stateLock.Unlock()
+		return errors.New("recognize session is closed")
+	}
+	if session.started {
+		session.stateLock.Unlock()
+		return errors.New("recognize session already has an utterance in progress")
+	}
+	session.started = true
+	session.listening = false
+	session.stopping = false
+	session.stopped = make(chan struct{})
+	session.stateLock.Unlock()
+
+	startMsgBytes, err := startMessage(session.options)
+	if err != nil {
+		return err
+	}
+	return session.writeMessage(websocket.TextMessage, startMsgBytes)
+}
+
+// Write : Sends a chunk of audio for the current utterance
+func (session *RecognizeSession) Write(chunk []byte) (int, error) {
+	session.stateLock.Lock()
+	active := session.started && !session.stopping && !session.closing
+	session.stateLock.Unlock()
+	if !active {
+		return 0, errors.New("recognize session has no utterance in progress")
+	}
+
+	if err := session.writeMessage(websocket.BinaryMessage, chunk); err != nil {
+		return 0, err
+	}
+	return len(chunk), nil
+}
+
+// Stop : Sends the stop message for the current utterance without closing the connection
+//
+// Stop blocks until the service has returned the final results of the utterance or the connection ends.
+func (session *RecognizeSession) Stop() error {
+	session.stateLock.Lock()
+	if !session.started || session.stopping {
+		session.stateLock.Unlock()
+		return errors.New("recognize session has no utterance in progress")
+	}
+	session.stopping = true
+	stopped := session.stopped
+	session.stateLock.Unlock()
+
+	if err := session.sendStop(); err != nil {
+		return err
+	}
+
+	select {
+	case <-stopped:
+		return nil
+	case <-session.done:
+		return errors.New("recognize session closed before the utterance completed")
+	}
+}
+
+// Close : Closes the connection and waits for the results reader to finish
+func (session *RecognizeSession) Close() error {
+	var err error
+	session.closeOnce.Do(func() {
+		session.stateLock.Lock()
+		session.closing = true
+		session.stateLock.Unlock()
+
+		_ = session.writeMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		err = session.conn.Close()
+		<-session.done
+	})
+	return err
+}
+
+// Done : Returns a channel that is closed when the connection has ended
+func (session *RecognizeSession) Done() <-chan struct{} {
+	return session.done
+}
+
+func (session *RecognizeSession) sendStop() error {
+	return session.writeMessage(websocket.TextMessage, stopMessage)
+}
+
+func (session *RecognizeSession) writeMessage(messageType int, data []byte) error {
+	session.writeLock.Lock()
+	defer session.writeLock.Unlock()
+	return session.conn.WriteMessage(messageType, data)
+}
+
+// readResults : Delivers results to the callback until the connection ends
+//
+// The service sends a `listening` state once to acknowledge a start message and once more after it has sent the
+// final results for a stop message.
+func (session *RecognizeSession) readResults() {
+	defer func() {
+		session.conn.Close()
+		session.callback.OnClose()
+		close(session.done)
+	}()
+
+	for {
+		_, result, err := session.conn.ReadMessage()
+		if err != nil {
+			session.stateLock.Lock()
+			closing := session.closing
+			session.stateLock.Unlock()
+			if !closing && !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				session.callback.OnError(websocketerrors.NewReadError(err))
+			}
+			return
+		}
+
+		var websocketResponse WebsocketRecognitionResults
+		if err = json.Unmarshal(result, &websocketResponse); err != nil {
+			session.callback.OnError(err)
+			continue
+		}
+
+		if len(websocketResponse.Error) > 0 {
+			session.callback.OnError(&RecognitionError{Message: websocketResponse.Error})
+			continue
+		}
+
+		if websocketResponse.State == "listening" {
+			session.stateLock.Lock()
+			if !session.listening {
+				session.listening = true
+			} else if session.stopping {
+				session.started = false
+				session.stopping = false
+				close(session.stopped)
+			}
+			session.stateLock.Unlock()
+			continue
+		}
+
+		detailResp := core.DetailedResponse{}
+		detailResp.Result = result
+		detailResp.StatusCode = SUCCESS
+		session.callback.OnData(&detailResp)
+	}
+}
+
+// startMessage : Builds the start message from the recognition parameters of the options
+func startMessage(recognizeWSOptions *RecognizeUsingWebsocketOptions) ([]byte, error) {
+	body := make(map[string]interface{})
+	body["action"] = "start"
+	if recognizeWSOptions.ContentType != nil {
+		body["content-type"] = recognizeWSOptions.ContentType
+	}
+	if recognizeWSOptions.CustomizationWeight != nil {
+		body["customization_weight"] = recognizeWSOptions.CustomizationWeight
+	}
+	if recognizeWSOptions.InactivityTimeout != nil {
+		body["inactivity_timeout"] = recognizeWSOptions.InactivityTimeout
+	}
+	if recognizeWSOptions.InterimResults != nil {
+		body["interim_results"] = recognizeWSOptions.InterimResults
+	}
+	if recognizeWSOptions.Keywords != nil {
+		body["keywords"] = recognizeWSOptions.Keywords
+	}
+	if recognizeWSOptions.KeywordsThreshold != nil {
+		body["keywords_threshold"] = recognizeWSOptions.KeywordsThreshold
+	}
+	if recognizeWSOptions.MaxAlternatives != nil {
+		body["max_alternatives"] = recognizeWSOptions.MaxAlternatives
+	}
+	if recognizeWSOptions.WordAlternativesThreshold != nil {
+		body["word_alternatives_threshold"] = recognizeWSOptions.WordAlternativesThreshold
+	}
+	if recognizeWSOptions.WordConfidence != nil {
+		body["word_confidence"] = recognizeWSOptions.WordConfidence
+	}
+	if recognizeWSOptions.Timestamps != nil {
+		body["timestamps"] = recognizeWSOptions.Timestamps
+	}
+	if recognizeWSOptions.ProfanityFilter != nil {
+		body["profanity_filter"] = recognizeWSOptions.ProfanityFilter
+	}
+	if recognizeWSOptions.SmartFormatting != nil {
+		body["smart_formatting"] = recognizeWSOptions.SmartFormatting
+	}
+	if recognizeWSOptions.SpeakerLabels != nil {
+		body["speaker_labels"] = recognizeWSOptions.SpeakerLabels
+	}
+	if recognizeWSOptions.GrammarName != nil {
+		body["grammar_name"] = recognizeWSOptions.GrammarName
+	}
+	if recognizeWSOptions.Redaction != nil {
+		body["redaction"] = recognizeWSOptions.Redaction
+	}
+	if recognizeWSOptions.ProcessingMetrics != nil {
+		body["processing_metrics"] = recognizeWSOptions.ProcessingMetrics
+	}
+	if recognizeWSOptions.ProcessingMetricsInterval != nil {
+		body["processing_metrics_interval"] = recognizeWSOptions.ProcessingMetricsInterval
+	}
+	if recognizeWSOptions.AudioMetrics != nil {
+		body["audio_metrics"] = recognizeWSOptions.AudioMetrics
+	}
+	if recognizeWSOptions.EndOfPhraseSilenceTime != nil {
+		body["end_of_phrase_silence_time"] = recognizeWSOptions.EndOfPhraseSilenceTime
+	}
+	if recognizeWSOptions.SplitTranscriptAtPhraseEnd != nil {
+		body["split_transcript_at_phrase_end"] = recognizeWSOptions.SplitTranscriptAtPhraseEnd
+	}
+	if recognizeWSOptions.SpeechDetectorSensitivity != nil {
+		body["speech_detector_sensitivity"] = recognizeWSOptions.SpeechDetectorSensitivity
+	}
+	if recognizeWSOptions.BackgroundAudioSuppression != nil {
+		body["background_audio_suppression"] = recognizeWSOptions.BackgroundAudioSuppression
+	}
+	if recognizeWSOptions.CharacterInsertionBias != nil {
+		body["character_insertion_bias"] = recognizeWSOptions.CharacterInsertionBias
+	}
+	if recognizeWSOptions.LowLatency != nil {
+		body["low_latency"] = recognizeWSOptions.LowLatency
+	}
+	return json.Marshal(body)
+}
+
+// stopMessage : The message that ends the audio of an utterance
+var stopMessage = []byte(`{"action":"stop"}`)
@@ -0,0 +1,158 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1/sttest"
+)
+
+// recordingCallback : A RecognizeCallbackWrapper that records what it receives
+type recordingCallback struct {
+	lock   sync.Mutex
+	data   []string
+	errors []error
+	closed int
+}
+
+func (callback *recordingCallback) OnOpen() {}
+
+func (callback *recordingCallback) OnClose() {
+	callback.lock.Lock()
+	defer callback.lock.Unlock()
+	callback.closed++
+}
+
+func (callback *recordingCallback) OnData(resp *core.DetailedResponse) {
+	callback.lock.Lock()
+	defer callback.lock.Unlock()
+	callback.data = append(callback.data, string(resp.Result.([]byte)))
+}
+
+func (callback *recordingCallback) OnError(err error) {
+	callback.lock.Lock()
+	defer callback.lock.Unlock()
+	callback.errors = append(callback.errors, err)
+}
+
+// closeOrderCallback : A recordingCallback that records whether the session was done when OnClose was called
+type closeOrderCallback struct {
+	recordingCallback
+	session     *speechtotextv1.RecognizeSession
+	doneAtClose bool
+}
+
+func (callback *closeOrderCallback) OnClose() {
+	select {
+	case <-callback.session.Done():
+		callback.doneAtClose = true
+	default:
+	}
+	callback.recordingCallback.OnClose()
+}
+
+// newWebsocketService : Returns a service that connects to a local websocket test server
+func newWebsocketService(server *sttest.Server) *speechtotextv1.SpeechToTextV1 {
+	speechToTextService, serviceErr := speechtotextv1.NewSpeechToTextV1(&speechtotextv1.SpeechToTextV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	Expect(serviceErr).To(BeNil())
+	return speechToTextService
+}
+
+var _ = Describe(`RecognizeSession`, func() {
+	var testServer *sttest.Server
+	var speechToTextService *speechtotextv1.SpeechToTextV1
+
+	BeforeEach(func() {
+		testServer = sttest.NewTranscribingServer(sttest.InterimResult(0, "hel"), sttest.FinalResult(0, "hello"))
+		speechToTextService = newWebsocketService(testServer)
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`Recognizes several utterances over one session`, func() {
+		callback := new(recordingCallback)
+		recognizeOptions := speechToTextService.NewRecognizeUsingWebsocketOptions(nil, "audio/l16;rate=16000")
+		session, err := speechToTextService.OpenRecognizeSession(recognizeOptions, callback)
+		Expect(err).To(BeNil())
+
+		for utterance := 0; utterance < 2; utterance++ {
+			if utterance > 0 {
+				Expect(session.Start()).To(Succeed())
+			}
+			_, err = session.Write([]byte("audio"))
+			Expect(err).To(BeNil())
+			Expect(session.Stop()).To(Succeed())
+		}
+		_, err = session.Write([]byte("audio"))
+		Expect(err).ToNot(BeNil())
+		Expect(session.Close()).To(Succeed())
+
+		Expect(callback.data).To(HaveLen(4))
+		Expect(callback.errors).To(BeEmpty())
+		Expect(callback.closed).To(Equal(1))
+		Expect(testServer.Connections()).To(Equal(1))
+	})
+	It(`Rejects a second Start while an utterance is in progress`, func() {
+		session, err := speechToTextService.OpenRecognizeSession(
+			speechToTextService.NewRecognizeUsingWebsocketOptions(nil, "audio/l16;rate=16000"), new(recordingCallback))
+		Expect(err).To(BeNil())
+		defer session.Close()
+
+		Expect(session.Start()).ToNot(Succeed())
+	})
+	It(`Rejects Stop without an utterance in progress`, func() {
+		session, err := speechToTextService.OpenRecognizeSession(
+			speechToTextService.NewRecognizeUsingWebsocketOptions(nil, "audio/l16;rate=16000"), new(recordingCallback))
+		Expect(err).To(BeNil())
+		defer session.Close()
+
+		Expect(session.Stop()).To(Succeed())
+		Expect(session.Stop()).ToNot(Succeed())
+	})
+	It(`Calls OnClose once, before Done is closed`, func() {
+		callback := &closeOrderCallback{}
+		recognizeOptions := speechToTextService.NewRecognizeUsingWebsocketOptions(nil, "audio/l16;rate=16000")
+		session, err := speechToTextService.OpenRecognizeSession(recognizeOptions, callback)
+		Expect(err).To(BeNil())
+		callback.session = session
+
+		Expect(session.Close()).To(Succeed())
+		Expect(session.Close()).To(Succeed())
+		<-session.Done()
+
+		Expect(callback.closed).To(Equal(1))
+		Expect(callback.doneAtClose).To(BeFalse())
+		Expect(session.Start()).ToNot(Succeed())
+	})
+	It(`Validates its arguments`, func() {
+		_, err := speechToTextService.OpenRecognizeSession(nil, new(recordingCallback))
+		Expect(err).ToNot(BeNil())
+		_, err = speechToTextService.OpenRecognizeSession(
+			speechToTextService.NewRecognizeUsingWebsocketOptions(nil, "audio/l16;rate=16000"), nil)
+		Expect(err).ToNot(BeNil())
+		Expect(testServer.Connections()).To(Equal(0))
+	})
+})
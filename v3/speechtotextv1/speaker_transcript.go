@@ -0,0 +1,229 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SpeakerUnknown is the speaker of words that no speaker label covers yet.
+const SpeakerUnknown int64 = -1
+
+// SpeakerTurn : A run of consecutive words attributed to the same speaker
+type SpeakerTurn struct {
+	// The speaker, as numbered by the service, or SpeakerUnknown.
+	Speaker int64
+
+	// The start time of the first word, in seconds from the start of the audio.
+	StartTime float64
+
+	// The end time of the last word, in seconds from the start of the audio.
+	EndTime float64
+
+	// The words of the turn, separated by spaces.
+	Text string
+
+	// The mean confidence of the speaker labels of the words, or 0 when the speaker is unknown.
+	Confidence float64
+
+	// Whether the words come from final results and their speaker labels are final, so that the turn will not
+	// change.
+	Final bool
+}
+
+// SpeakerTranscriptBuilder : Joins the speaker labels of a recognition with the word timestamps of its results to
+// produce speaker turns. Recognition must be requested with both `speaker_labels` and `timestamps`.
+//
+// Results and labels may arrive in separate messages of the websocket interface. Labels that are not final are
+// replaced when the service sends a revised label for the same start time, and dropped when a final label overlaps
+// them; results are merged by result index as by TranscriptAssembler. A builder is safe for concurrent use.
+type SpeakerTranscriptBuilder struct {
+	assembler *TranscriptAssembler
+
+	lock   sync.Mutex
+	labels map[float64]SpeakerLabel
+}
+
+// NewSpeakerTranscriptBuilder : Instantiate an empty SpeakerTranscriptBuilder
+func NewSpeakerTranscriptBuilder() *SpeakerTranscriptBuilder {
+	return &SpeakerTranscriptBuilder{
+		assembler: NewTranscriptAssembler(),
+		labels:    make(map[float64]SpeakerLabel),
+	}
+}
+
+// Add : Merges results and their speaker labels. Results with malformed word timestamps or speaker labels are
+// rejected without changing the builder.
+func (builder *SpeakerTranscriptBuilder) Add(results *SpeechRecognitionResults) error {
+	if results == nil {
+		return nil
+	}
+	speakerLabels, err := results.GetSpeakerLabels()
+	if err != nil {
+		return err
+	}
+	for i := range results.Results {
+		if len(results.Results[i].Alternatives) == 0 {
+			continue
+		}
+		if _, err := results.Results[i].Alternatives[0].GetWordTimestamps(); err != nil {
+			return fmt.Errorf("results[%d]: %s", i, err)
+		}
+	}
+
+	builder.lock.Lock()
+	for _, speakerLabel := range speakerLabels {
+		builder.addLabel(speakerLabel)
+	}
+	builder.lock.Unlock()
+
+	builder.assembler.Add(results)
+	return nil
+}
+
+// addLabel : Stores a speaker label. A final label replaces the labels that are not final within its time range, which
+// the service revised with other start times; a label that is not final is ignored where a final label exists.
+func (builder *SpeakerTranscriptBuilder) addLabel(speakerLabel SpeakerLabel) {
+	for from, current := range builder.labels {
+		if current.From >= speakerLabel.To || current.To <= speakerLabel.From || from == speakerLabel.From {
+			continue
+		}
+		if current.Final && !speakerLabel.Final {
+			return
+		}
+		if speakerLabel.Final && !current.Final {
+			delete(builder.labels, from)
+		}
+	}
+	if current, ok := builder.labels[speakerLabel.From]; ok && current.Final && !speakerLabel.Final {
+		return
+	}
+	builder.labels[speakerLabel.From] = speakerLabel
+}
+
+// AddWebsocketResults : Merges a message of the websocket interface
+func (builder *SpeakerTranscriptBuilder) AddWebsocketResults(results *WebsocketRecognitionResults) error {
+	if results == nil {
+		return nil
+	}
+	return builder.Add(&results.SpeechRecognitionResults)
+}
+
+// AddRecognitionJob : Merges the results of a completed asynchronous job
+func (builder *SpeakerTranscriptBuilder) AddRecognitionJob(job *RecognitionJob) error {
+	if job == nil {
+		return nil
+	}
+	for i := range job.Results {
+		if err := builder.Add(&job.Results[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Turns : Returns the speaker turns of the words received so far, in time order. Each word is attributed to the
+// speaker label that overlaps it the most.
+func (builder *SpeakerTranscriptBuilder) Turns() []SpeakerTurn {
+	builder.lock.Lock()
+	labels := make([]SpeakerLabel, 0, len(builder.labels))
+	for _, speakerLabel := range builder.labels {
+		labels = append(labels, speakerLabel)
+	}
+	builder.lock.Unlock()
+	sort.Slice(labels, func(i, j int) bool { return labels[i].From < labels[j].From })
+
+	var turns []SpeakerTurn
+	var words []string
+	var confidence float64
+	var labelled int
+	flush := func() {
+		if len(words) == 0 {
+			return
+		}
+		turn := &turns[len(turns)-1]
+		turn.Text = strings.Join(words, " ")
+		if labelled > 0 {
+			turn.Confidence = confidence / float64(labelled)
+		}
+		words, confidence, labelled = nil, 0, 0
+	}
+
+	for _, result := range builder.assembler.Results() {
+		if len(result.Alternatives) == 0 {
+			continue
+		}
+		timestamps, _ := result.Alternatives[0].GetWordTimestamps()
+		for _, timestamp := range timestamps {
+			speakerLabel, found := speakerLabelFor(labels, timestamp)
+			speaker := SpeakerUnknown
+			if found {
+				speaker = speakerLabel.Speaker
+			}
+			final := isFinalResult(result) && found && speakerLabel.Final
+
+			if len(turns) == 0 || turns[len(turns)-1].Speaker != speaker {
+				flush()
+				turns = append(turns, SpeakerTurn{Speaker: speaker, StartTime: timestamp.StartTime, Final: true})
+			}
+			turn := &turns[len(turns)-1]
+			turn.EndTime = timestamp.EndTime
+			turn.Final = turn.Final && final
+			words = append(words, timestamp.Word)
+			if found {
+				confidence += speakerLabel.Confidence
+				labelled++
+			}
+		}
+	}
+	flush()
+	return turns
+}
+
+// speakerLabelFor : Finds the label, among non-overlapping labels sorted by start time, that overlaps a word the most.
+// The service aligns labels with words, so an exact match on the start time is tried first.
+func speakerLabelFor(labels []SpeakerLabel, timestamp WordTimestamp) (SpeakerLabel, bool) {
+	i := sort.Search(len(labels), func(i int) bool { return labels[i].From >= timestamp.StartTime })
+	if i < len(labels) && labels[i].From == timestamp.StartTime {
+		return labels[i], true
+	}
+
+	best, bestOverlap, found := SpeakerLabel{}, -1.0, false
+	if i > 0 && labels[i-1].To > timestamp.StartTime {
+		best, bestOverlap, found = labels[i-1], timeOverlap(labels[i-1], timestamp), true
+	}
+	for j := i; j < len(labels) && labels[j].From < timestamp.EndTime; j++ {
+		if overlap := timeOverlap(labels[j], timestamp); overlap > bestOverlap {
+			best, bestOverlap, found = labels[j], overlap, true
+		}
+	}
+	return best, found
+}
+
+func timeOverlap(speakerLabel SpeakerLabel, timestamp WordTimestamp) float64 {
+	start, end := speakerLabel.From, speakerLabel.To
+	if timestamp.StartTime > start {
+		start = timestamp.StartTime
+	}
+	if timestamp.EndTime < end {
+		end = timestamp.EndTime
+	}
+	return end - start
+}
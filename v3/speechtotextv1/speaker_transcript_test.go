@@ -0,0 +1,150 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+)
+
+func timedResult(resultIndex int64, final bool, timestamps ...speechtotextv1.WordTimestamp) *speechtotextv1.SpeechRecognitionResults {
+	alternative := speechtotextv1.SpeechRecognitionAlternative{Transcript: core.StringPtr("")}
+	alternative.SetWordTimestamps(timestamps)
+	return &speechtotextv1.SpeechRecognitionResults{
+		ResultIndex: core.Int64Ptr(resultIndex),
+		Results: []speechtotextv1.SpeechRecognitionResult{
+			{Final: core.BoolPtr(final), Alternatives: []speechtotextv1.SpeechRecognitionAlternative{alternative}},
+		},
+	}
+}
+
+func speakerLabelsResult(from float32, to float32, speaker int64, confidence float32, final bool) speechtotextv1.SpeakerLabelsResult {
+	return speechtotextv1.SpeakerLabelsResult{
+		From:       core.Float32Ptr(from),
+		To:         core.Float32Ptr(to),
+		Speaker:    core.Int64Ptr(speaker),
+		Confidence: core.Float32Ptr(confidence),
+		Final:      core.BoolPtr(final),
+	}
+}
+
+var _ = Describe(`SpeakerTranscriptBuilder`, func() {
+	It(`Groups words into speaker turns`, func() {
+		builder := speechtotextv1.NewSpeakerTranscriptBuilder()
+		results := timedResult(0, true,
+			speechtotextv1.WordTimestamp{Word: "hello", StartTime: 0.5, EndTime: 1},
+			speechtotextv1.WordTimestamp{Word: "there", StartTime: 1, EndTime: 1.5},
+			speechtotextv1.WordTimestamp{Word: "hi", StartTime: 2, EndTime: 2.5},
+		)
+		results.SpeakerLabels = []speechtotextv1.SpeakerLabelsResult{
+			speakerLabelsResult(0.5, 1, 0, 0.5, true),
+			speakerLabelsResult(1, 1.5, 0, 0.75, true),
+			speakerLabelsResult(2, 2.5, 1, 0.25, true),
+		}
+		Expect(builder.Add(results)).To(Succeed())
+
+		Expect(builder.Turns()).To(Equal([]speechtotextv1.SpeakerTurn{
+			{Speaker: 0, StartTime: 0.5, EndTime: 1.5, Text: "hello there", Confidence: 0.625, Final: true},
+			{Speaker: 1, StartTime: 2, EndTime: 2.5, Text: "hi", Confidence: 0.25, Final: true},
+		}))
+	})
+
+	It(`Applies revised labels that arrive in later messages`, func() {
+		builder := speechtotextv1.NewSpeakerTranscriptBuilder()
+		Expect(builder.Add(timedResult(0, true,
+			speechtotextv1.WordTimestamp{Word: "good", StartTime: 0, EndTime: 0.5},
+			speechtotextv1.WordTimestamp{Word: "morning", StartTime: 0.5, EndTime: 1},
+		))).To(Succeed())
+
+		turns := builder.Turns()
+		Expect(turns).To(HaveLen(1))
+		Expect(turns[0].Speaker).To(Equal(speechtotextv1.SpeakerUnknown))
+		Expect(turns[0].Final).To(BeFalse())
+
+		Expect(builder.Add(&speechtotextv1.SpeechRecognitionResults{SpeakerLabels: []speechtotextv1.SpeakerLabelsResult{
+			speakerLabelsResult(0, 0.5, 0, 0.5, false),
+			speakerLabelsResult(0.5, 1, 0, 0.5, false),
+		}})).To(Succeed())
+		turns = builder.Turns()
+		Expect(turns).To(HaveLen(1))
+		Expect(turns[0].Speaker).To(Equal(int64(0)))
+		Expect(turns[0].Final).To(BeFalse())
+
+		Expect(builder.Add(&speechtotextv1.SpeechRecognitionResults{SpeakerLabels: []speechtotextv1.SpeakerLabelsResult{
+			speakerLabelsResult(0, 0.5, 0, 0.5, true),
+			speakerLabelsResult(0.5, 1, 1, 0.5, true),
+		}})).To(Succeed())
+		// A final label is not replaced by a later interim one
+		Expect(builder.Add(&speechtotextv1.SpeechRecognitionResults{SpeakerLabels: []speechtotextv1.SpeakerLabelsResult{
+			speakerLabelsResult(0.5, 1, 0, 0.5, false),
+		}})).To(Succeed())
+
+		turns = builder.Turns()
+		Expect(turns).To(HaveLen(2))
+		Expect(turns[0].Text).To(Equal("good"))
+		Expect(turns[1].Text).To(Equal("morning"))
+		Expect(turns[1].Speaker).To(Equal(int64(1)))
+		Expect(turns[1].Final).To(BeTrue())
+	})
+
+	It(`Drops the labels that are not final within the time range of a final label`, func() {
+		builder := speechtotextv1.NewSpeakerTranscriptBuilder()
+		results := timedResult(0, true,
+			speechtotextv1.WordTimestamp{Word: "good", StartTime: 0, EndTime: 0.5},
+			speechtotextv1.WordTimestamp{Word: "morning", StartTime: 0.5, EndTime: 1},
+		)
+		results.SpeakerLabels = []speechtotextv1.SpeakerLabelsResult{
+			speakerLabelsResult(0, 0.5, 1, 0.5, false),
+			speakerLabelsResult(0.5, 1, 1, 0.5, false),
+		}
+		Expect(builder.Add(results)).To(Succeed())
+
+		Expect(builder.Add(&speechtotextv1.SpeechRecognitionResults{SpeakerLabels: []speechtotextv1.SpeakerLabelsResult{
+			speakerLabelsResult(0, 1, 0, 0.75, true),
+		}})).To(Succeed())
+		// An interim label within the range of a final label is stale
+		Expect(builder.Add(&speechtotextv1.SpeechRecognitionResults{SpeakerLabels: []speechtotextv1.SpeakerLabelsResult{
+			speakerLabelsResult(0.5, 1, 1, 0.5, false),
+		}})).To(Succeed())
+
+		Expect(builder.Turns()).To(Equal([]speechtotextv1.SpeakerTurn{
+			{Speaker: 0, StartTime: 0, EndTime: 1, Text: "good morning", Confidence: 0.75, Final: true},
+		}))
+	})
+
+	It(`Attributes words to the most overlapping label`, func() {
+		builder := speechtotextv1.NewSpeakerTranscriptBuilder()
+		results := timedResult(0, false, speechtotextv1.WordTimestamp{Word: "overlap", StartTime: 1.1, EndTime: 2})
+		results.SpeakerLabels = []speechtotextv1.SpeakerLabelsResult{
+			speakerLabelsResult(1, 1.3, 0, 0.5, true),
+			speakerLabelsResult(1.3, 2, 1, 0.5, true),
+		}
+		Expect(builder.Add(results)).To(Succeed())
+		turns := builder.Turns()
+		Expect(turns).To(HaveLen(1))
+		Expect(turns[0].Speaker).To(Equal(int64(1)))
+		Expect(turns[0].Final).To(BeFalse())
+	})
+
+	It(`Rejects malformed timestamps`, func() {
+		builder := speechtotextv1.NewSpeakerTranscriptBuilder()
+		Expect(builder.Add(unmarshalRecognizeFixture(generatedRecognizeFixture))).ToNot(Succeed())
+		Expect(builder.Turns()).To(BeEmpty())
+	})
+})
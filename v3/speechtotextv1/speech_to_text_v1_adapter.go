@@ -1,10 +1,14 @@
 package speechtotextv1
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/IBM/go-sdk-core/v5/core"
+	common "github.com/watson-developer-cloud/go-sdk/v3/common"
 
 	"net/http"
 	"net/url"
@@ -45,6 +49,26 @@ type RecognizeUsingWebsocketOptions struct {
 	// instead of at periodic intervals, set the value to a large number. If the value is larger than the duration of the
 	// audio, the service returns processing metrics only for transcription events.
 	ProcessingMetricsInterval *float32 `json:"processing_metrics_interval,omitempty"`
+
+	// If set, RecognizeUsingWebsocketWithContext reconnects after connection failures and resumes from the last audio
+	// acknowledged by a final result. Requires a raw audio content type, see ParseRawAudioFormat.
+	Reconnect *ReconnectOptions `json:"-"`
+
+	// The number of bytes of audio sent in each websocket message. Defaults to 2048.
+	ChunkSize *int64 `json:"-"`
+
+	// How quickly audio is sent to the service. Defaults to `fixed_delay`.
+	Pacing *string `json:"-"`
+
+	// The number of bytes per second at which audio is sent with `rate` pacing.
+	PacingRate *int64 `json:"-"`
+
+	// A rate limiter that is shared with other connections, applied in addition to the pacing.
+	RateLimiter *TokenBucket `json:"-"`
+
+	// If `true`, the bearer token provided by the authenticator is sent in the `access_token` query parameter instead
+	// of the `Authorization` header, for proxies that strip headers from websocket handshakes.
+	AccessTokenQuery *bool `json:"-"`
 }
 
 // SetAction: Allows user to set the Action
@@ -71,11 +95,47 @@ func (recognizeWSOptions *RecognizeUsingWebsocketOptions) SetProcessingMetricsIn
 	return recognizeWSOptions
 }
 
+// SetReconnect : Allow user to set Reconnect
+func (recognizeWSOptions *RecognizeUsingWebsocketOptions) SetReconnect(reconnect *ReconnectOptions) *RecognizeUsingWebsocketOptions {
+	recognizeWSOptions.Reconnect = reconnect
+	return recognizeWSOptions
+}
+
+// SetChunkSize : Allow user to set ChunkSize
+func (recognizeWSOptions *RecognizeUsingWebsocketOptions) SetChunkSize(chunkSize int64) *RecognizeUsingWebsocketOptions {
+	recognizeWSOptions.ChunkSize = core.Int64Ptr(chunkSize)
+	return recognizeWSOptions
+}
+
+// SetPacing : Allow user to set Pacing
+func (recognizeWSOptions *RecognizeUsingWebsocketOptions) SetPacing(pacing string) *RecognizeUsingWebsocketOptions {
+	recognizeWSOptions.Pacing = core.StringPtr(pacing)
+	return recognizeWSOptions
+}
+
+// SetPacingRate : Allow user to set PacingRate
+func (recognizeWSOptions *RecognizeUsingWebsocketOptions) SetPacingRate(pacingRate int64) *RecognizeUsingWebsocketOptions {
+	recognizeWSOptions.PacingRate = core.Int64Ptr(pacingRate)
+	return recognizeWSOptions
+}
+
+// SetRateLimiter : Allow user to set RateLimiter
+func (recognizeWSOptions *RecognizeUsingWebsocketOptions) SetRateLimiter(rateLimiter *TokenBucket) *RecognizeUsingWebsocketOptions {
+	recognizeWSOptions.RateLimiter = rateLimiter
+	return recognizeWSOptions
+}
+
+// SetAccessTokenQuery : Allow user to set AccessTokenQuery
+func (recognizeWSOptions *RecognizeUsingWebsocketOptions) SetAccessTokenQuery(accessTokenQuery bool) *RecognizeUsingWebsocketOptions {
+	recognizeWSOptions.AccessTokenQuery = core.BoolPtr(accessTokenQuery)
+	return recognizeWSOptions
+}
+
 // NewRecognizeUsingWebsocketOptions: Instantiate RecognizeOptions to enable websocket support
 func (speechToText *SpeechToTextV1) NewRecognizeUsingWebsocketOptions(audio io.ReadCloser, contentType string) *RecognizeUsingWebsocketOptions {
 	recognizeOptions := speechToText.NewRecognizeOptions(audio)
 	recognizeOptions.SetContentType(contentType)
-	recognizeWSOptions := &RecognizeUsingWebsocketOptions{*recognizeOptions, nil, nil, nil, nil}
+	recognizeWSOptions := &RecognizeUsingWebsocketOptions{RecognizeOptions: *recognizeOptions}
 	return recognizeWSOptions
 }
 
@@ -95,34 +155,216 @@ type RecognizeCallbackWrapper interface {
 	OnError(error)
 }
 
-// RecognizeUsingWebsocket: Recognize audio over websocket connection
+// RecognizeUsingWebsocket: Recognize audio over websocket connection. Every error, including those that prevent the
+// connection from being opened, is delivered to the callback's OnError; use RecognizeUsingWebsocketWithError to have
+// those returned instead.
 func (speechToText *SpeechToTextV1) RecognizeUsingWebsocket(recognizeWSOptions *RecognizeUsingWebsocketOptions, callback RecognizeCallbackWrapper) {
+	if err := speechToText.RecognizeUsingWebsocketWithError(recognizeWSOptions, callback); err != nil && callback != nil {
+		callback.OnError(err)
+	}
+}
+
+// RecognizeUsingWebsocketWithError: Recognize audio over websocket connection. Errors that prevent the connection from
+// being opened are returned; errors that occur once recognition has started are delivered to the callback's OnError.
+func (speechToText *SpeechToTextV1) RecognizeUsingWebsocketWithError(recognizeWSOptions *RecognizeUsingWebsocketOptions, callback RecognizeCallbackWrapper) error {
 	if err := core.ValidateNotNil(recognizeWSOptions, "recognizeOptions cannot be nil"); err != nil {
-		panic(err)
+		return err
 	}
 	if err := core.ValidateStruct(recognizeWSOptions, "recognizeOptions"); err != nil {
-		panic(err)
+		return err
+	}
+	if err := core.ValidateNotNil(callback, "callback cannot be nil"); err != nil {
+		return err
+	}
+	if _, err := newAudioPacer(recognizeWSOptions); err != nil {
+		return err
+	}
+
+	dialURL, param, headers, err := speechToText.recognizeDialParams(recognizeWSOptions)
+	if err != nil {
+		return err
 	}
 
+	conn, err := dialRecognize(dialURL, param, headers)
+	if err != nil {
+		return err
+	}
+
+	recognizeListener := RecognizeListener{Callback: callback, IsClosed: make(chan bool, 1), done: make(chan struct{})}
+	recognizeListener.listen(recognizeWSOptions, conn)
+	return nil
+}
+
+// RecognizeUsingWebsocketWithContext: Recognize audio over websocket connection, delivering decoded results on a
+// channel. The results channel is closed when recognition ends; the error channel then yields at most one error
+// before it is closed too. Cancelling the context sends the stop message and closes the connection.
+func (speechToText *SpeechToTextV1) RecognizeUsingWebsocketWithContext(ctx context.Context, recognizeWSOptions *RecognizeUsingWebsocketOptions) (<-chan *WebsocketRecognitionResults, <-chan error) {
+	results := make(chan *WebsocketRecognitionResults)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(results)
+
+		if err := core.ValidateNotNil(recognizeWSOptions, "recognizeOptions cannot be nil"); err != nil {
+			errs <- err
+			return
+		}
+		if err := core.ValidateStruct(recognizeWSOptions, "recognizeOptions"); err != nil {
+			errs <- err
+			return
+		}
+		pacer, err := newAudioPacer(recognizeWSOptions)
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		if recognizeWSOptions.Reconnect != nil {
+			speechToText.recognizeWithReconnect(ctx, recognizeWSOptions, pacer, results, errs)
+			return
+		}
+
+		callback := &channelRecognizeCallback{ctx: ctx, results: results, errs: errs, failed: make(chan struct{})}
+		session, err := speechToText.OpenRecognizeSession(recognizeWSOptions, callback)
+		if err != nil {
+			callback.OnError(err)
+			return
+		}
+		defer session.Close()
+
+		sent := make(chan error, 1)
+		go func() {
+			sent <- sendSessionAudio(ctx, session, recognizeWSOptions.Audio, pacer)
+		}()
+
+		select {
+		case err = <-sent:
+			if err == nil {
+				err = session.Stop()
+			} else if ctx.Err() != nil {
+				_ = session.sendStop()
+			}
+			if err != nil {
+				callback.OnError(err)
+			}
+		case <-callback.failed:
+		case <-session.Done():
+		case <-ctx.Done():
+			_ = session.sendStop()
+			callback.OnError(ctx.Err())
+		}
+	}()
+
+	return results, errs
+}
+
+// sendSessionAudio : Sends audio to the session until the reader is exhausted or the context is done
+func sendSessionAudio(ctx context.Context, session *RecognizeSession, audio io.Reader, pacer *audioPacer) error {
+	chunk := make([]byte, pacer.chunkSize)
+	for {
+		bytesRead, err := audio.Read(chunk)
+		if bytesRead > 0 {
+			if waitErr := pacer.wait(ctx, bytesRead); waitErr != nil {
+				return waitErr
+			}
+			if _, writeErr := session.Write(chunk[:bytesRead]); writeErr != nil {
+				return writeErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// channelRecognizeCallback : Decodes session data onto the results channel and keeps the first error
+type channelRecognizeCallback struct {
+	ctx     context.Context
+	results chan<- *WebsocketRecognitionResults
+	errs    chan<- error
+
+	failOnce sync.Once
+	failed   chan struct{}
+}
+
+func (callback *channelRecognizeCallback) OnOpen() {}
+
+func (callback *channelRecognizeCallback) OnClose() {}
+
+func (callback *channelRecognizeCallback) OnData(resp *core.DetailedResponse) {
+	result := new(WebsocketRecognitionResults)
+	if err := json.Unmarshal(resp.Result.([]byte), result); err != nil {
+		callback.OnError(err)
+		return
+	}
+	select {
+	case callback.results <- result:
+	case <-callback.failed:
+	case <-callback.ctx.Done():
+	}
+}
+
+func (callback *channelRecognizeCallback) OnError(err error) {
+	callback.failOnce.Do(func() {
+		callback.errs <- err
+		close(callback.failed)
+	})
+}
+
+// recognizeDialParams : Builds the URL, query parameters and authenticated headers used to open a connection to the
+// recognize endpoint. Parameters that the service accepts only on the connection URL are sent as query parameters;
+// the recognition parameters are sent in the start message, see startMessage.
+func (speechToText *SpeechToTextV1) recognizeDialParams(recognizeWSOptions *RecognizeUsingWebsocketOptions) (dialURL string, param url.Values, headers http.Header, err error) {
 	// Add authentication to the outbound request.
 	if speechToText.Service.Options.Authenticator == nil {
-		panic(fmt.Errorf("Authentication information was not properly configured."))
+		err = &AuthenticationError{Err: fmt.Errorf("Authentication information was not properly configured.")}
+		return
 	}
 
 	// Create a dummy request for authenticate
 	// Need to update design to let recognizeListener take in a request object
 	req, _ := http.NewRequest("POST", speechToText.Service.Options.URL, nil)
-	err := speechToText.Service.Options.Authenticator.Authenticate(req)
-	if err != nil {
-		panic(err)
+	for headerName, headerValue := range speechToText.Service.DefaultHeaders {
+		req.Header.Add(headerName, strings.Join(headerValue, ""))
+	}
+	for headerName, headerValue := range recognizeWSOptions.Headers {
+		req.Header.Set(headerName, headerValue)
+	}
+	sdkHeaders := common.GetSdkHeaders("speech_to_text", "V1", "Recognize")
+	for headerName, headerValue := range sdkHeaders {
+		req.Header.Set(headerName, headerValue)
+	}
+	if req.Header.Get("User-Agent") == "" && speechToText.Service.UserAgent != "" {
+		req.Header.Set("User-Agent", speechToText.Service.UserAgent)
+	}
+	if recognizeWSOptions.ContentType != nil {
+		req.Header.Set("Content-Type", *recognizeWSOptions.ContentType)
 	}
-	headers := req.Header
 
-	headers.Set("Content-Type", *recognizeWSOptions.ContentType)
+	err = speechToText.Service.Options.Authenticator.Authenticate(req)
+	if err != nil {
+		err = &AuthenticationError{Err: err}
+		return
+	}
+	headers = req.Header
 
-	dialURL := strings.Replace(speechToText.Service.Options.URL, "https", "wss", 1)
-	param := url.Values{}
+	// https -> wss, http -> ws
+	dialURL = strings.Replace(speechToText.Service.Options.URL, "http", "ws", 1)
+	param = url.Values{}
 
+	if recognizeWSOptions.AccessTokenQuery != nil && *recognizeWSOptions.AccessTokenQuery {
+		authorization := headers.Get("Authorization")
+		if !strings.HasPrefix(authorization, "Bearer ") {
+			err = &AuthenticationError{Err: fmt.Errorf("the authenticator did not provide a bearer token for the access_token query parameter")}
+			return
+		}
+		param.Set("access_token", strings.TrimPrefix(authorization, "Bearer "))
+		headers.Del("Authorization")
+	}
 	if recognizeWSOptions.Model != nil {
 		param.Set("model", *recognizeWSOptions.Model)
 	}
@@ -135,6 +377,16 @@ func (speechToText *SpeechToTextV1) RecognizeUsingWebsocket(recognizeWSOptions *
 	if recognizeWSOptions.BaseModelVersion != nil {
 		param.Set("base_model_version", *recognizeWSOptions.BaseModelVersion)
 	}
+	if recognizeWSOptions.CustomizationID != nil {
+		param.Set("customization_id", *recognizeWSOptions.CustomizationID)
+	}
 
-	speechToText.NewRecognizeListener(callback, recognizeWSOptions, dialURL, param, headers)
+	// The service reads the data privacy headers from the query of websocket connections
+	if learningOptOut := headers.Get("X-Watson-Learning-Opt-Out"); learningOptOut != "" {
+		param.Set("x-watson-learning-opt-out", learningOptOut)
+	}
+	if metadata := headers.Get("X-Watson-Metadata"); metadata != "" {
+		param.Set("x-watson-metadata", metadata)
+	}
+	return
 }
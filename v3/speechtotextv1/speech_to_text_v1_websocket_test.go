@@ -0,0 +1,162 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1/sttest"
+)
+
+// recognizeHandshake : What a mock recognize endpoint received when a connection was opened
+type recognizeHandshake struct {
+	query        url.Values
+	header       http.Header
+	startMessage map[string]interface{}
+}
+
+var _ = Describe(`SpeechToTextV1 websocket`, func() {
+	var testServer *sttest.Server
+	var handshakes chan recognizeHandshake
+
+	BeforeEach(func() {
+		handshakes = make(chan recognizeHandshake, 1)
+		testServer = sttest.NewServer(func(conn *sttest.Conn) {
+			defer GinkgoRecover()
+			startMessage, err := conn.ReadStart()
+			Expect(err).To(BeNil())
+			handshakes <- recognizeHandshake{query: conn.Request.URL.Query(), header: conn.Request.Header, startMessage: startMessage}
+			Expect(conn.SendListening()).To(Succeed())
+			_, err = conn.ReadAudio()
+			Expect(err).To(BeNil())
+			Expect(conn.SendListening()).To(Succeed())
+		})
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	recognize := func(authenticator core.Authenticator, setOption func(*speechtotextv1.RecognizeUsingWebsocketOptions)) recognizeHandshake {
+		speechToTextService, serviceErr := speechtotextv1.NewSpeechToTextV1(&speechtotextv1.SpeechToTextV1Options{
+			URL:           testServer.URL,
+			Authenticator: authenticator,
+		})
+		Expect(serviceErr).To(BeNil())
+
+		recognizeOptions := speechToTextService.NewRecognizeUsingWebsocketOptions(CreateMockReader("This is a mock file."), "audio/l16;rate=16000")
+		recognizeOptions.SetPacing(speechtotextv1.RecognizeUsingWebsocketOptionsPacingNoneConst)
+		setOption(recognizeOptions)
+
+		results, errs := speechToTextService.RecognizeUsingWebsocketWithContext(context.Background(), recognizeOptions)
+		for range results {
+		}
+		Expect(<-errs).To(BeNil())
+		return <-handshakes
+	}
+
+	table.DescribeTable(`Recognize parameters are sent where the service expects them`,
+		func(setOption func(*speechtotextv1.RecognizeUsingWebsocketOptions), location string, name string, expected interface{}) {
+			handshake := recognize(&core.NoAuthAuthenticator{}, setOption)
+			switch location {
+			case "query":
+				Expect(handshake.query.Get(name)).To(Equal(expected))
+				Expect(handshake.startMessage).ToNot(HaveKey(name))
+			case "start":
+				Expect(handshake.startMessage).To(HaveKeyWithValue(name, expected))
+				Expect(handshake.query).ToNot(HaveKey(name))
+			case "header":
+				Expect(handshake.header.Get(name)).To(Equal(expected))
+			}
+		},
+		table.Entry("model", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetModel("en-US_Telephony") }, "query", "model", "en-US_Telephony"),
+		table.Entry("language_customization_id", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetLanguageCustomizationID("lm") }, "query", "language_customization_id", "lm"),
+		table.Entry("acoustic_customization_id", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetAcousticCustomizationID("am") }, "query", "acoustic_customization_id", "am"),
+		table.Entry("base_model_version", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetBaseModelVersion("v1") }, "query", "base_model_version", "v1"),
+		table.Entry("customization_id", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetCustomizationID("lm") }, "query", "customization_id", "lm"),
+		table.Entry("x-watson-learning-opt-out", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) {
+			o.SetHeaders(map[string]string{"X-Watson-Learning-Opt-Out": "true"})
+		}, "query", "x-watson-learning-opt-out", "true"),
+		table.Entry("x-watson-metadata", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) {
+			o.SetHeaders(map[string]string{"X-Watson-Metadata": "customer_id=abc"})
+		}, "query", "x-watson-metadata", "customer_id=abc"),
+		table.Entry("content-type", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) {}, "start", "content-type", "audio/l16;rate=16000"),
+		table.Entry("customization_weight", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetCustomizationWeight(0.5) }, "start", "customization_weight", 0.5),
+		table.Entry("inactivity_timeout", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetInactivityTimeout(-1) }, "start", "inactivity_timeout", -1.0),
+		table.Entry("interim_results", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetInterimResults(true) }, "start", "interim_results", true),
+		table.Entry("keywords", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetKeywords([]string{"hello"}) }, "start", "keywords", []interface{}{"hello"}),
+		table.Entry("keywords_threshold", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetKeywordsThreshold(0.5) }, "start", "keywords_threshold", 0.5),
+		table.Entry("max_alternatives", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetMaxAlternatives(3) }, "start", "max_alternatives", 3.0),
+		table.Entry("word_alternatives_threshold", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetWordAlternativesThreshold(0.25) }, "start", "word_alternatives_threshold", 0.25),
+		table.Entry("word_confidence", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetWordConfidence(true) }, "start", "word_confidence", true),
+		table.Entry("timestamps", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetTimestamps(true) }, "start", "timestamps", true),
+		table.Entry("profanity_filter", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetProfanityFilter(false) }, "start", "profanity_filter", false),
+		table.Entry("smart_formatting", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetSmartFormatting(true) }, "start", "smart_formatting", true),
+		table.Entry("speaker_labels", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetSpeakerLabels(true) }, "start", "speaker_labels", true),
+		table.Entry("grammar_name", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetGrammarName("yesno") }, "start", "grammar_name", "yesno"),
+		table.Entry("redaction", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetRedaction(true) }, "start", "redaction", true),
+		table.Entry("processing_metrics", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetProcessingMetrics(true) }, "start", "processing_metrics", true),
+		table.Entry("processing_metrics_interval", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetProcessingMetricsInterval(0.25) }, "start", "processing_metrics_interval", 0.25),
+		table.Entry("audio_metrics", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetAudioMetrics(true) }, "start", "audio_metrics", true),
+		table.Entry("end_of_phrase_silence_time", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetEndOfPhraseSilenceTime(1.5) }, "start", "end_of_phrase_silence_time", 1.5),
+		table.Entry("split_transcript_at_phrase_end", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetSplitTranscriptAtPhraseEnd(true) }, "start", "split_transcript_at_phrase_end", true),
+		table.Entry("speech_detector_sensitivity", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetSpeechDetectorSensitivity(0.75) }, "start", "speech_detector_sensitivity", 0.75),
+		table.Entry("background_audio_suppression", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetBackgroundAudioSuppression(0.5) }, "start", "background_audio_suppression", 0.5),
+		table.Entry("character_insertion_bias", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetCharacterInsertionBias(-0.5) }, "start", "character_insertion_bias", -0.5),
+		table.Entry("low_latency", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) { o.SetLowLatency(true) }, "start", "low_latency", true),
+		table.Entry("custom header", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) {
+			o.SetHeaders(map[string]string{"x-custom-header": "x-custom-value"})
+		}, "header", "X-Custom-Header", "x-custom-value"),
+		table.Entry("content type header", func(o *speechtotextv1.RecognizeUsingWebsocketOptions) {}, "header", "Content-Type", "audio/l16;rate=16000"),
+	)
+
+	It(`Sends the SDK analytics header`, func() {
+		handshake := recognize(&core.NoAuthAuthenticator{}, func(o *speechtotextv1.RecognizeUsingWebsocketOptions) {})
+		Expect(handshake.header.Get("X-IBMCloud-SDK-Analytics")).To(ContainSubstring("operation_id=Recognize"))
+	})
+	It(`Sends the bearer token in the Authorization header`, func() {
+		handshake := recognize(&core.BearerTokenAuthenticator{BearerToken: "token"}, func(o *speechtotextv1.RecognizeUsingWebsocketOptions) {})
+		Expect(handshake.header.Get("Authorization")).To(Equal("Bearer token"))
+		Expect(handshake.query).ToNot(HaveKey("access_token"))
+	})
+	It(`Sends the bearer token in the access_token query parameter`, func() {
+		handshake := recognize(&core.BearerTokenAuthenticator{BearerToken: "token"}, func(o *speechtotextv1.RecognizeUsingWebsocketOptions) {
+			o.SetAccessTokenQuery(true)
+		})
+		Expect(handshake.query.Get("access_token")).To(Equal("token"))
+		Expect(handshake.header.Get("Authorization")).To(BeEmpty())
+	})
+	It(`Rejects access_token query authentication without a bearer token`, func() {
+		speechToTextService, serviceErr := speechtotextv1.NewSpeechToTextV1(&speechtotextv1.SpeechToTextV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.BasicAuthenticator{Username: "user", Password: "pass"},
+		})
+		Expect(serviceErr).To(BeNil())
+
+		recognizeOptions := speechToTextService.NewRecognizeUsingWebsocketOptions(CreateMockReader("This is a mock file."), "audio/l16;rate=16000")
+		recognizeOptions.SetAccessTokenQuery(true)
+		_, errs := speechToTextService.RecognizeUsingWebsocketWithContext(context.Background(), recognizeOptions)
+		err := <-errs
+		Expect(err).To(BeAssignableToTypeOf(&speechtotextv1.AuthenticationError{}))
+	})
+})
@@ -0,0 +1,203 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sttest provides a local websocket server that speaks the Speech to Text recognize protocol, so that code
+// built on SpeechToTextV1.RecognizeUsingWebsocket and its variants can be tested without the service.
+//
+// A Server runs a Handler for every connection. The Handler scripts the service side of the conversation with the
+// methods of Conn:
+//
+//	server := sttest.NewServer(func(conn *sttest.Conn) {
+//		conn.ReadStart()
+//		conn.SendListening()
+//		conn.ReadAudio()
+//		conn.SendResults(sttest.FinalResult(0, "hello world"))
+//		conn.SendListening()
+//	})
+//	defer server.Close()
+//
+//	speechToText, _ := speechtotextv1.NewSpeechToTextV1(&speechtotextv1.SpeechToTextV1Options{
+//		URL:           server.URL,
+//		Authenticator: &core.NoAuthAuthenticator{},
+//	})
+package sttest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/gorilla/websocket"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/wstest"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+)
+
+// RecognizePath is the path of the recognize endpoint served by a Server.
+const RecognizePath = "/v1/recognize"
+
+// Handler scripts the service side of a single connection.
+type Handler func(conn *Conn)
+
+// Server : A local recognize endpoint. Use its URL as the service URL of a SpeechToTextV1 instance. Connections returns
+// the number of connections the server has accepted.
+type Server struct {
+	*wstest.Server
+}
+
+// NewServer : Starts a server that runs handler for every connection. The connection is closed normally when the
+// handler returns, unless the handler closed or aborted it already.
+func NewServer(handler Handler) *Server {
+	return &Server{wstest.NewServer(RecognizePath, func(conn *wstest.Conn) {
+		handler(&Conn{conn})
+	})}
+}
+
+// NewTranscribingServer : Starts a server that answers every utterance with the given results, followed by the
+// `listening` state, for as long as the client keeps the connection open.
+func NewTranscribingServer(results ...*speechtotextv1.SpeechRecognitionResults) *Server {
+	return NewServer(func(conn *Conn) {
+		for {
+			if _, err := conn.ReadStart(); err != nil {
+				return
+			}
+			if conn.SendListening() != nil {
+				return
+			}
+			if _, err := conn.ReadAudio(); err != nil {
+				return
+			}
+			for _, result := range results {
+				if conn.SendResults(result) != nil {
+					return
+				}
+			}
+			if conn.SendListening() != nil {
+				return
+			}
+		}
+	})
+}
+
+// Conn : The service side of a recognize connection. The connection is upgraded to a websocket on first use, unless
+// the handler calls Reject first. Request is the handshake request sent by the client, including its query parameters
+// and headers.
+type Conn struct {
+	*wstest.Conn
+}
+
+// ReadStart : Reads messages until the client sends a start message and returns its parameters. Audio received before
+// it is discarded.
+func (conn *Conn) ReadStart() (map[string]interface{}, error) {
+	return conn.readAction("start")
+}
+
+// ReadAudio : Reads audio until the client sends a stop message and returns it
+func (conn *Conn) ReadAudio() ([]byte, error) {
+	var audio []byte
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return audio, err
+		}
+		if messageType == websocket.BinaryMessage {
+			audio = append(audio, data...)
+			continue
+		}
+		var message map[string]interface{}
+		if err = json.Unmarshal(data, &message); err != nil {
+			return audio, err
+		}
+		if message["action"] == "stop" {
+			return audio, nil
+		}
+	}
+}
+
+// ReadAudioBytes : Reads audio until at least n bytes have arrived and returns it. A stop message before that is
+// reported as an error.
+func (conn *Conn) ReadAudioBytes(n int) ([]byte, error) {
+	var audio []byte
+	for len(audio) < n {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return audio, err
+		}
+		if messageType == websocket.BinaryMessage {
+			audio = append(audio, data...)
+			continue
+		}
+		return audio, fmt.Errorf("sttest: unexpected text message %s", data)
+	}
+	return audio, nil
+}
+
+func (conn *Conn) readAction(action string) (map[string]interface{}, error) {
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return nil, err
+		}
+		if messageType != websocket.TextMessage {
+			continue
+		}
+		var message map[string]interface{}
+		if err = json.Unmarshal(data, &message); err != nil {
+			return nil, err
+		}
+		if message["action"] == action {
+			return message, nil
+		}
+	}
+}
+
+// SendListening : Sends the `listening` state that acknowledges a start or stop message
+func (conn *Conn) SendListening() error {
+	return conn.SendJSON(map[string]string{"state": "listening"})
+}
+
+// SendResults : Sends interim or final results
+func (conn *Conn) SendResults(results *speechtotextv1.SpeechRecognitionResults) error {
+	return conn.SendJSON(results)
+}
+
+// SendError : Sends an `error` message, which the service follows by closing the connection
+func (conn *Conn) SendError(message string) error {
+	return conn.SendJSON(map[string]string{"error": message})
+}
+
+// InterimResult : Builds interim results with a single transcript at the given result index
+func InterimResult(resultIndex int64, transcript string) *speechtotextv1.SpeechRecognitionResults {
+	return transcriptResult(resultIndex, transcript, false)
+}
+
+// FinalResult : Builds final results with a single transcript at the given result index
+func FinalResult(resultIndex int64, transcript string) *speechtotextv1.SpeechRecognitionResults {
+	return transcriptResult(resultIndex, transcript, true)
+}
+
+func transcriptResult(resultIndex int64, transcript string, final bool) *speechtotextv1.SpeechRecognitionResults {
+	return &speechtotextv1.SpeechRecognitionResults{
+		ResultIndex: core.Int64Ptr(resultIndex),
+		Results: []speechtotextv1.SpeechRecognitionResult{
+			{
+				Final: core.BoolPtr(final),
+				Alternatives: []speechtotextv1.SpeechRecognitionAlternative{
+					{Transcript: core.StringPtr(transcript)},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,253 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SubtitleOptions : Controls how SubtitleCues splits recognition results into cues. Zero values select the defaults.
+type SubtitleOptions struct {
+	// The maximum number of characters in a line of a cue. Defaults to 42.
+	MaxLineLength int
+
+	// The maximum number of lines in a cue. Defaults to 2.
+	MaxLines int
+
+	// The maximum time a cue stays on screen. Defaults to 7 seconds.
+	MaxCueDuration time.Duration
+
+	// Formats the prefix that introduces a speaker, for example `func(speaker int64) string { return
+	// fmt.Sprintf("Speaker %d: ", speaker) }`. When set, a new cue starts whenever the speaker changes. Words that no
+	// speaker label covers get no prefix.
+	SpeakerPrefix func(speaker int64) string
+
+	// Lets a cue continue across the end of an utterance. By default a cue ends with every result that has an
+	// `end_of_utterance` value.
+	IgnoreEndOfUtterance bool
+
+	// Keeps the `%HESITATION` markers that the service transcribes for pauses such as "uhm". They are dropped by
+	// default.
+	KeepHesitations bool
+}
+
+func (options *SubtitleOptions) withDefaults() SubtitleOptions {
+	withDefaults := SubtitleOptions{}
+	if options != nil {
+		withDefaults = *options
+	}
+	if withDefaults.MaxLineLength <= 0 {
+		withDefaults.MaxLineLength = 42
+	}
+	if withDefaults.MaxLines <= 0 {
+		withDefaults.MaxLines = 2
+	}
+	if withDefaults.MaxCueDuration <= 0 {
+		withDefaults.MaxCueDuration = 7 * time.Second
+	}
+	return withDefaults
+}
+
+// SubtitleCue : A piece of text shown on screen for a span of the audio
+type SubtitleCue struct {
+	// The time the cue appears, in seconds from the start of the audio.
+	StartTime float64
+
+	// The time the cue disappears, in seconds from the start of the audio.
+	EndTime float64
+
+	// The speaker of the cue, or SpeakerUnknown.
+	Speaker int64
+
+	// The lines of text, including the speaker prefix.
+	Lines []string
+}
+
+// hesitationMarker is the word the service transcribes for a hesitation.
+const hesitationMarker = "%HESITATION"
+
+// SubtitleCues : Splits the final results of a recognition into subtitle cues. Recognition must be requested with
+// `timestamps`, and with `speaker_labels` for speaker prefixes. Use MergeSpeechRecognitionResults to combine the
+// messages of a websocket recognition or the results of an asynchronous job first.
+func SubtitleCues(results *SpeechRecognitionResults, options *SubtitleOptions) ([]SubtitleCue, error) {
+	if results == nil {
+		return nil, nil
+	}
+	settings := options.withDefaults()
+	speakerLabels, err := results.GetSpeakerLabels()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(speakerLabels, func(i, j int) bool { return speakerLabels[i].From < speakerLabels[j].From })
+
+	var cues []SubtitleCue
+	var cue *SubtitleCue
+	var words []string
+	flush := func() {
+		if cue != nil && len(words) > 0 {
+			cue.Lines = wrapSubtitleLines(cuePrefix(settings, cue.Speaker), words, settings.MaxLineLength)
+			cues = append(cues, *cue)
+		}
+		cue, words = nil, nil
+	}
+
+	for i, result := range results.Results {
+		if !isFinalResult(result) || len(result.Alternatives) == 0 {
+			continue
+		}
+		timestamps, err := result.Alternatives[0].GetWordTimestamps()
+		if err != nil {
+			return nil, fmt.Errorf("results[%d]: %s", i, err)
+		}
+		if timestamps == nil && strings.TrimSpace(resultTranscript(result)) != "" {
+			return nil, fmt.Errorf("results[%d] has no word timestamps; recognize with timestamps enabled", i)
+		}
+
+		for _, timestamp := range timestamps {
+			if timestamp.Word == hesitationMarker && !settings.KeepHesitations {
+				continue
+			}
+			speaker := SpeakerUnknown
+			if speakerLabel, found := speakerLabelFor(speakerLabels, timestamp); found {
+				speaker = speakerLabel.Speaker
+			}
+
+			if cue != nil {
+				tooLong := time.Duration((timestamp.EndTime-cue.StartTime)*float64(time.Second)) > settings.MaxCueDuration
+				tooWide := len(wrapSubtitleLines(cuePrefix(settings, cue.Speaker), append(words, timestamp.Word),
+					settings.MaxLineLength)) > settings.MaxLines
+				speakerChanged := settings.SpeakerPrefix != nil && speaker != cue.Speaker
+				if tooLong || tooWide || speakerChanged {
+					flush()
+				}
+			}
+			if cue == nil {
+				cue = &SubtitleCue{StartTime: timestamp.StartTime, Speaker: speaker}
+			}
+			cue.EndTime = timestamp.EndTime
+			words = append(words, timestamp.Word)
+		}
+
+		if result.EndOfUtterance != nil && !settings.IgnoreEndOfUtterance {
+			flush()
+		}
+	}
+	flush()
+	return cues, nil
+}
+
+func cuePrefix(settings SubtitleOptions, speaker int64) string {
+	if settings.SpeakerPrefix == nil || speaker == SpeakerUnknown {
+		return ""
+	}
+	return settings.SpeakerPrefix(speaker)
+}
+
+// wrapSubtitleLines : Fills lines greedily with words. A word longer than a line gets a line of its own.
+func wrapSubtitleLines(prefix string, words []string, maxLineLength int) []string {
+	var lines []string
+	line := prefix
+	for _, word := range words {
+		switch {
+		case line == "" || line == prefix && strings.HasSuffix(prefix, " "):
+			line += word
+		case len(line)+1+len(word) <= maxLineLength:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	return append(lines, line)
+}
+
+// WriteSRT : Writes cues in the SubRip (.srt) format
+func WriteSRT(writer io.Writer, cues []SubtitleCue) error {
+	buffered := bufio.NewWriter(writer)
+	for i, cue := range cues {
+		fmt.Fprintf(buffered, "%d\n%s --> %s\n", i+1, subtitleTimestamp(cue.StartTime, ","),
+			subtitleTimestamp(cue.EndTime, ","))
+		for _, line := range cue.Lines {
+			fmt.Fprintf(buffered, "%s\n", line)
+		}
+		fmt.Fprint(buffered, "\n")
+	}
+	return buffered.Flush()
+}
+
+// WriteWebVTT : Writes cues in the WebVTT (.vtt) format
+func WriteWebVTT(writer io.Writer, cues []SubtitleCue) error {
+	buffered := bufio.NewWriter(writer)
+	fmt.Fprint(buffered, "WEBVTT\n\n")
+	for _, cue := range cues {
+		fmt.Fprintf(buffered, "%s --> %s\n", subtitleTimestamp(cue.StartTime, "."), subtitleTimestamp(cue.EndTime, "."))
+		for _, line := range cue.Lines {
+			// Text that could be read as markup or as a cue timing line is escaped
+			line = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(line)
+			fmt.Fprintf(buffered, "%s\n", line)
+		}
+		fmt.Fprint(buffered, "\n")
+	}
+	return buffered.Flush()
+}
+
+// WriteTTML : Writes cues as a Timed Text Markup Language (.ttml) document. The language is written as the `xml:lang`
+// attribute of the document when it is not empty.
+func WriteTTML(writer io.Writer, cues []SubtitleCue, language string) error {
+	buffered := bufio.NewWriter(writer)
+	fmt.Fprint(buffered, xml.Header)
+	fmt.Fprint(buffered, `<tt xmlns="http://www.w3.org/ns/ttml"`)
+	if language != "" {
+		fmt.Fprint(buffered, ` xml:lang="`)
+		if err := xml.EscapeText(buffered, []byte(language)); err != nil {
+			return err
+		}
+		fmt.Fprint(buffered, `"`)
+	}
+	fmt.Fprint(buffered, ">\n  <body>\n    <div>\n")
+	for _, cue := range cues {
+		fmt.Fprintf(buffered, `      <p begin="%s" end="%s">`, subtitleTimestamp(cue.StartTime, "."),
+			subtitleTimestamp(cue.EndTime, "."))
+		for i, line := range cue.Lines {
+			if i > 0 {
+				fmt.Fprint(buffered, "<br/>")
+			}
+			if err := xml.EscapeText(buffered, []byte(line)); err != nil {
+				return err
+			}
+		}
+		fmt.Fprint(buffered, "</p>\n")
+	}
+	fmt.Fprint(buffered, "    </div>\n  </body>\n</tt>\n")
+	return buffered.Flush()
+}
+
+// subtitleTimestamp : Formats seconds as hours:minutes:seconds with milliseconds after the separator
+func subtitleTimestamp(seconds float64, separator string) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	milliseconds := int64(seconds*1000 + 0.5)
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", milliseconds/3600000, milliseconds/60000%60, milliseconds/1000%60,
+		separator, milliseconds%1000)
+}
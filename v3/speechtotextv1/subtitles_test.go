@@ -0,0 +1,138 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1/sttest"
+)
+
+var _ = Describe(`Subtitles`, func() {
+	var results *speechtotextv1.SpeechRecognitionResults
+	BeforeEach(func() {
+		first := timedResult(0, true,
+			speechtotextv1.WordTimestamp{Word: "so", StartTime: 0.5, EndTime: 0.75},
+			speechtotextv1.WordTimestamp{Word: "%HESITATION", StartTime: 0.75, EndTime: 1},
+			speechtotextv1.WordTimestamp{Word: "welcome", StartTime: 1, EndTime: 1.5},
+			speechtotextv1.WordTimestamp{Word: "everyone", StartTime: 1.5, EndTime: 2},
+		)
+		first.Results[0].EndOfUtterance = core.StringPtr("silence")
+		second := timedResult(1, true,
+			speechtotextv1.WordTimestamp{Word: "thanks", StartTime: 2.5, EndTime: 3},
+			speechtotextv1.WordTimestamp{Word: "<Bob>", StartTime: 3, EndTime: 3.5},
+		)
+		results = speechtotextv1.MergeSpeechRecognitionResults(*first, *second)
+		results.SpeakerLabels = []speechtotextv1.SpeakerLabelsResult{
+			speakerLabelsResult(0.5, 0.75, 0, 0.5, true),
+			speakerLabelsResult(0.75, 1, 0, 0.5, true),
+			speakerLabelsResult(1, 1.5, 0, 0.5, true),
+			speakerLabelsResult(1.5, 2, 0, 0.5, true),
+			speakerLabelsResult(2.5, 3, 1, 0.5, true),
+			speakerLabelsResult(3, 3.5, 1, 0.5, true),
+		}
+	})
+
+	It(`Writes SRT with one cue per utterance`, func() {
+		cues, err := speechtotextv1.SubtitleCues(results, nil)
+		Expect(err).To(BeNil())
+		var srt bytes.Buffer
+		Expect(speechtotextv1.WriteSRT(&srt, cues)).To(Succeed())
+		Expect(srt.String()).To(Equal("1\n00:00:00,500 --> 00:00:02,000\nso welcome everyone\n\n" +
+			"2\n00:00:02,500 --> 00:00:03,500\nthanks <Bob>\n\n"))
+	})
+
+	It(`Writes WebVTT with speaker prefixes and escaped text`, func() {
+		cues, err := speechtotextv1.SubtitleCues(results, &speechtotextv1.SubtitleOptions{
+			SpeakerPrefix:        func(speaker int64) string { return fmt.Sprintf("Speaker %d: ", speaker) },
+			IgnoreEndOfUtterance: true,
+			KeepHesitations:      true,
+		})
+		Expect(err).To(BeNil())
+		var vtt bytes.Buffer
+		Expect(speechtotextv1.WriteWebVTT(&vtt, cues)).To(Succeed())
+		Expect(vtt.String()).To(Equal("WEBVTT\n\n" +
+			"00:00:00.500 --> 00:00:02.000\nSpeaker 0: so %HESITATION welcome everyone\n\n" +
+			"00:00:02.500 --> 00:00:03.500\nSpeaker 1: thanks &lt;Bob&gt;\n\n"))
+	})
+
+	It(`Writes TTML with wrapped lines`, func() {
+		cues, err := speechtotextv1.SubtitleCues(results, &speechtotextv1.SubtitleOptions{MaxLineLength: 10})
+		Expect(err).To(BeNil())
+		var ttml bytes.Buffer
+		Expect(speechtotextv1.WriteTTML(&ttml, cues, "en-US")).To(Succeed())
+		Expect(ttml.String()).To(Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<tt xmlns="http://www.w3.org/ns/ttml" xml:lang="en-US">
+  <body>
+    <div>
+      <p begin="00:00:00.500" end="00:00:02.000">so welcome<br/>everyone</p>
+      <p begin="00:00:02.500" end="00:00:03.500">thanks<br/>&lt;Bob&gt;</p>
+    </div>
+  </body>
+</tt>
+`))
+	})
+
+	It(`Limits the duration of cues`, func() {
+		cues, err := speechtotextv1.SubtitleCues(results, &speechtotextv1.SubtitleOptions{
+			MaxCueDuration:       time.Second,
+			IgnoreEndOfUtterance: true,
+		})
+		Expect(err).To(BeNil())
+		Expect(cues).To(HaveLen(3))
+		Expect(cues[0].Lines).To(Equal([]string{"so welcome"}))
+		Expect(cues[1].Lines).To(Equal([]string{"everyone"}))
+		Expect(cues[2].Lines).To(Equal([]string{"thanks <Bob>"}))
+	})
+
+	It(`Starts a new cue when the lines are full`, func() {
+		cues, err := speechtotextv1.SubtitleCues(results, &speechtotextv1.SubtitleOptions{MaxLineLength: 10, MaxLines: 1})
+		Expect(err).To(BeNil())
+		Expect(cues).To(HaveLen(4))
+		Expect(cues[0]).To(Equal(speechtotextv1.SubtitleCue{StartTime: 0.5, EndTime: 1.5, Speaker: 0, Lines: []string{"so welcome"}}))
+		Expect(cues[1].Lines).To(Equal([]string{"everyone"}))
+	})
+
+	It(`Merges the messages of a websocket recognition`, func() {
+		interim := timedResult(0, false, speechtotextv1.WordTimestamp{Word: "so", StartTime: 0.5, EndTime: 0.75})
+		final := timedResult(0, true,
+			speechtotextv1.WordTimestamp{Word: "so", StartTime: 0.5, EndTime: 0.75},
+			speechtotextv1.WordTimestamp{Word: "yes", StartTime: 0.75, EndTime: 1},
+		)
+		labels := speechtotextv1.SpeechRecognitionResults{SpeakerLabels: []speechtotextv1.SpeakerLabelsResult{
+			speakerLabelsResult(0.5, 0.75, 0, 0.5, true),
+		}}
+		merged := speechtotextv1.MergeSpeechRecognitionResults(*interim, *final, labels)
+		Expect(merged.Results).To(HaveLen(1))
+		Expect(merged.SpeakerLabels).To(HaveLen(1))
+
+		cues, err := speechtotextv1.SubtitleCues(merged, nil)
+		Expect(err).To(BeNil())
+		Expect(cues).To(Equal([]speechtotextv1.SubtitleCue{{StartTime: 0.5, EndTime: 1, Speaker: 0, Lines: []string{"so yes"}}}))
+	})
+
+	It(`Requires word timestamps`, func() {
+		_, err := speechtotextv1.SubtitleCues(sttest.FinalResult(0, "no timestamps"), nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
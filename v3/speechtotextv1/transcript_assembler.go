@@ -0,0 +1,315 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// TranscriptAssembler : Merges a stream of recognition results into a running transcript. The service numbers every
+// result with a result index; interim results for an index are replaced by later results for the same index until a
+// final result arrives. The assembler tracks those revisions so that callers can render live captions without
+// handling the indices themselves.
+//
+// Results from Recognize, from the jobs of the asynchronous interface and from the websocket interface can be added.
+// An assembler is safe for concurrent use.
+type TranscriptAssembler struct {
+	lock    sync.Mutex
+	entries []*transcriptEntry
+}
+
+// TranscriptRevision : One version of the result at a result index
+type TranscriptRevision struct {
+	// The result index, counted from the start of the recognition.
+	ResultIndex int64
+
+	// The revision number, starting at 0 for the first result received for the index.
+	Revision int
+
+	// The transcript of the most likely alternative.
+	Transcript string
+
+	// Whether the result is final.
+	Final bool
+}
+
+// TranscriptChange : Describes how adding results changed the transcript
+type TranscriptChange struct {
+	// The new revision of the result. When Removed is set it is the last revision of a result that the service
+	// retracted.
+	TranscriptRevision
+
+	// Whether the interim result at the index was retracted.
+	Removed bool
+
+	// The stable text after the change, made of the final results up to the first result that is not final.
+	Stable string
+
+	// The volatile text after the change, made of the results after the stable text, which may still be revised.
+	Volatile string
+}
+
+// maxResultIndexGap : How far past the last known result index results may start. The service numbers results
+// consecutively, so results further ahead are not results of the same recognition.
+const maxResultIndexGap = 100
+
+type transcriptEntry struct {
+	result    SpeechRecognitionResult
+	revisions []TranscriptRevision
+}
+
+// NewTranscriptAssembler : Instantiate an empty TranscriptAssembler
+func NewTranscriptAssembler() *TranscriptAssembler {
+	return &TranscriptAssembler{}
+}
+
+// Add : Merges results into the transcript and returns the changes they caused, in result index order. Results that
+// do not change the transcript of a result index, such as a repeated interim result, produce no change. Results with a
+// negative result index, or one more than 100 past the last known index, are dropped.
+func (assembler *TranscriptAssembler) Add(results *SpeechRecognitionResults) []TranscriptChange {
+	if results == nil || len(results.Results) == 0 {
+		return nil
+	}
+	assembler.lock.Lock()
+	defer assembler.lock.Unlock()
+
+	start := 0
+	if results.ResultIndex != nil {
+		if *results.ResultIndex < 0 || *results.ResultIndex > int64(len(assembler.entries)+maxResultIndexGap) {
+			return nil
+		}
+		start = int(*results.ResultIndex)
+	}
+	end := start + len(results.Results)
+	for len(assembler.entries) < end {
+		assembler.entries = append(assembler.entries, nil)
+	}
+
+	var changes []TranscriptChange
+	for i, result := range results.Results {
+		if revision, changed := assembler.update(start+i, result); changed {
+			changes = append(changes, TranscriptChange{TranscriptRevision: revision})
+		}
+	}
+
+	// The service numbers results consecutively, so interim results past the last index it sent have been retracted
+	// in favour of the results it just sent.
+	retracted := len(assembler.entries)
+	for retracted > end && (assembler.entries[retracted-1] == nil || !isFinalResult(assembler.entries[retracted-1].result)) {
+		retracted--
+	}
+	for _, entry := range assembler.entries[retracted:] {
+		if entry != nil {
+			changes = append(changes, TranscriptChange{
+				TranscriptRevision: entry.revisions[len(entry.revisions)-1],
+				Removed:            true,
+			})
+		}
+	}
+	assembler.entries = assembler.entries[:retracted]
+
+	stable, volatile := assembler.text()
+	for i := range changes {
+		changes[i].Stable = stable
+		changes[i].Volatile = volatile
+	}
+	return changes
+}
+
+// AddWebsocketResults : Merges a message of the websocket interface into the transcript. Messages that only report
+// the state of the connection or an error produce no change.
+func (assembler *TranscriptAssembler) AddWebsocketResults(results *WebsocketRecognitionResults) []TranscriptChange {
+	if results == nil {
+		return nil
+	}
+	return assembler.Add(&results.SpeechRecognitionResults)
+}
+
+// AddRecognitionJob : Merges the results of a completed asynchronous job into the transcript
+func (assembler *TranscriptAssembler) AddRecognitionJob(job *RecognitionJob) []TranscriptChange {
+	if job == nil {
+		return nil
+	}
+	var changes []TranscriptChange
+	for i := range job.Results {
+		changes = append(changes, assembler.Add(&job.Results[i])...)
+	}
+	return changes
+}
+
+// update : Stores a result at an index and records a revision when its transcript or finality changed
+func (assembler *TranscriptAssembler) update(index int, result SpeechRecognitionResult) (TranscriptRevision, bool) {
+	entry := assembler.entries[index]
+	if entry == nil {
+		entry = &transcriptEntry{}
+		assembler.entries[index] = entry
+	}
+	entry.result = result
+
+	revision := TranscriptRevision{
+		ResultIndex: int64(index),
+		Revision:    len(entry.revisions),
+		Transcript:  resultTranscript(result),
+		Final:       isFinalResult(result),
+	}
+	if n := len(entry.revisions); n > 0 {
+		last := entry.revisions[n-1]
+		if last.Transcript == revision.Transcript && last.Final == revision.Final {
+			return last, false
+		}
+	}
+	entry.revisions = append(entry.revisions, revision)
+	return revision, true
+}
+
+// text : Joins the transcripts into the stable and volatile text
+func (assembler *TranscriptAssembler) text() (stable string, volatile string) {
+	var stableParts, volatileParts []string
+	inStable := true
+	for _, entry := range assembler.entries {
+		if entry == nil || !isFinalResult(entry.result) {
+			inStable = false
+		}
+		if entry == nil {
+			continue
+		}
+		transcript := strings.TrimSpace(resultTranscript(entry.result))
+		if transcript == "" {
+			continue
+		}
+		if inStable {
+			stableParts = append(stableParts, transcript)
+		} else {
+			volatileParts = append(volatileParts, transcript)
+		}
+	}
+	return strings.Join(stableParts, " "), strings.Join(volatileParts, " ")
+}
+
+// Stable : Returns the text of the final results up to the first result that is not final
+func (assembler *TranscriptAssembler) Stable() string {
+	assembler.lock.Lock()
+	defer assembler.lock.Unlock()
+	stable, _ := assembler.text()
+	return stable
+}
+
+// Volatile : Returns the text of the results after the stable text, which may still be revised
+func (assembler *TranscriptAssembler) Volatile() string {
+	assembler.lock.Lock()
+	defer assembler.lock.Unlock()
+	_, volatile := assembler.text()
+	return volatile
+}
+
+// Text : Returns the stable and the volatile text joined together
+func (assembler *TranscriptAssembler) Text() string {
+	assembler.lock.Lock()
+	defer assembler.lock.Unlock()
+	stable, volatile := assembler.text()
+	if stable == "" || volatile == "" {
+		return stable + volatile
+	}
+	return stable + " " + volatile
+}
+
+// IsFinal : Reports whether results have been added and all of them are final
+func (assembler *TranscriptAssembler) IsFinal() bool {
+	assembler.lock.Lock()
+	defer assembler.lock.Unlock()
+	for _, entry := range assembler.entries {
+		if entry == nil || !isFinalResult(entry.result) {
+			return false
+		}
+	}
+	return len(assembler.entries) > 0
+}
+
+// Results : Returns the latest result for every result index, in index order. Indices for which no result has been
+// received are skipped.
+func (assembler *TranscriptAssembler) Results() []SpeechRecognitionResult {
+	assembler.lock.Lock()
+	defer assembler.lock.Unlock()
+	results := make([]SpeechRecognitionResult, 0, len(assembler.entries))
+	for _, entry := range assembler.entries {
+		if entry != nil {
+			results = append(results, entry.result)
+		}
+	}
+	return results
+}
+
+// Revisions : Returns every revision recorded for a result index, oldest first
+func (assembler *TranscriptAssembler) Revisions(resultIndex int64) []TranscriptRevision {
+	assembler.lock.Lock()
+	defer assembler.lock.Unlock()
+	if resultIndex < 0 || resultIndex >= int64(len(assembler.entries)) || assembler.entries[resultIndex] == nil {
+		return nil
+	}
+	revisions := assembler.entries[resultIndex].revisions
+	return append([]TranscriptRevision(nil), revisions...)
+}
+
+// MergeSpeechRecognitionResults : Merges the messages of a websocket recognition, or the results of an asynchronous
+// job, into a single SpeechRecognitionResults that holds the latest result for every result index and the latest
+// speaker label for every start time, as the response of Recognize would.
+func MergeSpeechRecognitionResults(results ...SpeechRecognitionResults) *SpeechRecognitionResults {
+	assembler := NewTranscriptAssembler()
+	labels := make(map[float32]SpeakerLabelsResult)
+	for i := range results {
+		assembler.Add(&results[i])
+		for _, speakerLabel := range results[i].SpeakerLabels {
+			if speakerLabel.From == nil {
+				continue
+			}
+			current, ok := labels[*speakerLabel.From]
+			if ok && current.Final != nil && *current.Final && (speakerLabel.Final == nil || !*speakerLabel.Final) {
+				continue
+			}
+			labels[*speakerLabel.From] = speakerLabel
+		}
+	}
+
+	merged := &SpeechRecognitionResults{Results: assembler.Results()}
+	if len(merged.Results) > 0 {
+		merged.ResultIndex = core.Int64Ptr(0)
+	}
+	for _, speakerLabel := range labels {
+		merged.SpeakerLabels = append(merged.SpeakerLabels, speakerLabel)
+	}
+	sort.Slice(merged.SpeakerLabels, func(i, j int) bool {
+		return *merged.SpeakerLabels[i].From < *merged.SpeakerLabels[j].From
+	})
+	return merged
+}
+
+// isFinalResult : Reports whether the service marked a result as final
+func isFinalResult(result SpeechRecognitionResult) bool {
+	return result.Final != nil && *result.Final
+}
+
+// resultTranscript : Returns the transcript of the most likely alternative of a result
+func resultTranscript(result SpeechRecognitionResult) string {
+	if len(result.Alternatives) == 0 {
+		return ""
+	}
+	return core.StringNilMapper(result.Alternatives[0].Transcript)
+}
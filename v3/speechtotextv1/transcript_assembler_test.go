@@ -0,0 +1,160 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1/sttest"
+)
+
+func resultsAt(resultIndex int64, results ...*speechtotextv1.SpeechRecognitionResults) *speechtotextv1.SpeechRecognitionResults {
+	merged := &speechtotextv1.SpeechRecognitionResults{ResultIndex: core.Int64Ptr(resultIndex)}
+	for _, result := range results {
+		merged.Results = append(merged.Results, result.Results...)
+	}
+	return merged
+}
+
+var _ = Describe(`TranscriptAssembler`, func() {
+	It(`Replaces interim results until they are final`, func() {
+		assembler := speechtotextv1.NewTranscriptAssembler()
+
+		changes := assembler.Add(sttest.InterimResult(0, "hel"))
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Revision).To(Equal(0))
+		Expect(changes[0].Stable).To(Equal(""))
+		Expect(changes[0].Volatile).To(Equal("hel"))
+
+		Expect(assembler.Add(sttest.InterimResult(0, "hel"))).To(BeEmpty())
+
+		changes = assembler.Add(sttest.FinalResult(0, "hello "))
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Revision).To(Equal(1))
+		Expect(changes[0].Final).To(BeTrue())
+		Expect(changes[0].Stable).To(Equal("hello"))
+		Expect(changes[0].Volatile).To(Equal(""))
+
+		changes = assembler.Add(sttest.InterimResult(1, "wor"))
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].ResultIndex).To(Equal(int64(1)))
+		Expect(assembler.Stable()).To(Equal("hello"))
+		Expect(assembler.Volatile()).To(Equal("wor"))
+		Expect(assembler.Text()).To(Equal("hello wor"))
+		Expect(assembler.IsFinal()).To(BeFalse())
+
+		assembler.Add(sttest.FinalResult(1, "world "))
+		Expect(assembler.Text()).To(Equal("hello world"))
+		Expect(assembler.IsFinal()).To(BeTrue())
+		Expect(assembler.Results()).To(HaveLen(2))
+		Expect(assembler.Revisions(0)).To(Equal([]speechtotextv1.TranscriptRevision{
+			{ResultIndex: 0, Revision: 0, Transcript: "hel"},
+			{ResultIndex: 0, Revision: 1, Transcript: "hello ", Final: true},
+		}))
+		Expect(assembler.Revisions(5)).To(BeNil())
+	})
+
+	It(`Retracts interim results the service no longer reports`, func() {
+		assembler := speechtotextv1.NewTranscriptAssembler()
+		assembler.Add(resultsAt(0, sttest.InterimResult(0, "the cat"), sttest.InterimResult(1, "sat")))
+		Expect(assembler.Volatile()).To(Equal("the cat sat"))
+
+		changes := assembler.Add(sttest.FinalResult(0, "the cat sat "))
+		Expect(changes).To(HaveLen(2))
+		Expect(changes[0].Final).To(BeTrue())
+		Expect(changes[1].Removed).To(BeTrue())
+		Expect(changes[1].ResultIndex).To(Equal(int64(1)))
+		Expect(changes[1].Stable).To(Equal("the cat sat"))
+		Expect(assembler.Results()).To(HaveLen(1))
+	})
+
+	It(`Keeps results after a missing index volatile`, func() {
+		assembler := speechtotextv1.NewTranscriptAssembler()
+		assembler.Add(sttest.FinalResult(0, "one"))
+		assembler.Add(sttest.FinalResult(2, "three"))
+		Expect(assembler.Stable()).To(Equal("one"))
+		Expect(assembler.Volatile()).To(Equal("three"))
+		Expect(assembler.IsFinal()).To(BeFalse())
+	})
+
+	It(`Drops results with an invalid result index`, func() {
+		assembler := speechtotextv1.NewTranscriptAssembler()
+		assembler.Add(sttest.FinalResult(0, "one"))
+
+		Expect(assembler.Add(sttest.FinalResult(-1, "minus one"))).To(BeEmpty())
+		Expect(assembler.Add(sttest.FinalResult(1<<40, "far ahead"))).To(BeEmpty())
+		Expect(assembler.Add(sttest.FinalResult(102, "past the gap"))).To(BeEmpty())
+		Expect(assembler.Results()).To(HaveLen(1))
+		Expect(assembler.Stable()).To(Equal("one"))
+		Expect(assembler.IsFinal()).To(BeTrue())
+
+		Expect(assembler.Add(sttest.FinalResult(101, "within the gap"))).To(HaveLen(1))
+		Expect(assembler.Volatile()).To(Equal("within the gap"))
+	})
+
+	It(`Merges the results of an asynchronous job`, func() {
+		assembler := speechtotextv1.NewTranscriptAssembler()
+		job := &speechtotextv1.RecognitionJob{
+			Results: []speechtotextv1.SpeechRecognitionResults{
+				*resultsAt(0, sttest.FinalResult(0, "first "), sttest.FinalResult(1, "second ")),
+			},
+		}
+		Expect(assembler.AddRecognitionJob(job)).To(HaveLen(2))
+		Expect(assembler.Text()).To(Equal("first second"))
+	})
+
+	It(`Assembles the websocket result stream`, func() {
+		server := sttest.NewServer(func(conn *sttest.Conn) {
+			defer GinkgoRecover()
+			_, err := conn.ReadStart()
+			Expect(err).To(BeNil())
+			Expect(conn.SendListening()).To(Succeed())
+			_, err = conn.ReadAudio()
+			Expect(err).To(BeNil())
+			Expect(conn.SendResults(sttest.InterimResult(0, "good"))).To(Succeed())
+			Expect(conn.SendResults(sttest.FinalResult(0, "good morning "))).To(Succeed())
+			Expect(conn.SendResults(sttest.FinalResult(1, "everyone "))).To(Succeed())
+			Expect(conn.SendListening()).To(Succeed())
+		})
+		defer server.Close()
+
+		speechToText, err := speechtotextv1.NewSpeechToTextV1(&speechtotextv1.SpeechToTextV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		options := speechToText.NewRecognizeUsingWebsocketOptions(CreateMockReader("This is a mock file."), "audio/l16;rate=16000")
+		options.SetInterimResults(true)
+		options.SetPacing(speechtotextv1.RecognizeUsingWebsocketOptionsPacingNoneConst)
+
+		assembler := speechtotextv1.NewTranscriptAssembler()
+		var volatile []string
+		results, errs := speechToText.RecognizeUsingWebsocketWithContext(context.Background(), options)
+		for result := range results {
+			for _, change := range assembler.AddWebsocketResults(result) {
+				volatile = append(volatile, change.Volatile)
+			}
+		}
+		Expect(<-errs).To(BeNil())
+		Expect(volatile).To(Equal([]string{"good", "", ""}))
+		Expect(assembler.Text()).To(Equal("good morning everyone"))
+	})
+})
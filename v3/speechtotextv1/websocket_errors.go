@@ -0,0 +1,66 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/websocketerrors"
+)
+
+// WebsocketDialError : The websocket handshake with the service failed. When the service answered the handshake, the
+// HTTP status code and body of its response are included.
+type WebsocketDialError = websocketerrors.DialError
+
+// WebsocketCloseError : The websocket connection was closed with a code other than a normal closure.
+type WebsocketCloseError = websocketerrors.CloseError
+
+// RecognitionError : The service sent an `error` message over the websocket connection.
+type RecognitionError struct {
+	// The error message sent by the service.
+	Message string
+}
+
+func (e *RecognitionError) Error() string {
+	return e.Message
+}
+
+// AuthenticationError : The configured authenticator could not authenticate the websocket handshake.
+type AuthenticationError struct {
+	// The underlying authenticator error.
+	Err error
+}
+
+func (e *AuthenticationError) Error() string {
+	return fmt.Sprintf("websocket authentication failed: %s", e.Err)
+}
+
+func (e *AuthenticationError) Unwrap() error {
+	return e.Err
+}
+
+// dialRecognize : Opens a connection to the recognize endpoint
+func dialRecognize(dialURL string, param url.Values, headers http.Header) (*websocket.Conn, error) {
+	conn, response, err := websocket.DefaultDialer.Dial(fmt.Sprintf("%s%s?%s", dialURL, RECOGNIZE_ENDPOINT, param.Encode()), headers)
+	if err != nil {
+		return nil, websocketerrors.NewDialError(response, err)
+	}
+	return conn, nil
+}
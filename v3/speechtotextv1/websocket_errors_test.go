@@ -0,0 +1,139 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package speechtotextv1_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/speechtotextv1/sttest"
+)
+
+var _ = Describe(`SpeechToTextV1 websocket errors`, func() {
+	var testServer *sttest.Server
+	var speechToTextService *speechtotextv1.SpeechToTextV1
+
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`Returns the handshake status and body of a rejected connection`, func() {
+		testServer = sttest.NewServer(func(conn *sttest.Conn) {
+			conn.Reject(http.StatusUnauthorized, `{"code":401,"error":"Unauthorized"}`)
+		})
+		speechToTextService = newWebsocketService(testServer)
+
+		recognizeOptions := speechToTextService.NewRecognizeUsingWebsocketOptions(CreateMockReader("This is a mock file."), "audio/l16;rate=16000")
+		callback := new(recordingCallback)
+		err := speechToTextService.RecognizeUsingWebsocketWithError(recognizeOptions, callback)
+		Expect(err).To(BeAssignableToTypeOf(&speechtotextv1.WebsocketDialError{}))
+		Expect(err.(*speechtotextv1.WebsocketDialError).StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(err.(*speechtotextv1.WebsocketDialError).Body).To(ContainSubstring("Unauthorized"))
+		Expect(callback.errors).To(BeEmpty())
+	})
+	It(`Delivers a rejected connection to OnError when no error is returned`, func() {
+		testServer = sttest.NewServer(func(conn *sttest.Conn) {
+			conn.Reject(http.StatusUnauthorized, `{"code":401,"error":"Unauthorized"}`)
+		})
+		speechToTextService = newWebsocketService(testServer)
+
+		recognizeOptions := speechToTextService.NewRecognizeUsingWebsocketOptions(CreateMockReader("This is a mock file."), "audio/l16;rate=16000")
+		callback := new(recordingCallback)
+		speechToTextService.RecognizeUsingWebsocket(recognizeOptions, callback)
+		Expect(callback.errors).To(HaveLen(1))
+		Expect(callback.errors[0]).To(BeAssignableToTypeOf(&speechtotextv1.WebsocketDialError{}))
+	})
+	It(`Returns validation errors instead of panicking`, func() {
+		testServer = sttest.NewTranscribingServer()
+		speechToTextService = newWebsocketService(testServer)
+
+		err := speechToTextService.RecognizeUsingWebsocketWithError(nil, new(recordingCallback))
+		Expect(err).ToNot(BeNil())
+		callback := new(recordingCallback)
+		Expect(func() { speechToTextService.RecognizeUsingWebsocket(nil, callback) }).ToNot(Panic())
+		Expect(callback.errors).To(HaveLen(1))
+		Expect(func() { speechToTextService.RecognizeUsingWebsocket(nil, nil) }).ToNot(Panic())
+		Expect(testServer.Connections()).To(Equal(0))
+	})
+	It(`Reports service error messages and abnormal closes`, func() {
+		testServer = sttest.NewServer(func(conn *sttest.Conn) {
+			_, _ = conn.ReadStart()
+			_ = conn.SendListening()
+			_ = conn.SendError("unable to transcode data stream")
+			_ = conn.CloseWith(websocket.CloseInternalServerErr, "transcode failure")
+		})
+		speechToTextService = newWebsocketService(testServer)
+
+		callback := new(recordingCallback)
+		recognizeOptions := speechToTextService.NewRecognizeUsingWebsocketOptions(nil, "audio/l16;rate=16000")
+		session, err := speechToTextService.OpenRecognizeSession(recognizeOptions, callback)
+		Expect(err).To(BeNil())
+		<-session.Done()
+
+		Expect(callback.errors).To(HaveLen(2))
+		Expect(callback.errors[0]).To(Equal(&speechtotextv1.RecognitionError{Message: "unable to transcode data stream"}))
+		Expect(callback.errors[1]).To(Equal(&speechtotextv1.WebsocketCloseError{Code: websocket.CloseInternalServerErr, Text: "transcode failure"}))
+	})
+	It(`Reports a dropped connection on the error channel`, func() {
+		testServer = sttest.NewServer(func(conn *sttest.Conn) {
+			_, _ = conn.ReadStart()
+			_ = conn.SendListening()
+			_ = conn.Abort()
+		})
+		speechToTextService = newWebsocketService(testServer)
+
+		recognizeOptions := speechToTextService.NewRecognizeUsingWebsocketOptions(CreateMockReader("This is a mock file."), "audio/l16;rate=16000")
+		results, errs := speechToTextService.RecognizeUsingWebsocketWithContext(context.Background(), recognizeOptions)
+		Eventually(results).Should(BeClosed())
+		Expect(<-errs).To(BeAssignableToTypeOf(&speechtotextv1.WebsocketCloseError{}))
+	})
+	It(`Returns when the connection ends while the audio reader is blocked`, func() {
+		testServer = sttest.NewServer(func(conn *sttest.Conn) {
+			defer GinkgoRecover()
+			_, err := conn.ReadStart()
+			Expect(err).To(BeNil())
+			Expect(conn.SendListening()).To(Succeed())
+			_, err = conn.ReadAudioBytes(1)
+			Expect(err).To(BeNil())
+			Expect(conn.SendResults(sttest.FinalResult(0, "hello"))).To(Succeed())
+			Expect(conn.SendListening()).To(Succeed())
+		})
+		speechToTextService = newWebsocketService(testServer)
+
+		audio, writer := io.Pipe()
+		defer writer.Close()
+		go func() {
+			_, _ = writer.Write(make([]byte, 8192))
+		}()
+		recognizeOptions := speechToTextService.NewRecognizeUsingWebsocketOptions(audio, "audio/l16;rate=16000")
+		recognizeOptions.SetPacing(speechtotextv1.RecognizeUsingWebsocketOptionsPacingNoneConst)
+		callback := new(recordingCallback)
+		returned := make(chan error, 1)
+		go func() {
+			returned <- speechToTextService.RecognizeUsingWebsocketWithError(recognizeOptions, callback)
+		}()
+
+		Eventually(returned).Should(Receive(BeNil()))
+		Expect(callback.data).To(HaveLen(1))
+		Expect(callback.closed).To(Equal(1))
+	})
+})
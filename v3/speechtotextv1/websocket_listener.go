@@ -1,21 +1,23 @@
 package speechtotextv1
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"time"
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/gorilla/websocket"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/websocketerrors"
 )
 
 type RecognizeListener struct {
 	IsClosed chan bool
 	Callback RecognizeCallbackWrapper
+
+	// done is closed once the results reader has closed the connection
+	done chan struct{}
 }
 
 /*
@@ -43,7 +45,9 @@ func (wsHandle RecognizeListener) OnData(conn *websocket.Conn, recognizeOptions
 		var websocketResponse WebsocketRecognitionResults
 		_, result, err := conn.ReadMessage()
 		if err != nil {
-			wsHandle.OnError(err)
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				wsHandle.OnError(websocketerrors.NewReadError(err))
+			}
 			break
 		}
 		err = json.Unmarshal(result, &websocketResponse)
@@ -62,7 +66,7 @@ func (wsHandle RecognizeListener) OnData(conn *websocket.Conn, recognizeOptions
 		}
 
 		if len(websocketResponse.Error) > 0 {
-			wsHandle.OnError(errors.New(websocketResponse.Error))
+			wsHandle.OnError(&RecognitionError{Message: websocketResponse.Error})
 			break
 		}
 
@@ -72,6 +76,9 @@ func (wsHandle RecognizeListener) OnData(conn *websocket.Conn, recognizeOptions
 		wsHandle.Callback.OnData(&detailResp)
 	}
 	conn.Close()
+	if wsHandle.done != nil {
+		close(wsHandle.done)
+	}
 	wsHandle.IsClosed <- true
 }
 
@@ -86,10 +93,10 @@ func (wsHandle RecognizeListener) OnError(err error) {
 	sendStartMessage : Sends start message to server
 */
 func sendStartMessage(conn *websocket.Conn, textParams *RecognizeUsingWebsocketOptions, recognizeListener *RecognizeListener) {
-	action := "start"
-	textParams.Action = &action
-	startMsgBytes, _ := json.Marshal(textParams)
-	err := conn.WriteMessage(websocket.TextMessage, startMsgBytes)
+	startMsgBytes, err := startMessage(textParams)
+	if err == nil {
+		err = conn.WriteMessage(websocket.TextMessage, startMsgBytes)
+	}
 	if err != nil {
 		recognizeListener.OnError(err)
 	}
@@ -99,30 +106,43 @@ func sendStartMessage(conn *websocket.Conn, textParams *RecognizeUsingWebsocketO
 	sendCloseMessage : Sends end message to server
 */
 func sendCloseMessage(conn *websocket.Conn) {
-	stop := "stop"
-	closeMsgBytes, _ := json.Marshal(RecognizeUsingWebsocketOptions{Action: &stop})
-	_ = conn.WriteMessage(websocket.TextMessage, closeMsgBytes)
+	_ = conn.WriteMessage(websocket.TextMessage, stopMessage)
 }
 
 /*
 	sendAudio : Sends audio data to the server
 */
 func sendAudio(conn *websocket.Conn, recognizeOptions *RecognizeUsingWebsocketOptions, recognizeListener *RecognizeListener) {
-	chunk := make([]byte, ONE_KB*2)
+	pacer, err := newAudioPacer(recognizeOptions)
+	if err != nil {
+		recognizeListener.OnError(err)
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		return
+	}
+
+	chunk := make([]byte, pacer.chunkSize)
 	for {
 		bytesRead, err := (recognizeOptions.Audio).Read(chunk)
-		if err != nil {
-			if err == io.EOF {
-				break
-			} else {
-				recognizeListener.OnError(err)
+		if bytesRead > 0 {
+			_ = pacer.wait(context.Background(), bytesRead)
+			if writeErr := conn.WriteMessage(websocket.BinaryMessage, chunk[:bytesRead]); writeErr != nil {
+				// A write after the reader closed the connection is not an error of its own
+				select {
+				case <-recognizeListener.done:
+				default:
+					recognizeListener.OnError(writeErr)
+				}
+				return
 			}
 		}
-		err = conn.WriteMessage(websocket.BinaryMessage, chunk[:bytesRead])
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
 			recognizeListener.OnError(err)
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
 		}
-		time.Sleep(TEN_MILLISECONDS)
 	}
 	sendCloseMessage(conn)
 }
@@ -131,13 +151,31 @@ func sendAudio(conn *websocket.Conn, recognizeOptions *RecognizeUsingWebsocketOp
 	NewRecognizeListener : Instantiates a listener instance to control the sending/receiving of audio/text
 */
 func (speechToText *SpeechToTextV1) NewRecognizeListener(callback RecognizeCallbackWrapper, recognizeWSOptions *RecognizeUsingWebsocketOptions, dialURL string, param url.Values, headers http.Header) {
-	recognizeListener := RecognizeListener{Callback: callback, IsClosed: make(chan bool, 1)}
-	conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("%s%s?%s", dialURL, RECOGNIZE_ENDPOINT, param.Encode()), headers)
+	recognizeListener := RecognizeListener{Callback: callback, IsClosed: make(chan bool, 1), done: make(chan struct{})}
+	conn, err := dialRecognize(dialURL, param, headers)
 	if err != nil {
 		recognizeListener.OnError(err)
+		return
+	}
+	recognizeListener.listen(recognizeWSOptions, conn)
+}
+
+/*
+	listen : Sends the audio over an open connection and delivers results until the connection is closed
+*/
+func (wsHandle RecognizeListener) listen(recognizeWSOptions *RecognizeUsingWebsocketOptions, conn *websocket.Conn) {
+	sent := make(chan bool)
+	wsHandle.OnOpen(recognizeWSOptions, conn)
+	go wsHandle.OnData(conn, recognizeWSOptions)
+	go func() {
+		sendAudio(conn, recognizeWSOptions, &wsHandle)
+		close(sent)
+	}()
+	wsHandle.OnClose()
+
+	// The audio reader may still be blocked once the connection has ended
+	select {
+	case <-sent:
+	case <-wsHandle.done:
 	}
-	recognizeListener.OnOpen(recognizeWSOptions, conn)
-	go recognizeListener.OnData(conn, recognizeWSOptions)
-	go sendAudio(conn, recognizeWSOptions, &recognizeListener)
-	recognizeListener.OnClose()
 }
@@ -0,0 +1,358 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package texttospeechv1
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/audioutil"
+)
+
+// audioFormat : A format of synthesized audio that can be joined
+type audioFormat interface {
+	// duration returns the length of the audio in seconds.
+	duration(audio []byte) (float64, error)
+
+	// join writes the audio of the segments as one stream.
+	join(writer io.Writer, segments [][]byte) (int64, error)
+}
+
+// newAudioFormat : Returns the format of audio of the given content type
+func newAudioFormat(contentType string) (audioFormat, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content type %q: %s", contentType, err)
+	}
+	switch mediaType {
+	case "audio/wav", "audio/wave", "audio/x-wav":
+		return wavFormat{}, nil
+	case "audio/ogg":
+		return oggFormat{}, nil
+	case "audio/mp3", "audio/mpeg":
+		return mp3Format{}, nil
+	case "audio/l16", "audio/mulaw", "audio/alaw", "audio/basic":
+		format, err := audioutil.ParseRawFormat(contentType)
+		if err != nil {
+			return nil, err
+		}
+		return rawFormat{format: format}, nil
+	}
+	return nil, fmt.Errorf("audio in the %s format cannot be joined; use WAV, Ogg, MP3 or raw audio", mediaType)
+}
+
+// rawFormat : Audio without a header: l16, mu-law and a-law
+type rawFormat struct {
+	format audioutil.RawFormat
+}
+
+func (format rawFormat) duration(audio []byte) (float64, error) {
+	return format.format.Duration(int64(len(audio))).Seconds(), nil
+}
+
+func (format rawFormat) join(writer io.Writer, segments [][]byte) (int64, error) {
+	var written int64
+	for _, segment := range segments {
+		n, err := writer.Write(segment)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// wavFormat : WAV audio. The service streams WAV audio with a header that does not give the size of the data, so the
+// data of a segment is taken to run to its end.
+type wavFormat struct{}
+
+func (wavFormat) duration(audio []byte) (float64, error) {
+	wav, err := audioutil.ParseWav(audio)
+	if err != nil {
+		return 0, err
+	}
+	return wav.Format.Duration(int64(len(wav.Data))).Seconds(), nil
+}
+
+func (wavFormat) join(writer io.Writer, segments [][]byte) (int64, error) {
+	if len(segments) == 0 {
+		return 0, nil
+	}
+	var format audioutil.RawFormat
+	data := make([][]byte, len(segments))
+	for i, segment := range segments {
+		wav, err := audioutil.ParseWav(segment)
+		if err != nil {
+			return 0, err
+		}
+		if i == 0 {
+			format = wav.Format
+		} else if wav.Format != format {
+			return 0, fmt.Errorf("the segments have different WAV formats")
+		}
+		data[i] = wav.Data
+	}
+	return audioutil.WriteWav(writer, format, data...)
+}
+
+// oggFormat : Ogg audio, with Opus or Vorbis. The streams of the segments are chained, each with its own serial
+// number.
+type oggFormat struct{}
+
+// oggPage : A page of an Ogg stream
+type oggPage struct {
+	data []byte
+}
+
+func (page oggPage) granule() int64 {
+	return int64(binary.LittleEndian.Uint64(page.data[6:14]))
+}
+
+func (page oggPage) serial() uint32 {
+	return binary.LittleEndian.Uint32(page.data[14:18])
+}
+
+// body : Returns the packet data of the page
+func (page oggPage) body() []byte {
+	segments := int(page.data[26])
+	return page.data[27+segments:]
+}
+
+// setSerial : Changes the serial number of the page and updates its checksum
+func (page oggPage) setSerial(serial uint32) {
+	binary.LittleEndian.PutUint32(page.data[14:18], serial)
+	binary.LittleEndian.PutUint32(page.data[22:26], 0)
+	binary.LittleEndian.PutUint32(page.data[22:26], oggCRC(page.data))
+}
+
+func parseOgg(audio []byte) ([]oggPage, error) {
+	var pages []oggPage
+	for position := 0; position < len(audio); {
+		if len(audio)-position < 27 || string(audio[position:position+4]) != "OggS" {
+			return nil, fmt.Errorf("invalid Ogg page at byte %d", position)
+		}
+		segments := int(audio[position+26])
+		if len(audio)-position < 27+segments {
+			return nil, fmt.Errorf("the Ogg page at byte %d is truncated", position)
+		}
+		size := 27 + segments
+		for _, lacing := range audio[position+27 : position+27+segments] {
+			size += int(lacing)
+		}
+		if len(audio)-position < size {
+			return nil, fmt.Errorf("the Ogg page at byte %d is truncated", position)
+		}
+		pages = append(pages, oggPage{data: audio[position : position+size]})
+		position += size
+	}
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("the Ogg audio has no pages")
+	}
+	return pages, nil
+}
+
+func (oggFormat) duration(audio []byte) (float64, error) {
+	pages, err := parseOgg(audio)
+	if err != nil {
+		return 0, err
+	}
+	first := pages[0].body()
+	var rate, preSkip int64
+	switch {
+	case len(first) >= 19 && string(first[0:8]) == "OpusHead":
+		rate, preSkip = 48000, int64(binary.LittleEndian.Uint16(first[10:12]))
+	case len(first) >= 16 && string(first[0:7]) == "\x01vorbis":
+		rate = int64(binary.LittleEndian.Uint32(first[12:16]))
+	}
+	if rate == 0 {
+		return 0, fmt.Errorf("the Ogg audio is neither Opus nor Vorbis")
+	}
+	samples := pages[len(pages)-1].granule() - preSkip
+	if samples < 0 {
+		samples = 0
+	}
+	return float64(samples) / float64(rate), nil
+}
+
+func (oggFormat) join(writer io.Writer, segments [][]byte) (int64, error) {
+	var total int64
+	var serial uint32
+	for i, segment := range segments {
+		// The pages are changed in a copy, so that the audio of the segment is left as it is
+		pages, err := parseOgg(append([]byte(nil), segment...))
+		if err != nil {
+			return total, err
+		}
+		if i == 0 {
+			serial = pages[0].serial()
+		}
+		for _, page := range pages {
+			page.setSerial(serial + uint32(i))
+			written, err := writer.Write(page.data)
+			total += int64(written)
+			if err != nil {
+				return total, err
+			}
+		}
+	}
+	return total, nil
+}
+
+var oggCRCTable = func() (table [256]uint32) {
+	for i := range table {
+		crc := uint32(i) << 24
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ 0x04c11db7
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return
+}()
+
+// oggCRC : Returns the checksum of an Ogg page whose checksum field is zero
+func oggCRC(data []byte) uint32 {
+	var crc uint32
+	for _, b := range data {
+		crc = crc<<8 ^ oggCRCTable[byte(crc>>24)^b]
+	}
+	return crc
+}
+
+// mp3Format : MPEG layer III audio. The ID3 tags of the segments and their Xing or Info frames, whose frame counts
+// would be wrong for the joined audio, are left out.
+type mp3Format struct{}
+
+// mp3Frame : A frame of MPEG layer III audio
+type mp3Frame struct {
+	data       []byte
+	samples    int
+	sampleRate int
+}
+
+var (
+	mp3Bitrates = [2][16]int{
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+	}
+	mp3SampleRates = [3]int{44100, 48000, 32000}
+)
+
+func parseMp3(audio []byte) ([]mp3Frame, error) {
+	position := 0
+	if len(audio) >= 10 && string(audio[0:3]) == "ID3" {
+		size := int(audio[6]&0x7f)<<21 | int(audio[7]&0x7f)<<14 | int(audio[8]&0x7f)<<7 | int(audio[9]&0x7f)
+		position = 10 + size
+		if audio[5]&0x10 != 0 {
+			position += 10
+		}
+	}
+	end := len(audio)
+	if end-position >= 128 && string(audio[end-128:end-125]) == "TAG" {
+		end -= 128
+	}
+
+	var frames []mp3Frame
+	for position < end {
+		if end-position < 4 || audio[position] != 0xff || audio[position+1]&0xe0 != 0xe0 {
+			return nil, fmt.Errorf("invalid MP3 frame at byte %d", position)
+		}
+		header := audio[position : position+4]
+		version := header[1] >> 3 & 3
+		layer := header[1] >> 1 & 3
+		bitrateIndex := header[2] >> 4
+		rateIndex := header[2] >> 2 & 3
+		if version == 1 || layer != 1 || rateIndex == 3 || mp3Bitrates[0][bitrateIndex] == 0 {
+			return nil, fmt.Errorf("the frame at byte %d is not a valid MPEG layer III frame", position)
+		}
+		table, samples, rate := 0, 1152, mp3SampleRates[rateIndex]
+		switch version {
+		case 2:
+			table, samples, rate = 1, 576, rate/2
+		case 0:
+			table, samples, rate = 1, 576, rate/4
+		}
+		size := samples/8*mp3Bitrates[table][bitrateIndex]*1000/rate + int(header[2]>>1&1)
+		if end-position < size {
+			return nil, fmt.Errorf("the MP3 frame at byte %d is truncated", position)
+		}
+		frames = append(frames, mp3Frame{data: audio[position : position+size], samples: samples, sampleRate: rate})
+		position += size
+	}
+	if len(frames) > 0 && frames[0].isInfo() {
+		frames = frames[1:]
+	}
+	return frames, nil
+}
+
+// isInfo : Reports whether the frame is a Xing or Info frame, which holds the frame count instead of audio
+func (frame mp3Frame) isInfo() bool {
+	head := frame.data
+	if len(head) > 64 {
+		head = head[:64]
+	}
+	return bytes.Contains(head, []byte("Xing")) || bytes.Contains(head, []byte("Info"))
+}
+
+func (mp3Format) duration(audio []byte) (float64, error) {
+	frames, err := parseMp3(audio)
+	if err != nil {
+		return 0, err
+	}
+	duration := 0.0
+	for _, frame := range frames {
+		duration += float64(frame.samples) / float64(frame.sampleRate)
+	}
+	return duration, nil
+}
+
+func (mp3Format) join(writer io.Writer, segments [][]byte) (int64, error) {
+	var total int64
+	for _, segment := range segments {
+		frames, err := parseMp3(segment)
+		if err != nil {
+			return total, err
+		}
+		for _, frame := range frames {
+			written, err := writer.Write(frame.data)
+			total += int64(written)
+			if err != nil {
+				return total, err
+			}
+		}
+	}
+	return total, nil
+}
+
+// contentTypeOf : Returns the content type of the audio that the service synthesizes for accept. Without one, the
+// Synthesize method asks for audio/basic and the websocket interface returns Ogg audio with Opus.
+func contentTypeOf(accept string, websocket bool) string {
+	if strings.TrimSpace(accept) == "" && websocket {
+		return "audio/ogg;codecs=opus"
+	}
+	if strings.TrimSpace(accept) == "" {
+		return "audio/basic"
+	}
+	return accept
+}
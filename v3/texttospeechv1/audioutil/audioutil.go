@@ -0,0 +1,125 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package audioutil stores the audio that TextToSpeechV1 synthesizes. The service streams `audio/wav` with a header
+// that does not give the size of the data, which many players reject; RepairWavHeader and RepairWavFile write the
+// sizes into the header. Raw `audio/l16`, `audio/mulaw` and `audio/alaw` audio can be wrapped into WAV with RawToWav,
+// and raw or WAV audio can be split into pieces of a maximum duration and joined again.
+//
+// For example, to store synthesized WAV audio in a file as it is streamed:
+//
+//	file, err := os.Create("hello.wav")
+//	...
+//	options := textToSpeech.NewSynthesizeStreamOptions("Hello world")
+//	options.SetAccept("audio/wav")
+//	if _, err = textToSpeech.SynthesizeStream(ctx, options, file); err == nil {
+//		err = audioutil.RepairWavFile(file)
+//	}
+//
+// Raw audio can be stored the same way by writing WavHeader(format, 0) to the file first.
+package audioutil
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/rawaudio"
+)
+
+// Encoding : How the samples of audio are encoded, as the format tag of a WAV file
+type Encoding = rawaudio.Encoding
+
+// The encodings of the audio that the service synthesizes
+const (
+	EncodingPCM   = rawaudio.EncodingPCM
+	EncodingALaw  = rawaudio.EncodingALaw
+	EncodingMuLaw = rawaudio.EncodingMuLaw
+)
+
+// RawFormat : The layout of uncompressed audio, described by a content type such as `audio/l16;rate=22050` or by the
+// header of a WAV file. It is the same type as speechtotextv1.RawAudioFormat.
+type RawFormat = rawaudio.Format
+
+// validateFormat : Checks that format is one that the functions of the package can read and write
+func validateFormat(format RawFormat) error {
+	switch {
+	case format.Rate <= 0:
+		return fmt.Errorf("the rate %d is not valid", format.Rate)
+	case format.Channels <= 0:
+		return fmt.Errorf("the number of channels %d is not valid", format.Channels)
+	case format.Encoding == EncodingPCM && format.BitsPerSample != 8 && format.BitsPerSample != 16 &&
+		format.BitsPerSample != 24 && format.BitsPerSample != 32:
+		return fmt.Errorf("PCM audio with %d bits per sample is not supported", format.BitsPerSample)
+	case (format.Encoding == EncodingALaw || format.Encoding == EncodingMuLaw) && format.BitsPerSample != 8:
+		return fmt.Errorf("a-law and mu-law audio must have 8 bits per sample, not %d", format.BitsPerSample)
+	case format.Encoding != EncodingPCM && format.Encoding != EncodingALaw && format.Encoding != EncodingMuLaw:
+		return fmt.Errorf("the encoding %d is not supported", format.Encoding)
+	}
+	return nil
+}
+
+// ParseRawFormat : Parses the content type of raw audio that the service synthesizes: `audio/l16`, `audio/mulaw` and
+// `audio/alaw` with a `rate` parameter, and `audio/basic`. The `channels` and `endianness` parameters are read too;
+// `audio/l16` is little-endian unless `endianness=big-endian` is given.
+func ParseRawFormat(contentType string) (RawFormat, error) {
+	return rawaudio.Parse(contentType)
+}
+
+// SplitPCM : Splits raw audio into pieces of at most maxDuration, cut between samples. The pieces share the memory of
+// audio.
+func SplitPCM(audio []byte, format RawFormat, maxDuration time.Duration) ([][]byte, error) {
+	if err := validateFormat(format); err != nil {
+		return nil, err
+	}
+	if len(audio)%format.FrameSize() != 0 {
+		return nil, fmt.Errorf("the audio of %d bytes is not a whole number of %d-byte samples", len(audio), format.FrameSize())
+	}
+	frames := int(maxDuration.Seconds() * float64(format.Rate))
+	if frames <= 0 {
+		return nil, fmt.Errorf("the duration %s is shorter than a sample", maxDuration)
+	}
+
+	var pieces [][]byte
+	pieceSize := frames * format.FrameSize()
+	for len(audio) > pieceSize {
+		pieces = append(pieces, audio[:pieceSize:pieceSize])
+		audio = audio[pieceSize:]
+	}
+	if len(audio) > 0 {
+		pieces = append(pieces, audio)
+	}
+	return pieces, nil
+}
+
+// JoinPCM : Joins pieces of raw audio in the same format. Every piece must be a whole number of samples, so that the
+// channels and the bytes of the samples that follow it stay in place.
+func JoinPCM(format RawFormat, pieces ...[]byte) ([]byte, error) {
+	if err := validateFormat(format); err != nil {
+		return nil, err
+	}
+	size := 0
+	for i, piece := range pieces {
+		if len(piece)%format.FrameSize() != 0 {
+			return nil, fmt.Errorf("piece %d of %d bytes is not a whole number of %d-byte samples", i+1, len(piece), format.FrameSize())
+		}
+		size += len(piece)
+	}
+	joined := make([]byte, 0, size)
+	for _, piece := range pieces {
+		joined = append(joined, piece...)
+	}
+	return joined, nil
+}
@@ -0,0 +1,173 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audioutil_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/audioutil"
+)
+
+var l16 = audioutil.RawFormat{Encoding: audioutil.EncodingPCM, Rate: 22050, Channels: 1, BitsPerSample: 16}
+
+// streamedWav : Returns WAV audio with a LIST chunk and a header that does not give the sizes, as the service streams
+// it
+func streamedWav(data []byte) []byte {
+	audio := []byte("RIFF\xff\xff\xff\xffWAVEfmt \x10\x00\x00\x00\x01\x00\x01\x00\x22\x56\x00\x00\x44\xac\x00\x00\x02\x00\x10\x00")
+	audio = append(audio, "LIST\x04\x00\x00\x00INFO"...)
+	audio = append(audio, "data\xff\xff\xff\xff"...)
+	return append(audio, data...)
+}
+
+var _ = Describe(`Audioutil`, func() {
+	table.DescribeTable(`ParseRawFormat reads raw content types`,
+		func(contentType string, format audioutil.RawFormat) {
+			Expect(audioutil.ParseRawFormat(contentType)).To(Equal(format))
+		},
+		table.Entry(`l16`, `audio/l16;rate=22050`, l16),
+		table.Entry(`big-endian stereo l16`, `audio/l16; rate=16000; channels=2; endianness=big-endian`,
+			audioutil.RawFormat{Encoding: audioutil.EncodingPCM, Rate: 16000, Channels: 2, BitsPerSample: 16, BigEndian: true}),
+		table.Entry(`mu-law`, `audio/mulaw;rate=8000`,
+			audioutil.RawFormat{Encoding: audioutil.EncodingMuLaw, Rate: 8000, Channels: 1, BitsPerSample: 8}),
+		table.Entry(`basic`, `audio/basic`,
+			audioutil.RawFormat{Encoding: audioutil.EncodingMuLaw, Rate: 8000, Channels: 1, BitsPerSample: 8}),
+	)
+
+	table.DescribeTable(`ParseRawFormat rejects other content types`,
+		func(contentType string, message string) {
+			_, err := audioutil.ParseRawFormat(contentType)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal(message))
+		},
+		table.Entry(`compressed audio`, `audio/ogg;codecs=opus`, `content type audio/ogg is not a raw audio format`),
+		table.Entry(`no rate`, `audio/l16`, `content type audio/l16 requires a rate parameter`),
+		table.Entry(`an invalid rate`, `audio/alaw;rate=fast`, `invalid rate "fast" in content type audio/alaw;rate=fast`),
+	)
+
+	It(`Repairs the header of streamed WAV audio`, func() {
+		// The last byte is half of a sample
+		repaired, err := audioutil.RepairWavHeader(streamedWav([]byte{1, 2, 3, 4, 5}))
+		Expect(err).To(BeNil())
+		Expect(repaired).To(Equal(append(audioutil.WavHeader(l16, 4), 1, 2, 3, 4)))
+		Expect(binary.LittleEndian.Uint32(repaired[4:8])).To(Equal(uint32(len(repaired) - 8)))
+		Expect(binary.LittleEndian.Uint32(repaired[40:44])).To(Equal(uint32(4)))
+
+		wav, err := audioutil.ParseWav(repaired)
+		Expect(err).To(BeNil())
+		Expect(wav).To(Equal(&audioutil.Wav{Format: l16, Data: []byte{1, 2, 3, 4}}))
+		Expect(wav.Format.Duration(44100)).To(Equal(time.Second))
+
+		_, err = audioutil.RepairWavHeader([]byte("OggS\x00\x02"))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("the audio is not WAV audio"))
+	})
+
+	It(`Repairs the header of WAV audio in a file`, func() {
+		file, err := ioutil.TempFile("", "audioutil-*.wav")
+		Expect(err).To(BeNil())
+		defer os.Remove(file.Name())
+		defer file.Close()
+
+		mulaw := audioutil.RawFormat{Encoding: audioutil.EncodingMuLaw, Rate: 8000, Channels: 1, BitsPerSample: 8}
+		header := audioutil.WavHeader(mulaw, 0)
+		Expect(header).To(HaveLen(46))
+		_, err = file.Write(append(header, 1, 2, 3))
+		Expect(err).To(BeNil())
+		Expect(audioutil.RepairWavFile(file)).To(Succeed())
+
+		repaired, err := ioutil.ReadFile(file.Name())
+		Expect(err).To(BeNil())
+		Expect(repaired).To(Equal(append(audioutil.WavHeader(mulaw, 3), 1, 2, 3, 0)))
+		Expect(binary.LittleEndian.Uint32(repaired[4:8])).To(Equal(uint32(len(repaired) - 8)))
+
+		// The sizes are kept once they are right
+		Expect(audioutil.RepairWavFile(file)).To(Succeed())
+		again, err := ioutil.ReadFile(file.Name())
+		Expect(err).To(BeNil())
+		Expect(again).To(Equal(repaired))
+	})
+
+	It(`Wraps raw audio into WAV`, func() {
+		wav, err := audioutil.RawToWav([]byte{0x01, 0x02, 0x03, 0x04}, "audio/l16;rate=22050;endianness=big-endian")
+		Expect(err).To(BeNil())
+		Expect(wav).To(Equal(append(audioutil.WavHeader(l16, 4), 0x02, 0x01, 0x04, 0x03)))
+
+		wav, err = audioutil.RawToWav([]byte{0x01, 0x02}, "audio/l16;rate=22050")
+		Expect(err).To(BeNil())
+		Expect(wav[44:]).To(Equal([]byte{0x01, 0x02}))
+
+		_, err = audioutil.RawToWav([]byte{0x01, 0x02, 0x03}, "audio/l16;rate=22050")
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("the audio of 3 bytes is not a whole number of 2-byte samples"))
+	})
+
+	It(`Splits and joins raw audio between samples`, func() {
+		stereo := audioutil.RawFormat{Encoding: audioutil.EncodingPCM, Rate: 10, Channels: 2, BitsPerSample: 16}
+		audio := make([]byte, 100)
+		for i := range audio {
+			audio[i] = byte(i)
+		}
+		pieces, err := audioutil.SplitPCM(audio, stereo, 1100*time.Millisecond)
+		Expect(err).To(BeNil())
+		Expect(pieces).To(HaveLen(3))
+		Expect(pieces[0]).To(HaveLen(44))
+		Expect(pieces[1]).To(HaveLen(44))
+		Expect(pieces[2]).To(HaveLen(12))
+
+		joined, err := audioutil.JoinPCM(stereo, pieces...)
+		Expect(err).To(BeNil())
+		Expect(joined).To(Equal(audio))
+
+		_, err = audioutil.JoinPCM(stereo, audio[:4], audio[4:10])
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("piece 2 of 6 bytes is not a whole number of 4-byte samples"))
+
+		_, err = audioutil.SplitPCM(audio, stereo, time.Millisecond)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("the duration 1ms is shorter than a sample"))
+	})
+
+	It(`Splits and joins WAV audio`, func() {
+		data := bytes.Repeat([]byte{7, 0}, 22050*5)
+		pieces, err := audioutil.SplitWav(streamedWav(data), 2*time.Second)
+		Expect(err).To(BeNil())
+		Expect(pieces).To(HaveLen(3))
+		for i, size := range []int{88200, 88200, 44100} {
+			wav, err := audioutil.ParseWav(pieces[i])
+			Expect(err).To(BeNil())
+			Expect(wav.Data).To(HaveLen(size))
+			Expect(binary.LittleEndian.Uint32(pieces[i][40:44])).To(Equal(uint32(size)))
+		}
+
+		joined, err := audioutil.JoinWav(pieces...)
+		Expect(err).To(BeNil())
+		Expect(joined).To(Equal(append(audioutil.WavHeader(l16, len(data)), data...)))
+
+		other, err := audioutil.RawToWav([]byte{1}, "audio/mulaw;rate=8000")
+		Expect(err).To(BeNil())
+		_, err = audioutil.JoinWav(pieces[0], other)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("piece 2 has a different format than the first piece"))
+	})
+})
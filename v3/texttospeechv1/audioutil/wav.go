@@ -0,0 +1,310 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audioutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// unknownSize : The size that a streamed header gives for the RIFF and data chunks
+const unknownSize = 0xFFFFFFFF
+
+// encodingExtensible : The format tag of a WAV file whose encoding is given by the sub-format of its fmt chunk
+const encodingExtensible = 0xFFFE
+
+// Wav : The format and samples of WAV audio
+type Wav struct {
+	Format RawFormat
+
+	// The samples, which share the memory of the parsed audio.
+	Data []byte
+}
+
+// ParseWav : Reads WAV audio. A data chunk whose size is unknown, as in the audio that the service streams, or larger
+// than the audio runs to the end of the audio, without an incomplete sample at the end.
+func ParseWav(audio []byte) (*Wav, error) {
+	if len(audio) < 12 || string(audio[0:4]) != "RIFF" || string(audio[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("the audio is not WAV audio")
+	}
+	var wav *Wav
+	var format *RawFormat
+	for position := 12; position+8 <= len(audio); {
+		id := string(audio[position : position+4])
+		declared := binary.LittleEndian.Uint32(audio[position+4 : position+8])
+		size := int(declared)
+		body := position + 8
+		if id == "data" {
+			if format == nil {
+				return nil, fmt.Errorf("the WAV audio has no fmt chunk before its data")
+			}
+			wav = &Wav{Format: *format, Data: audio[body:]}
+			if declared != 0 && declared != unknownSize && size <= len(audio)-body {
+				wav.Data = wav.Data[:size]
+			} else {
+				wav.Data = wav.Data[:len(wav.Data)-len(wav.Data)%format.FrameSize()]
+			}
+			break
+		}
+		if size > len(audio)-body {
+			return nil, fmt.Errorf("the %q chunk of the WAV audio is truncated", id)
+		}
+		if id == "fmt " {
+			parsed, err := parseFmtChunk(audio[body : body+size])
+			if err != nil {
+				return nil, err
+			}
+			format = &parsed
+		}
+		position = body + size + size%2
+	}
+	if wav == nil {
+		return nil, fmt.Errorf("the WAV audio has no data chunk")
+	}
+	return wav, nil
+}
+
+// parseFmtChunk : Reads the format of WAV audio from its fmt chunk
+func parseFmtChunk(chunk []byte) (RawFormat, error) {
+	if len(chunk) < 16 {
+		return RawFormat{}, fmt.Errorf("the fmt chunk of the WAV audio is too short")
+	}
+	format := RawFormat{
+		Encoding:      Encoding(binary.LittleEndian.Uint16(chunk[0:2])),
+		Channels:      int(binary.LittleEndian.Uint16(chunk[2:4])),
+		Rate:          int(binary.LittleEndian.Uint32(chunk[4:8])),
+		BitsPerSample: int(binary.LittleEndian.Uint16(chunk[14:16])),
+	}
+	if format.Encoding == encodingExtensible && len(chunk) >= 26 {
+		format.Encoding = Encoding(binary.LittleEndian.Uint16(chunk[24:26]))
+	}
+	if err := validateFormat(format); err != nil {
+		return RawFormat{}, fmt.Errorf("the WAV audio cannot be read: %s", err)
+	}
+	return format, nil
+}
+
+// WavHeader : Returns the header of WAV audio in format with dataSize bytes of samples. The samples that follow the
+// header must be little-endian, and a pad byte must follow them when dataSize is odd.
+func WavHeader(format RawFormat, dataSize int) []byte {
+	fmtSize := 16
+	if format.Encoding != EncodingPCM {
+		// Formats other than PCM have the size of an extension, which is 0
+		fmtSize = 18
+	}
+	header := make([]byte, 20+fmtSize+8)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(4+8+fmtSize+8+dataSize+dataSize%2))
+	copy(header[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(header[16:20], uint32(fmtSize))
+	binary.LittleEndian.PutUint16(header[20:22], uint16(format.Encoding))
+	binary.LittleEndian.PutUint16(header[22:24], uint16(format.Channels))
+	binary.LittleEndian.PutUint32(header[24:28], uint32(format.Rate))
+	binary.LittleEndian.PutUint32(header[28:32], uint32(format.ByteRate()))
+	binary.LittleEndian.PutUint16(header[32:34], uint16(format.FrameSize()))
+	binary.LittleEndian.PutUint16(header[34:36], uint16(format.BitsPerSample))
+	copy(header[20+fmtSize:], "data")
+	binary.LittleEndian.PutUint32(header[24+fmtSize:], uint32(dataSize))
+	return header
+}
+
+// WriteWav : Writes WAV audio in format with the samples of data, which are joined in order
+func WriteWav(writer io.Writer, format RawFormat, data ...[]byte) (int64, error) {
+	if err := validateFormat(format); err != nil {
+		return 0, err
+	}
+	dataSize := 0
+	for _, samples := range data {
+		dataSize += len(samples)
+	}
+	if int64(dataSize) > math.MaxUint32-64 {
+		return 0, fmt.Errorf("the audio of %d bytes is too large for WAV", dataSize)
+	}
+
+	written, err := writer.Write(WavHeader(format, dataSize))
+	total := int64(written)
+	for _, samples := range data {
+		if err != nil {
+			break
+		}
+		written, err = writer.Write(samples)
+		total += int64(written)
+	}
+	if err == nil && dataSize%2 == 1 {
+		written, err = writer.Write([]byte{0})
+		total += int64(written)
+	}
+	return total, err
+}
+
+// RepairWavHeader : Returns WAV audio whose header gives the sizes of its chunks. The audio that the service streams
+// has a header with unknown sizes, which many players reject. Chunks other than the format and the data are left out.
+func RepairWavHeader(audio []byte) ([]byte, error) {
+	wav, err := ParseWav(audio)
+	if err != nil {
+		return nil, err
+	}
+	var repaired bytes.Buffer
+	if _, err = WriteWav(&repaired, wav.Format, wav.Data); err != nil {
+		return nil, err
+	}
+	return repaired.Bytes(), nil
+}
+
+// RepairWavFile : Writes the sizes of the chunks into the header of WAV audio stored in file, such as an *os.File that
+// synthesized audio was streamed to. A data chunk whose size is unknown runs to the end of the file, and a pad byte is
+// added to the file when that size is odd. The other bytes of the file are not changed.
+func RepairWavFile(file io.ReadWriteSeeker) error {
+	fileSize, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	riff := make([]byte, 12)
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err = io.ReadFull(file, riff); err != nil || string(riff[0:4]) != "RIFF" || string(riff[8:12]) != "WAVE" {
+		return fmt.Errorf("the file is not WAV audio")
+	}
+
+	chunk := make([]byte, 8)
+	for position := int64(12); ; {
+		if _, err = file.Seek(position, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err = io.ReadFull(file, chunk); err != nil {
+			return fmt.Errorf("the WAV audio has no data chunk")
+		}
+		size := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+		body := position + 8
+		if string(chunk[0:4]) == "data" {
+			if size == 0 || size == unknownSize || size > fileSize-body {
+				size = fileSize - body
+				if size%2 == 1 {
+					if err = writeAt(file, fileSize, []byte{0}); err != nil {
+						return err
+					}
+					fileSize++
+				}
+			}
+			if fileSize-8 > math.MaxUint32 {
+				return fmt.Errorf("the file of %d bytes is too large for WAV", fileSize)
+			}
+			if err = writeUint32At(file, 4, uint32(fileSize-8)); err != nil {
+				return err
+			}
+			return writeUint32At(file, position+4, uint32(size))
+		}
+		if size > fileSize-body {
+			return fmt.Errorf("the %q chunk of the WAV audio is truncated", chunk[0:4])
+		}
+		position = body + size + size%2
+	}
+}
+
+func writeUint32At(file io.WriteSeeker, offset int64, value uint32) error {
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, value)
+	return writeAt(file, offset, data)
+}
+
+func writeAt(file io.WriteSeeker, offset int64, data []byte) error {
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := file.Write(data)
+	return err
+}
+
+// RawToWav : Wraps raw audio of the given content type, such as `audio/l16;rate=22050` from Synthesize, into WAV
+// audio. Big-endian samples are made little-endian.
+func RawToWav(audio []byte, contentType string) ([]byte, error) {
+	format, err := ParseRawFormat(contentType)
+	if err != nil {
+		return nil, err
+	}
+	if len(audio)%format.FrameSize() != 0 {
+		return nil, fmt.Errorf("the audio of %d bytes is not a whole number of %d-byte samples", len(audio), format.FrameSize())
+	}
+	if format.BigEndian {
+		swapped := make([]byte, len(audio))
+		for i := 0; i < len(audio); i += 2 {
+			swapped[i], swapped[i+1] = audio[i+1], audio[i]
+		}
+		audio = swapped
+		format.BigEndian = false
+	}
+
+	var wav bytes.Buffer
+	if _, err = WriteWav(&wav, format, audio); err != nil {
+		return nil, err
+	}
+	return wav.Bytes(), nil
+}
+
+// SplitWav : Splits WAV audio into WAV pieces of at most maxDuration, cut between samples
+func SplitWav(audio []byte, maxDuration time.Duration) ([][]byte, error) {
+	wav, err := ParseWav(audio)
+	if err != nil {
+		return nil, err
+	}
+	data, err := SplitPCM(wav.Data, wav.Format, maxDuration)
+	if err != nil {
+		return nil, err
+	}
+	pieces := make([][]byte, len(data))
+	for i, samples := range data {
+		var piece bytes.Buffer
+		if _, err = WriteWav(&piece, wav.Format, samples); err != nil {
+			return nil, err
+		}
+		pieces[i] = piece.Bytes()
+	}
+	return pieces, nil
+}
+
+// JoinWav : Joins pieces of WAV audio in the same format into one WAV audio, whose header gives its size. The pieces
+// can have streamed headers with unknown sizes.
+func JoinWav(pieces ...[]byte) ([]byte, error) {
+	if len(pieces) == 0 {
+		return nil, fmt.Errorf("there is no audio to join")
+	}
+	var format RawFormat
+	data := make([][]byte, len(pieces))
+	for i, piece := range pieces {
+		wav, err := ParseWav(piece)
+		if err != nil {
+			return nil, fmt.Errorf("piece %d: %s", i+1, err)
+		}
+		if i == 0 {
+			format = wav.Format
+		} else if wav.Format != format {
+			return nil, fmt.Errorf("piece %d has a different format than the first piece", i+1)
+		}
+		data[i] = wav.Data
+	}
+
+	var joined bytes.Buffer
+	if _, err := WriteWav(&joined, format, data...); err != nil {
+		return nil, err
+	}
+	return joined.Bytes(), nil
+}
@@ -0,0 +1,86 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lexicon
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// csvHeader : The columns of a lexicon in CSV
+var csvHeader = []string{"word", "translation", "alphabet", "part_of_speech"}
+
+// ReadCSV : Reads a lexicon from CSV with the columns word, translation, alphabet and part_of_speech, of which only the
+// first two are required. The translation is a phoneme in the alphabet, `ipa` or `ibm`, or sounds-like words when the
+// alphabet is empty; a translation that is an SSML phoneme element, as the service stores it, is read too. A first row
+// with the names of the columns is skipped.
+func ReadCSV(reader io.Reader) (*Lexicon, error) {
+	records := csv.NewReader(reader)
+	records.FieldsPerRecord = -1
+	records.TrimLeadingSpace = true
+
+	lexicon := &Lexicon{}
+	for line := 1; ; line++ {
+		record, err := records.Read()
+		if err == io.EOF {
+			return lexicon, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %s", err)
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), csvHeader[0]) {
+			continue
+		}
+		if len(record) < 2 || len(record) > len(csvHeader) {
+			return nil, fmt.Errorf("invalid CSV: line %d has %d columns; use %s", line, len(record), strings.Join(csvHeader, ", "))
+		}
+		for len(record) < len(csvHeader) {
+			record = append(record, "")
+		}
+
+		word, translation := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		alphabet, partOfSpeech := strings.TrimSpace(record[2]), strings.TrimSpace(record[3])
+		entry := Entry{Word: word, Phoneme: translation, Alphabet: normalizeAlphabet(alphabet), PartOfSpeech: partOfSpeech}
+		if alphabet == "" {
+			if entry, err = NewEntry(word, translation, partOfSpeech); err != nil {
+				return nil, fmt.Errorf("invalid CSV: line %d: %s", line, err)
+			}
+		}
+		lexicon.Entries = append(lexicon.Entries, entry)
+	}
+}
+
+// WriteCSV : Writes the lexicon as CSV, with the names of the columns in the first row
+func (lexicon *Lexicon) WriteCSV(writer io.Writer) error {
+	records := csv.NewWriter(writer)
+	if err := records.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, entry := range lexicon.Entries {
+		record := []string{entry.Word, entry.SoundsLike, "", entry.PartOfSpeech}
+		if entry.Phoneme != "" {
+			record[1], record[2] = entry.Phoneme, entry.Alphabet
+		}
+		if err := records.Write(record); err != nil {
+			return err
+		}
+	}
+	records.Flush()
+	return records.Error()
+}
@@ -0,0 +1,207 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package lexicon imports and exports the words of TextToSpeechV1 custom models as W3C Pronunciation Lexicon
+// Specification (PLS) documents and CSV files, and syncs a custom model with a lexicon. Sync adds and updates the
+// words whose translations differ and deletes the words the lexicon does not list, with as few requests as it can.
+//
+// For example:
+//
+//	file, err := os.Open("products.pls")
+//	...
+//	words, err := lexicon.ReadPLS(file)
+//	...
+//	changes, err := words.Sync(ctx, textToSpeech, customizationID, nil)
+package lexicon
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/validation"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/ssml"
+)
+
+// The limits of the words of a custom model
+const (
+	maxWordLength        = 49
+	maxTranslationLength = 499
+)
+
+// partsOfSpeech : The parts of speech of Japanese words
+var partsOfSpeech = []string{
+	texttospeechv1.WordPartOfSpeechDosiConst, texttospeechv1.WordPartOfSpeechFukuConst,
+	texttospeechv1.WordPartOfSpeechGobiConst, texttospeechv1.WordPartOfSpeechHokaConst,
+	texttospeechv1.WordPartOfSpeechJodoConst, texttospeechv1.WordPartOfSpeechJosiConst,
+	texttospeechv1.WordPartOfSpeechKatoConst, texttospeechv1.WordPartOfSpeechKedoConst,
+	texttospeechv1.WordPartOfSpeechKeyoConst, texttospeechv1.WordPartOfSpeechKigoConst,
+	texttospeechv1.WordPartOfSpeechKoyuConst, texttospeechv1.WordPartOfSpeechMesiConst,
+	texttospeechv1.WordPartOfSpeechRetaConst, texttospeechv1.WordPartOfSpeechStbiConst,
+	texttospeechv1.WordPartOfSpeechSttoConst, texttospeechv1.WordPartOfSpeechStzoConst,
+	texttospeechv1.WordPartOfSpeechSujiConst,
+}
+
+// Lexicon : Words and their translations
+type Lexicon struct {
+	// The language of the words, such as `en-US`.
+	Language string
+
+	Entries []Entry
+}
+
+// Entry : A word and its translation, which is either phonetic or sounds-like
+type Entry struct {
+	Word string
+
+	// The phonetic translation of the word, in Alphabet.
+	Phoneme string
+
+	// The alphabet of Phoneme: ssml.AlphabetIPA or ssml.AlphabetSPR.
+	Alphabet string
+
+	// The sounds-like translation of the word: one or more words that, when combined, sound like the word.
+	SoundsLike string
+
+	// **Japanese only.** The part of speech of the word, one of the texttospeechv1.WordPartOfSpeech constants.
+	PartOfSpeech string
+}
+
+// Translation returns the translation of the entry as the service stores it: an SSML phoneme element for a phonetic
+// translation, or the sounds-like words.
+func (entry Entry) Translation() string {
+	if entry.Phoneme == "" {
+		return entry.SoundsLike
+	}
+	var phoneme bytes.Buffer
+	phoneme.WriteString(`<phoneme alphabet="`)
+	_ = xml.EscapeText(&phoneme, []byte(entry.Alphabet))
+	phoneme.WriteString(`" ph="`)
+	_ = xml.EscapeText(&phoneme, []byte(entry.Phoneme))
+	phoneme.WriteString(`"></phoneme>`)
+	return phoneme.String()
+}
+
+// NewEntry : Reads an entry from a word and its translation as the service stores it
+func NewEntry(word string, translation string, partOfSpeech string) (Entry, error) {
+	entry := Entry{Word: word, PartOfSpeech: partOfSpeech}
+	translation = strings.TrimSpace(translation)
+	if !strings.HasPrefix(translation, "<") {
+		entry.SoundsLike = translation
+		return entry, nil
+	}
+
+	var phoneme struct {
+		XMLName  xml.Name
+		Alphabet string `xml:"alphabet,attr"`
+		PH       string `xml:"ph,attr"`
+	}
+	if err := xml.Unmarshal([]byte(translation), &phoneme); err != nil {
+		return entry, fmt.Errorf("the translation of %q cannot be read: %s", word, err)
+	}
+	if phoneme.XMLName.Local != "phoneme" {
+		return entry, fmt.Errorf("the translation of %q is a %s element, not a phoneme element", word, phoneme.XMLName.Local)
+	}
+	entry.Phoneme = strings.TrimSpace(phoneme.PH)
+	entry.Alphabet = normalizeAlphabet(phoneme.Alphabet)
+	return entry, nil
+}
+
+// normalizeAlphabet : Returns the SSML name of a phonetic alphabet as it is written in SSML or PLS
+func normalizeAlphabet(alphabet string) string {
+	switch strings.ToLower(strings.TrimSpace(alphabet)) {
+	case "", ssml.AlphabetIPA:
+		return ssml.AlphabetIPA
+	case ssml.AlphabetSPR, plsAlphabetSPR:
+		return ssml.AlphabetSPR
+	}
+	return alphabet
+}
+
+// FromWords : Builds a lexicon in language from the words of a custom model
+func FromWords(language string, words []texttospeechv1.Word) (*Lexicon, error) {
+	lexicon := &Lexicon{Language: language}
+	for _, word := range words {
+		entry, err := NewEntry(core.StringNilMapper(word.Word), core.StringNilMapper(word.Translation),
+			core.StringNilMapper(word.PartOfSpeech))
+		if err != nil {
+			return nil, err
+		}
+		lexicon.Entries = append(lexicon.Entries, entry)
+	}
+	return lexicon, nil
+}
+
+// Words returns the entries of the lexicon as the words of a custom model, for AddWords.
+func (lexicon *Lexicon) Words() []texttospeechv1.Word {
+	words := make([]texttospeechv1.Word, len(lexicon.Entries))
+	for i, entry := range lexicon.Entries {
+		words[i] = entry.word()
+	}
+	return words
+}
+
+func (entry Entry) word() texttospeechv1.Word {
+	word := texttospeechv1.Word{Word: core.StringPtr(entry.Word), Translation: core.StringPtr(entry.Translation())}
+	if entry.PartOfSpeech != "" {
+		word.PartOfSpeech = core.StringPtr(entry.PartOfSpeech)
+	}
+	return word
+}
+
+// ValidationError : The problems that Validate found in a lexicon
+type ValidationError = validation.Error
+
+// Validate : Checks that a custom model can store the entries of the lexicon: every word is listed once, is at most
+// 49 characters long and has one translation of at most 499 characters, phonemes are in the IPA or IBM SPR alphabet,
+// and parts of speech are valid. It returns a *ValidationError that lists every problem found.
+func (lexicon *Lexicon) Validate() error {
+	var problems validation.Problems
+	listed := map[string]bool{}
+	for _, entry := range lexicon.Entries {
+		word := entry.Word
+		switch {
+		case strings.TrimSpace(word) == "":
+			problems.Add("an entry has no word")
+			continue
+		case utf8.RuneCountInString(word) > maxWordLength:
+			problems.Add("the word %q is longer than %d characters", word, maxWordLength)
+		case listed[word]:
+			problems.Add("the word %q is listed more than once", word)
+		}
+		listed[word] = true
+
+		switch {
+		case entry.Phoneme != "" && entry.SoundsLike != "":
+			problems.Add("the word %q has both a phoneme and a sounds-like translation", word)
+		case entry.Phoneme == "" && strings.TrimSpace(entry.SoundsLike) == "":
+			problems.Add("the word %q has no translation", word)
+		case entry.Phoneme != "" && entry.Alphabet != ssml.AlphabetIPA && entry.Alphabet != ssml.AlphabetSPR:
+			problems.Add("the phoneme of %q has the alphabet %q; use one of %s, %s", word, entry.Alphabet,
+				ssml.AlphabetIPA, ssml.AlphabetSPR)
+		case utf8.RuneCountInString(entry.Translation()) > maxTranslationLength:
+			problems.Add("the translation of %q is longer than %d characters", word, maxTranslationLength)
+		}
+		if entry.PartOfSpeech != "" && !validation.Contains(partsOfSpeech, entry.PartOfSpeech) {
+			problems.Add("the word %q has the invalid part of speech %q", word, entry.PartOfSpeech)
+		}
+	}
+	return problems.Err("lexicon")
+}
@@ -0,0 +1,314 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lexicon_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/fakeservice"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/lexicon"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/ssml"
+)
+
+// products : A lexicon with phonetic and sounds-like entries in both alphabets
+func products() *lexicon.Lexicon {
+	return &lexicon.Lexicon{
+		Language: "en-US",
+		Entries: []lexicon.Entry{
+			{Word: "tomato", Phoneme: "təˈmɑtoʊ", Alphabet: ssml.AlphabetIPA},
+			{Word: "IEEE", SoundsLike: "I triple E"},
+			{Word: "data", Phoneme: ".1Dey.0tx", Alphabet: ssml.AlphabetSPR},
+		},
+	}
+}
+
+const productsPLS = `<?xml version="1.0" encoding="UTF-8"?>
+<lexicon xmlns="http://www.w3.org/2005/01/pronunciation-lexicon" version="1.0" alphabet="ipa" xml:lang="en-US">
+  <lexeme>
+    <grapheme>tomato</grapheme>
+    <phoneme>təˈmɑtoʊ</phoneme>
+  </lexeme>
+  <lexeme>
+    <grapheme>IEEE</grapheme>
+    <alias>I triple E</alias>
+  </lexeme>
+  <lexeme>
+    <grapheme>data</grapheme>
+    <phoneme alphabet="x-ibm">.1Dey.0tx</phoneme>
+  </lexeme>
+</lexicon>
+`
+
+const productsCSV = `word,translation,alphabet,part_of_speech
+tomato,təˈmɑtoʊ,ipa,
+IEEE,I triple E,,
+data,.1Dey.0tx,ibm,
+`
+
+// customModelService : A fake custom model with words, which records the requests that read pronunciations or change
+// it
+type customModelService struct {
+	*fakeservice.Server
+	words    []texttospeechv1.Word
+	requests []string
+
+	// The phoneme format that GetPronunciation rejects.
+	unsupported string
+}
+
+func newCustomModelService() *customModelService {
+	service := &customModelService{Server: fakeservice.NewServer(), unsupported: "none"}
+	service.Handle(http.MethodGet, "/v1/customizations/cust-1", func(call *fakeservice.Call) {
+		call.JSON(http.StatusOK, map[string]interface{}{"customization_id": "cust-1", "language": "en-US", "words": service.words})
+	})
+	service.Handle(http.MethodGet, "/v1/customizations/cust-1/words", func(call *fakeservice.Call) {
+		call.JSON(http.StatusOK, map[string]interface{}{"words": service.words})
+	})
+	service.Handle(http.MethodGet, "/v1/voices", func(call *fakeservice.Call) {
+		call.JSON(http.StatusOK, `{"voices": [`+
+			`{"url": "u", "gender": "female", "name": "fr-FR_ReneeV3Voice", "language": "fr-FR", "description": "d", "customizable": true},`+
+			`{"url": "u", "gender": "male", "name": "en-US_MichaelV3Voice", "language": "en-US", "description": "d", "customizable": true},`+
+			`{"url": "u", "gender": "female", "name": "en-US_AllisonV3Voice", "language": "en-US", "description": "d", "customizable": true}]}`)
+	})
+	service.Handle(http.MethodGet, "/v1/pronunciation", func(call *fakeservice.Call) {
+		query := call.URL.Query()
+		service.requests = append(service.requests, fmt.Sprintf("pronunciation %s %s %s %s", query.Get("text"),
+			query.Get("voice"), query.Get("format"), query.Get("customization_id")))
+		if query.Get("format") == service.unsupported {
+			call.Error(http.StatusBadRequest, "The format is not supported for the language")
+			return
+		}
+		call.JSON(http.StatusOK, `{"pronunciation": "x"}`)
+	})
+	service.Handle(http.MethodPost, "/v1/customizations/cust-1/words", func(call *fakeservice.Call) {
+		var body texttospeechv1.Words
+		Expect(json.NewDecoder(call.Body).Decode(&body)).To(Succeed())
+		var added []string
+		for _, word := range body.Words {
+			added = append(added, *word.Word+"="+*word.Translation)
+		}
+		service.requests = append(service.requests, "add "+strings.Join(added, " "))
+		call.JSON(http.StatusOK, `{}`)
+	})
+	service.Handle(http.MethodDelete, "/v1/customizations/cust-1/words/{word}", func(call *fakeservice.Call) {
+		service.requests = append(service.requests, "delete "+call.Params["word"])
+		call.Response.WriteHeader(http.StatusNoContent)
+	})
+	return service
+}
+
+var _ = Describe(`Lexicon`, func() {
+	It(`Writes and reads PLS`, func() {
+		var pls bytes.Buffer
+		Expect(products().WritePLS(&pls)).To(Succeed())
+		Expect(pls.String()).To(Equal(productsPLS))
+
+		read, err := lexicon.ReadPLS(strings.NewReader(productsPLS))
+		Expect(err).To(BeNil())
+		Expect(read).To(Equal(products()))
+	})
+
+	It(`Reads lexemes with several graphemes and pronunciations`, func() {
+		read, err := lexicon.ReadPLS(strings.NewReader(`<lexicon version="1.0" alphabet="x-ibm" xml:lang="ja-JP"
+			xmlns="http://www.w3.org/2005/01/pronunciation-lexicon">
+			<lexeme role="Koyu">
+				<grapheme>W3C</grapheme><grapheme>w3c</grapheme>
+				<alias>World Wide Web Consortium</alias>
+				<phoneme prefer="true">.1dx.0bxl</phoneme>
+				<example>The W3C writes standards</example>
+			</lexeme>
+		</lexicon>`))
+		Expect(err).To(BeNil())
+		entry := lexicon.Entry{Word: "W3C", Phoneme: ".1dx.0bxl", Alphabet: ssml.AlphabetSPR, PartOfSpeech: "Koyu"}
+		lowercase := entry
+		lowercase.Word = "w3c"
+		Expect(read).To(Equal(&lexicon.Lexicon{Language: "ja-JP", Entries: []lexicon.Entry{entry, lowercase}}))
+
+		_, err = lexicon.ReadPLS(strings.NewReader(`<lexicon><lexeme><grapheme>W3C</grapheme></lexeme></lexicon>`))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("invalid PLS: lexeme 1 needs a grapheme and a phoneme or alias"))
+	})
+
+	It(`Writes and reads CSV`, func() {
+		var csv bytes.Buffer
+		Expect(products().WriteCSV(&csv)).To(Succeed())
+		Expect(csv.String()).To(Equal(productsCSV))
+
+		read, err := lexicon.ReadCSV(strings.NewReader(productsCSV))
+		Expect(err).To(BeNil())
+		Expect(read.Entries).To(Equal(products().Entries))
+
+		read, err = lexicon.ReadCSV(strings.NewReader(`tomato,"<phoneme alphabet=""IPA"" ph=""təˈmɑtoʊ""></phoneme>"` + "\n" +
+			"IEEE, I triple E\n"))
+		Expect(err).To(BeNil())
+		Expect(read.Entries).To(Equal(products().Entries[:2]))
+
+		_, err = lexicon.ReadCSV(strings.NewReader("tomato\n"))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("invalid CSV: line 1 has 1 columns; use word, translation, alphabet, part_of_speech"))
+	})
+
+	It(`Converts entries to and from the words of custom models`, func() {
+		words := products().Words()
+		Expect(*words[0].Translation).To(Equal(`<phoneme alphabet="ipa" ph="təˈmɑtoʊ"></phoneme>`))
+		Expect(*words[1].Translation).To(Equal(`I triple E`))
+		Expect(*words[2].Translation).To(Equal(`<phoneme alphabet="ibm" ph=".1Dey.0tx"></phoneme>`))
+
+		converted, err := lexicon.FromWords("en-US", words)
+		Expect(err).To(BeNil())
+		Expect(converted).To(Equal(products()))
+
+		quoted := lexicon.Entry{Word: "quote", Phoneme: `a"b<c`, Alphabet: ssml.AlphabetIPA}
+		Expect(quoted.Translation()).To(Equal(`<phoneme alphabet="ipa" ph="a&#34;b&lt;c"></phoneme>`))
+
+		_, err = lexicon.NewEntry("IBM", `<say-as interpret-as="letters">IBM</say-as>`, "")
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal(`the translation of "IBM" is a say-as element, not a phoneme element`))
+	})
+
+	table.DescribeTable(`Validate reports what a custom model cannot store`,
+		func(entry lexicon.Entry, problems ...string) {
+			err := (&lexicon.Lexicon{Entries: []lexicon.Entry{{Word: "ok", SoundsLike: "okay"}, entry}}).Validate()
+			if len(problems) == 0 {
+				Expect(err).To(BeNil())
+				return
+			}
+			Expect(err).To(BeAssignableToTypeOf(&lexicon.ValidationError{}))
+			Expect(err.(*lexicon.ValidationError).Problems).To(Equal(problems))
+		},
+		table.Entry(`a valid entry`, lexicon.Entry{Word: "東京", Phoneme: "toːkjoː", Alphabet: "ipa", PartOfSpeech: "Koyu"}),
+		table.Entry(`a duplicate word`, lexicon.Entry{Word: "ok", SoundsLike: "oh kay"}, `the word "ok" is listed more than once`),
+		table.Entry(`a long word`, lexicon.Entry{Word: strings.Repeat("a", 50), SoundsLike: "a"},
+			`the word "`+strings.Repeat("a", 50)+`" is longer than 49 characters`),
+		table.Entry(`no translation`, lexicon.Entry{Word: "IBM"}, `the word "IBM" has no translation`),
+		table.Entry(`two translations`, lexicon.Entry{Word: "IBM", SoundsLike: "I B M", Phoneme: "x", Alphabet: "ipa"},
+			`the word "IBM" has both a phoneme and a sounds-like translation`),
+		table.Entry(`an unknown alphabet`, lexicon.Entry{Word: "IBM", Phoneme: "aI bi Em", Alphabet: "x-sampa"},
+			`the phoneme of "IBM" has the alphabet "x-sampa"; use one of ipa, ibm`),
+		table.Entry(`a long translation`, lexicon.Entry{Word: "IBM", SoundsLike: strings.Repeat("I ", 250)},
+			`the translation of "IBM" is longer than 499 characters`),
+		table.Entry(`an invalid part of speech`, lexicon.Entry{Word: "IBM", SoundsLike: "I B M", PartOfSpeech: "Noun"},
+			`the word "IBM" has the invalid part of speech "Noun"`),
+	)
+
+	Describe(`With a custom model`, func() {
+		var service *customModelService
+		var textToSpeech *texttospeechv1.TextToSpeechV1
+
+		BeforeEach(func() {
+			service = newCustomModelService()
+			service.words = []texttospeechv1.Word{
+				{Word: core.StringPtr("IEEE"), Translation: core.StringPtr("I triple E")},
+				{Word: core.StringPtr("tomato"), Translation: core.StringPtr(`<phoneme alphabet="IPA" ph="təˈmeɪtoʊ"></phoneme>`)},
+				{Word: core.StringPtr("IBM"), Translation: core.StringPtr("I B M")},
+			}
+			var err error
+			textToSpeech, err = texttospeechv1.NewTextToSpeechV1(&texttospeechv1.TextToSpeechV1Options{
+				URL:           service.URL,
+				Authenticator: &core.NoAuthAuthenticator{},
+			})
+			Expect(err).To(BeNil())
+		})
+
+		AfterEach(func() {
+			service.Close()
+		})
+
+		It(`Exports the words of the model`, func() {
+			exported, err := lexicon.Export(context.Background(), textToSpeech, "cust-1")
+			Expect(err).To(BeNil())
+			Expect(exported).To(Equal(&lexicon.Lexicon{Language: "en-US", Entries: []lexicon.Entry{
+				{Word: "IEEE", SoundsLike: "I triple E"},
+				{Word: "tomato", Phoneme: "təˈmeɪtoʊ", Alphabet: ssml.AlphabetIPA},
+				{Word: "IBM", SoundsLike: "I B M"},
+			}}))
+		})
+
+		It(`Syncs the model with the fewest changes`, func() {
+			changes, err := products().Sync(context.Background(), textToSpeech, "cust-1", nil)
+			Expect(err).To(BeNil())
+			Expect(changes).To(Equal(&lexicon.SyncChanges{
+				Added:   []string{"data"},
+				Updated: []string{"tomato"},
+				Deleted: []string{"IBM"},
+			}))
+			Expect(service.requests).To(Equal([]string{
+				"pronunciation tomato en-US_AllisonV3Voice ipa cust-1",
+				"pronunciation data en-US_AllisonV3Voice ibm cust-1",
+				`add tomato=<phoneme alphabet="ipa" ph="təˈmɑtoʊ"></phoneme> data=<phoneme alphabet="ibm" ph=".1Dey.0tx"></phoneme>`,
+				"delete IBM",
+			}))
+		})
+
+		It(`Reports the changes of a dry run and keeps unlisted words`, func() {
+			words := products()
+			words.Language = ""
+			changes, err := words.Sync(context.Background(), textToSpeech, "cust-1",
+				&lexicon.SyncOptions{DryRun: true, KeepUnlisted: true, Voice: "en-US_MichaelV3Voice"})
+			Expect(err).To(BeNil())
+			Expect(changes).To(Equal(&lexicon.SyncChanges{Added: []string{"data"}, Updated: []string{"tomato"}}))
+			Expect(service.requests).To(Equal([]string{
+				"pronunciation tomato en-US_MichaelV3Voice ipa cust-1",
+				"pronunciation data en-US_MichaelV3Voice ibm cust-1",
+			}))
+		})
+
+		It(`Changes nothing when an alphabet is not supported`, func() {
+			service.unsupported = ssml.AlphabetSPR
+			_, err := products().Sync(context.Background(), textToSpeech, "cust-1", nil)
+			Expect(err).To(BeAssignableToTypeOf(&lexicon.ValidationError{}))
+			Expect(err.Error()).To(Equal(`invalid lexicon: the phoneme of "data" is in the ibm alphabet, which the custom ` +
+				`model does not support: The format is not supported for the language`))
+			Expect(service.requests).To(HaveLen(2))
+		})
+
+		It(`Reports every word whose phoneme is invalid`, func() {
+			service.unsupported = ssml.AlphabetSPR
+			words := products()
+			words.Entries = append(words.Entries,
+				lexicon.Entry{Word: "coupon", Phoneme: ".1ku.0pxn", Alphabet: ssml.AlphabetIPA},
+				lexicon.Entry{Word: "router", Phoneme: "ˈraʊtər", Alphabet: ssml.AlphabetSPR},
+				lexicon.Entry{Word: "W3C", Phoneme: "1dx.0bxl", Alphabet: ssml.AlphabetSPR},
+				lexicon.Entry{Word: "tuple", Phoneme: "ˈtuːpəl", Alphabet: ssml.AlphabetIPA},
+			)
+			_, err := words.Sync(context.Background(), textToSpeech, "cust-1", nil)
+			Expect(err).To(BeAssignableToTypeOf(&lexicon.ValidationError{}))
+			Expect(err.(*lexicon.ValidationError).Problems).To(Equal([]string{
+				`the phoneme of "coupon" has '1', which is not an IPA symbol`,
+				`the phoneme of "router" has 'ˈ', which is not an IBM SPR symbol`,
+				`the phoneme of "W3C" has the stress marker '1', which must be 0, 1 or 2 after a syllable boundary`,
+				`the phoneme of "data" is in the ibm alphabet, which the custom model does not support: The format is not supported for the language`,
+				`the phoneme of "router" is in the ibm alphabet, which the custom model does not support: The format is not supported for the language`,
+				`the phoneme of "W3C" is in the ibm alphabet, which the custom model does not support: The format is not supported for the language`,
+			}))
+			Expect(service.requests).To(Equal([]string{
+				"pronunciation tomato en-US_AllisonV3Voice ipa cust-1",
+				"pronunciation data en-US_AllisonV3Voice ibm cust-1",
+			}))
+		})
+	})
+})
@@ -0,0 +1,74 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lexicon
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/ssml"
+)
+
+// ipaSymbols : The ranges of characters that IPA transcriptions are written with, besides lowercase ASCII letters:
+// the Latin letters that IPA borrows, the IPA Extensions, spacing modifier letters such as stress and length marks,
+// combining diacritics, Greek letters, phonetic extensions, and prosodic marks
+var ipaSymbols = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00e6, Hi: 0x00e7, Stride: 1}, // æ ç
+		{Lo: 0x00f0, Hi: 0x00f8, Stride: 8}, // ð ø
+		{Lo: 0x0127, Hi: 0x0127, Stride: 1}, // ħ
+		{Lo: 0x014b, Hi: 0x0153, Stride: 8}, // ŋ œ
+		{Lo: 0x01c0, Hi: 0x01c3, Stride: 1}, // clicks
+		{Lo: 0x0250, Hi: 0x036f, Stride: 1}, // IPA Extensions, modifier letters and combining diacritics
+		{Lo: 0x03b2, Hi: 0x03b8, Stride: 6}, // β θ
+		{Lo: 0x03c7, Hi: 0x03c7, Stride: 1}, // χ
+		{Lo: 0x1d00, Hi: 0x1dbf, Stride: 1}, // phonetic extensions
+		{Lo: 0x2016, Hi: 0x2016, Stride: 1}, // ‖
+		{Lo: 0x203f, Hi: 0x203f, Stride: 1}, // ‿
+		{Lo: 0x2191, Hi: 0x2193, Stride: 2}, // ↑ ↓
+	},
+	LatinOffset: 2,
+}
+
+// phonemeProblem : Checks the syntax of the phoneme of an entry and returns what is wrong with it, or an empty string.
+// An IPA phoneme must consist of IPA symbols, syllable boundaries and spaces. An IBM SPR phoneme must consist of
+// printable ASCII characters, with the stress markers 0, 1 and 2 only after syllable boundaries.
+func phonemeProblem(entry Entry) string {
+	if strings.TrimSpace(entry.Phoneme) == "" {
+		return fmt.Sprintf("the phoneme of %q has no symbols", entry.Word)
+	}
+	previous := ' '
+	for _, symbol := range entry.Phoneme {
+		switch entry.Alphabet {
+		case ssml.AlphabetIPA:
+			if !(symbol >= 'a' && symbol <= 'z') && !strings.ContainsRune(" .|", symbol) && !unicode.Is(ipaSymbols, symbol) {
+				return fmt.Sprintf("the phoneme of %q has %q, which is not an IPA symbol", entry.Word, symbol)
+			}
+		case ssml.AlphabetSPR:
+			switch {
+			case symbol < ' ' || symbol > '~':
+				return fmt.Sprintf("the phoneme of %q has %q, which is not an IBM SPR symbol", entry.Word, symbol)
+			case symbol >= '0' && symbol <= '9' && (symbol > '2' || previous != '.'):
+				return fmt.Sprintf("the phoneme of %q has the stress marker %q, which must be 0, 1 or 2 after a "+
+					"syllable boundary", entry.Word, symbol)
+			}
+		}
+		previous = symbol
+	}
+	return ""
+}
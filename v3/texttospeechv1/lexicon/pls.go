@@ -0,0 +1,159 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lexicon
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/ssml"
+)
+
+const (
+	plsNamespace = "http://www.w3.org/2005/01/pronunciation-lexicon"
+	xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+	// The name of the IBM SPR alphabet in PLS, where alphabets other than IPA start with x-
+	plsAlphabetSPR = "x-ibm"
+)
+
+// plsLexicon : The lexicon element of a PLS document
+type plsLexicon struct {
+	XMLName  xml.Name
+	Version  string      `xml:"version,attr"`
+	Alphabet string      `xml:"alphabet,attr"`
+	Language xml.Attr    `xml:",any,attr"`
+	Lexemes  []plsLexeme `xml:"lexeme"`
+}
+
+// plsLexeme : A lexeme of a PLS document: graphemes that are pronounced the same, and their pronunciations
+type plsLexeme struct {
+	Role           string             `xml:"role,attr,omitempty"`
+	Graphemes      []string           `xml:"grapheme"`
+	Pronunciations []plsPronunciation `xml:",any"`
+}
+
+// plsPronunciation : A phoneme or alias element of a lexeme
+type plsPronunciation struct {
+	XMLName  xml.Name
+	Alphabet string `xml:"alphabet,attr,omitempty"`
+	Prefer   string `xml:"prefer,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+// ReadPLS : Reads a PLS document. Every grapheme of a lexeme becomes an entry with the preferred pronunciation of the
+// lexeme, which is its first phoneme or alias unless another one has `prefer="true"`. Aliases become sounds-like
+// translations, and the role of a lexeme becomes the part of speech of its entries.
+func ReadPLS(reader io.Reader) (*Lexicon, error) {
+	var document struct {
+		XMLName  xml.Name
+		Alphabet string      `xml:"alphabet,attr"`
+		Language string      `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+		Lexemes  []plsLexeme `xml:"lexeme"`
+	}
+	if err := xml.NewDecoder(reader).Decode(&document); err != nil {
+		return nil, fmt.Errorf("invalid PLS: %s", err)
+	}
+	if document.XMLName.Local != "lexicon" {
+		return nil, fmt.Errorf("invalid PLS: the root element is %s, not lexicon", document.XMLName.Local)
+	}
+
+	lexicon := &Lexicon{Language: document.Language}
+	for i, lexeme := range document.Lexemes {
+		var preferred *plsPronunciation
+		for j := range lexeme.Pronunciations {
+			pronunciation := &lexeme.Pronunciations[j]
+			name := pronunciation.XMLName.Local
+			if name != "phoneme" && name != "alias" {
+				continue
+			}
+			if preferred == nil || (pronunciation.Prefer == "true" && preferred.Prefer != "true") {
+				preferred = pronunciation
+			}
+		}
+		if len(lexeme.Graphemes) == 0 || preferred == nil {
+			return nil, fmt.Errorf("invalid PLS: lexeme %d needs a grapheme and a phoneme or alias", i+1)
+		}
+
+		entry := Entry{PartOfSpeech: lexeme.Role}
+		if preferred.XMLName.Local == "alias" {
+			entry.SoundsLike = strings.TrimSpace(preferred.Value)
+		} else {
+			entry.Phoneme = strings.TrimSpace(preferred.Value)
+			entry.Alphabet = document.Alphabet
+			if preferred.Alphabet != "" {
+				entry.Alphabet = preferred.Alphabet
+			}
+			entry.Alphabet = normalizeAlphabet(entry.Alphabet)
+		}
+		for _, grapheme := range lexeme.Graphemes {
+			entry.Word = strings.TrimSpace(grapheme)
+			lexicon.Entries = append(lexicon.Entries, entry)
+		}
+	}
+	return lexicon, nil
+}
+
+// WritePLS : Writes the lexicon as a PLS document, with a lexeme for every entry. The alphabet of the lexicon is the
+// alphabet of its first phoneme, and phonemes in the other alphabet name theirs; the IBM SPR alphabet is named x-ibm.
+func (lexicon *Lexicon) WritePLS(writer io.Writer) error {
+	document := plsLexicon{
+		XMLName:  xml.Name{Space: plsNamespace, Local: "lexicon"},
+		Version:  "1.0",
+		Alphabet: ssml.AlphabetIPA,
+		Language: xml.Attr{Name: xml.Name{Space: xmlNamespace, Local: "lang"}, Value: lexicon.Language},
+	}
+	for _, entry := range lexicon.Entries {
+		if entry.Phoneme != "" {
+			document.Alphabet = plsAlphabet(entry.Alphabet)
+			break
+		}
+	}
+	for _, entry := range lexicon.Entries {
+		lexeme := plsLexeme{Role: entry.PartOfSpeech, Graphemes: []string{entry.Word}}
+		pronunciation := plsPronunciation{XMLName: xml.Name{Local: "alias"}, Value: entry.SoundsLike}
+		if entry.Phoneme != "" {
+			pronunciation = plsPronunciation{XMLName: xml.Name{Local: "phoneme"}, Value: entry.Phoneme}
+			if alphabet := plsAlphabet(entry.Alphabet); alphabet != document.Alphabet {
+				pronunciation.Alphabet = alphabet
+			}
+		}
+		lexeme.Pronunciations = []plsPronunciation{pronunciation}
+		document.Lexemes = append(document.Lexemes, lexeme)
+	}
+
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, "\n")
+	return err
+}
+
+// plsAlphabet : Returns the name of a phonetic alphabet in PLS
+func plsAlphabet(alphabet string) string {
+	if normalizeAlphabet(alphabet) == ssml.AlphabetSPR {
+		return plsAlphabetSPR
+	}
+	return normalizeAlphabet(alphabet)
+}
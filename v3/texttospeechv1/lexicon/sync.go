@@ -0,0 +1,208 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lexicon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/validation"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1"
+)
+
+// Export : Gets a custom model and builds a lexicon from its language and words
+func Export(ctx context.Context, textToSpeech *texttospeechv1.TextToSpeechV1, customizationID string) (*Lexicon, error) {
+	model, _, err := textToSpeech.GetCustomModelWithContext(ctx, textToSpeech.NewGetCustomModelOptions(customizationID))
+	if err != nil {
+		return nil, fmt.Errorf("getting custom model %s: %s", customizationID, err)
+	}
+	return FromWords(core.StringNilMapper(model.Language), model.Words)
+}
+
+// SyncOptions : Controls what Sync changes. The zero value syncs the custom model with the lexicon exactly.
+type SyncOptions struct {
+	// Keeps the words that the custom model has but the lexicon does not list, instead of deleting them.
+	KeepUnlisted bool
+
+	// Reports the changes without making them.
+	DryRun bool
+
+	// The voice to request pronunciations with, which must have the language of the custom model. Defaults to a voice
+	// of the language of the lexicon, or of the custom model when the lexicon has none.
+	Voice string
+}
+
+func (options *SyncOptions) withDefaults() SyncOptions {
+	if options == nil {
+		return SyncOptions{}
+	}
+	return *options
+}
+
+// SyncChanges : The words that Sync added, updated and deleted
+type SyncChanges struct {
+	Added   []string
+	Updated []string
+	Deleted []string
+}
+
+// Sync : Makes the words of a custom model match the lexicon. The lexicon is validated, and the words of the model are
+// listed and compared with it; the words that are missing or whose translation or part of speech differs are added
+// with one AddWords request, and the words that the lexicon does not list are deleted. Before any change, the syntax of
+// every phoneme that changes is checked, and the pronunciation of a word is requested with GetPronunciation in every
+// phonetic alphabet that the changes use; the invalid phonemes and the phonemes in an alphabet that the language of
+// the model does not support are reported together as a *ValidationError. The changes made before an error are
+// returned with it.
+func (lexicon *Lexicon) Sync(ctx context.Context, textToSpeech *texttospeechv1.TextToSpeechV1, customizationID string, options *SyncOptions) (*SyncChanges, error) {
+	if err := lexicon.Validate(); err != nil {
+		return nil, err
+	}
+	settings := options.withDefaults()
+
+	listed, _, err := textToSpeech.ListWordsWithContext(ctx, textToSpeech.NewListWordsOptions(customizationID))
+	if err != nil {
+		return nil, fmt.Errorf("listing the words of custom model %s: %s", customizationID, err)
+	}
+	existing := map[string]*Entry{}
+	for _, word := range listed.Words {
+		entry, err := NewEntry(core.StringNilMapper(word.Word), core.StringNilMapper(word.Translation),
+			core.StringNilMapper(word.PartOfSpeech))
+		if err != nil {
+			// A translation that cannot be read is replaced
+			entry = Entry{Word: core.StringNilMapper(word.Word), SoundsLike: core.StringNilMapper(word.Translation)}
+		}
+		existing[entry.Word] = &entry
+	}
+
+	changes := &SyncChanges{}
+	var changed []Entry
+	wanted := map[string]bool{}
+	for _, entry := range lexicon.Entries {
+		wanted[entry.Word] = true
+		current, ok := existing[entry.Word]
+		if ok && *current == entry {
+			continue
+		}
+		changed = append(changed, entry)
+		if ok {
+			changes.Updated = append(changes.Updated, entry.Word)
+		} else {
+			changes.Added = append(changes.Added, entry.Word)
+		}
+	}
+	var deleted []string
+	if !settings.KeepUnlisted {
+		for _, word := range listed.Words {
+			if name := core.StringNilMapper(word.Word); !wanted[name] {
+				deleted = append(deleted, name)
+			}
+		}
+	}
+
+	if err = lexicon.checkPhonemes(ctx, textToSpeech, customizationID, settings.Voice, changed); err != nil {
+		return nil, err
+	}
+	if settings.DryRun {
+		changes.Deleted = deleted
+		return changes, nil
+	}
+
+	if len(changed) > 0 {
+		words := (&Lexicon{Entries: changed}).Words()
+		_, err = textToSpeech.AddWordsWithContext(ctx, textToSpeech.NewAddWordsOptions(customizationID, words))
+		if err != nil {
+			return &SyncChanges{}, fmt.Errorf("adding words to custom model %s: %s", customizationID, err)
+		}
+	}
+	for _, word := range deleted {
+		_, err = textToSpeech.DeleteWordWithContext(ctx, textToSpeech.NewDeleteWordOptions(customizationID, word))
+		if err != nil {
+			return changes, fmt.Errorf("deleting the word %q from custom model %s: %s", word, customizationID, err)
+		}
+		changes.Deleted = append(changes.Deleted, word)
+	}
+	return changes, nil
+}
+
+// checkPhonemes : Checks the syntax of the phoneme of every entry, and requests the pronunciation of a word in every
+// phonetic alphabet of the entries to find the alphabets that the custom model does not support. Every entry with an
+// invalid phoneme, and every entry in an alphabet that is not supported, is reported in one *ValidationError.
+func (lexicon *Lexicon) checkPhonemes(ctx context.Context, textToSpeech *texttospeechv1.TextToSpeechV1, customizationID string, voice string, entries []Entry) error {
+	var problems validation.Problems
+	var alphabets []string
+	byAlphabet := map[string][]string{}
+	for _, entry := range entries {
+		if entry.Phoneme == "" {
+			continue
+		}
+		if problem := phonemeProblem(entry); problem != "" {
+			problems.Add("%s", problem)
+		}
+		if _, ok := byAlphabet[entry.Alphabet]; !ok {
+			alphabets = append(alphabets, entry.Alphabet)
+		}
+		byAlphabet[entry.Alphabet] = append(byAlphabet[entry.Alphabet], entry.Word)
+	}
+	for _, alphabet := range alphabets {
+		words := byAlphabet[alphabet]
+		if voice == "" {
+			var err error
+			if voice, err = lexicon.voiceFor(ctx, textToSpeech, customizationID); err != nil {
+				return err
+			}
+		}
+		options := textToSpeech.NewGetPronunciationOptions(words[0]).
+			SetVoice(voice).
+			SetFormat(alphabet).
+			SetCustomizationID(customizationID)
+		if _, _, err := textToSpeech.GetPronunciationWithContext(ctx, options); err != nil {
+			for _, word := range words {
+				problems.Add("the phoneme of %q is in the %s alphabet, which the custom model does not support: %s",
+					word, alphabet, err)
+			}
+		}
+	}
+	return problems.Err("lexicon")
+}
+
+// voiceFor : Returns the first voice, by name, of the language of the lexicon or of the custom model
+func (lexicon *Lexicon) voiceFor(ctx context.Context, textToSpeech *texttospeechv1.TextToSpeechV1, customizationID string) (string, error) {
+	language := lexicon.Language
+	if language == "" {
+		model, _, err := textToSpeech.GetCustomModelWithContext(ctx, textToSpeech.NewGetCustomModelOptions(customizationID))
+		if err != nil {
+			return "", fmt.Errorf("getting custom model %s: %s", customizationID, err)
+		}
+		language = core.StringNilMapper(model.Language)
+	}
+	voices, _, err := textToSpeech.ListVoicesWithContext(ctx, textToSpeech.NewListVoicesOptions())
+	if err != nil {
+		return "", fmt.Errorf("listing the voices: %s", err)
+	}
+	voice := ""
+	for _, candidate := range voices.Voices {
+		name := core.StringNilMapper(candidate.Name)
+		if core.StringNilMapper(candidate.Language) == language && (voice == "" || name < voice) {
+			voice = name
+		}
+	}
+	if voice == "" {
+		return "", fmt.Errorf("there is no voice for the language %q", language)
+	}
+	return voice, nil
+}
@@ -0,0 +1,337 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/workerpool"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1"
+)
+
+// The statuses of prompts
+const (
+	StatusProcessing = "processing"
+	StatusAvailable  = "available"
+	StatusFailed     = "failed"
+)
+
+// Options : Controls how Apply uploads prompts and waits for them
+type Options struct {
+	// How long to wait between requests that check whether the service has processed the prompts. Defaults to 5
+	// seconds.
+	PollInterval time.Duration
+
+	// The maximum number of prompts that are uploaded at the same time. Defaults to 4.
+	Workers int
+
+	// Uploads every prompt of the manifest, even those that the custom model has with the same text and speaker, as
+	// when their audio was recorded again.
+	Reupload bool
+
+	// Deletes the prompts that the custom model has but the manifest does not list.
+	Prune bool
+}
+
+func (options *Options) withDefaults() Options {
+	withDefaults := Options{}
+	if options != nil {
+		withDefaults = *options
+	}
+	if withDefaults.PollInterval <= 0 {
+		withDefaults.PollInterval = 5 * time.Second
+	}
+	if withDefaults.Workers <= 0 {
+		withDefaults.Workers = 4
+	}
+	return withDefaults
+}
+
+// pause : Waits for the poll interval or until the context is done
+func (options Options) pause(ctx context.Context) error {
+	timer := time.NewTimer(options.PollInterval)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Changes : What Apply changed
+type Changes struct {
+	// The speaker ID of the speaker of the manifest, or empty when it has none.
+	SpeakerID string
+
+	// Whether the speaker was enrolled.
+	SpeakerEnrolled bool
+
+	Uploaded []string
+	Deleted  []string
+
+	// The prompts of the manifest as the service has them after processing, in the order of the manifest.
+	Prompts []texttospeechv1.Prompt
+}
+
+// FailedPrompt : A prompt that the service failed to process
+type FailedPrompt struct {
+	ID string
+
+	// The reason that the service gave.
+	Reason string
+}
+
+// FailedPromptsError : The prompts that the service failed to process. Recording the audio again, splitting a long
+// prompt, or adding a custom word for a word that the service does not recognize can correct a failure.
+type FailedPromptsError struct {
+	Prompts []FailedPrompt
+}
+
+func (err *FailedPromptsError) Error() string {
+	var prompts []string
+	for _, prompt := range err.Prompts {
+		prompts = append(prompts, fmt.Sprintf("%s (%s)", prompt.ID, prompt.Reason))
+	}
+	return fmt.Sprintf("failed prompts: %s", strings.Join(prompts, ", "))
+}
+
+// Apply : Makes the custom model have the prompts of the manifest. The manifest is validated, and its speaker is
+// enrolled with CreateSpeakerModel unless the service instance has a speaker of that name. The prompts that the custom
+// model lacks, that have another text or speaker, or that the service failed to process are uploaded concurrently with
+// AddCustomPrompt, and Apply polls GetCustomPrompt until the service has processed every prompt of the manifest. The
+// prompts that failed are reported as a *FailedPromptsError with the changes. The changes made before any other error
+// are returned with it.
+func (manifest *Manifest) Apply(ctx context.Context, textToSpeech *texttospeechv1.TextToSpeechV1, customizationID string, options *Options) (*Changes, error) {
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+	workflow := &promptWorkflow{
+		service:         textToSpeech,
+		manifest:        manifest,
+		customizationID: customizationID,
+		options:         options.withDefaults(),
+		changes:         &Changes{},
+		prompts:         map[string]texttospeechv1.Prompt{},
+	}
+	if err := workflow.run(ctx); err != nil {
+		return workflow.changes, err
+	}
+	return workflow.changes, nil
+}
+
+type promptWorkflow struct {
+	service         *texttospeechv1.TextToSpeechV1
+	manifest        *Manifest
+	customizationID string
+	options         Options
+	changes         *Changes
+
+	// The prompts of the custom model, by ID
+	prompts map[string]texttospeechv1.Prompt
+}
+
+func (workflow *promptWorkflow) run(ctx context.Context) error {
+	if workflow.manifest.Speaker != nil {
+		if err := workflow.enroll(ctx); err != nil {
+			return err
+		}
+	}
+	if err := workflow.syncPrompts(ctx); err != nil {
+		return err
+	}
+	if err := workflow.waitForPrompts(ctx); err != nil {
+		return err
+	}
+
+	failed := &FailedPromptsError{}
+	for _, prompt := range workflow.manifest.Prompts {
+		current := workflow.prompts[prompt.ID]
+		workflow.changes.Prompts = append(workflow.changes.Prompts, current)
+		if core.StringNilMapper(current.Status) == StatusFailed {
+			reason := core.StringNilMapper(current.Error)
+			if reason == "" {
+				reason = "the service could not process the prompt"
+			}
+			failed.Prompts = append(failed.Prompts, FailedPrompt{ID: prompt.ID, Reason: reason})
+		}
+	}
+	if len(failed.Prompts) > 0 {
+		return failed
+	}
+	return nil
+}
+
+// enroll : Looks the speaker up by name, and creates a speaker model when there is none
+func (workflow *promptWorkflow) enroll(ctx context.Context) error {
+	service, speaker := workflow.service, workflow.manifest.Speaker
+	speakers, _, err := service.ListSpeakerModelsWithContext(ctx, service.NewListSpeakerModelsOptions())
+	if err != nil {
+		return fmt.Errorf("listing the speakers: %s", err)
+	}
+	for _, existing := range speakers.Speakers {
+		if core.StringNilMapper(existing.Name) == speaker.Name {
+			workflow.changes.SpeakerID = core.StringNilMapper(existing.SpeakerID)
+			return nil
+		}
+	}
+
+	audio, err := os.Open(workflow.manifest.path(speaker.Audio))
+	if err != nil {
+		return err
+	}
+	defer audio.Close()
+	model, _, err := service.CreateSpeakerModelWithContext(ctx, service.NewCreateSpeakerModelOptions(speaker.Name, audio))
+	if err != nil {
+		return fmt.Errorf("enrolling the speaker %q: %s", speaker.Name, err)
+	}
+	workflow.changes.SpeakerID = core.StringNilMapper(model.SpeakerID)
+	workflow.changes.SpeakerEnrolled = true
+	return nil
+}
+
+// syncPrompts : Deletes the prompts that the manifest does not list when pruning, and uploads the prompts that changed
+func (workflow *promptWorkflow) syncPrompts(ctx context.Context) error {
+	service, customizationID := workflow.service, workflow.customizationID
+	listed, _, err := service.ListCustomPromptsWithContext(ctx, service.NewListCustomPromptsOptions(customizationID))
+	if err != nil {
+		return fmt.Errorf("listing the prompts of custom model %s: %s", customizationID, err)
+	}
+	for _, prompt := range listed.Prompts {
+		workflow.prompts[core.StringNilMapper(prompt.PromptID)] = prompt
+	}
+
+	var uploads []Prompt
+	wanted := map[string]bool{}
+	for _, prompt := range workflow.manifest.Prompts {
+		wanted[prompt.ID] = true
+		current, ok := workflow.prompts[prompt.ID]
+		if ok && !workflow.options.Reupload && core.StringNilMapper(current.Prompt) == prompt.Text &&
+			core.StringNilMapper(current.SpeakerID) == workflow.changes.SpeakerID &&
+			core.StringNilMapper(current.Status) != StatusFailed {
+			continue
+		}
+		uploads = append(uploads, prompt)
+	}
+
+	if workflow.options.Prune {
+		var unlisted []string
+		for id := range workflow.prompts {
+			if !wanted[id] {
+				unlisted = append(unlisted, id)
+			}
+		}
+		sort.Strings(unlisted)
+		for _, id := range unlisted {
+			_, err = service.DeleteCustomPromptWithContext(ctx, service.NewDeleteCustomPromptOptions(customizationID, id))
+			if err != nil {
+				return fmt.Errorf("deleting the prompt %q from custom model %s: %s", id, customizationID, err)
+			}
+			delete(workflow.prompts, id)
+			workflow.changes.Deleted = append(workflow.changes.Deleted, id)
+		}
+	}
+	return workflow.upload(ctx, uploads)
+}
+
+// upload : Adds prompts to the custom model with up to Workers requests at the same time. The first failure cancels
+// the uploads that have not finished.
+func (workflow *promptWorkflow) upload(ctx context.Context, uploads []Prompt) error {
+	pool := workerpool.New(ctx, workflow.options.Workers)
+	uploaded := make([]*texttospeechv1.Prompt, len(uploads))
+	for i, prompt := range uploads {
+		if !pool.Acquire() {
+			break
+		}
+		i, prompt := i, prompt
+		pool.Go(func(ctx context.Context) error {
+			result, err := workflow.addPrompt(ctx, prompt)
+			if err != nil {
+				return fmt.Errorf("uploading the prompt %q: %s", prompt.ID, err)
+			}
+			uploaded[i] = result
+			return nil
+		})
+	}
+	err := pool.Wait()
+
+	for i, prompt := range uploads {
+		if uploaded[i] == nil {
+			continue
+		}
+		workflow.prompts[prompt.ID] = *uploaded[i]
+		workflow.changes.Uploaded = append(workflow.changes.Uploaded, prompt.ID)
+	}
+	return err
+}
+
+func (workflow *promptWorkflow) addPrompt(ctx context.Context, prompt Prompt) (*texttospeechv1.Prompt, error) {
+	service := workflow.service
+	metadata, err := service.NewPromptMetadata(prompt.Text)
+	if err != nil {
+		return nil, err
+	}
+	if workflow.changes.SpeakerID != "" {
+		metadata.SpeakerID = core.StringPtr(workflow.changes.SpeakerID)
+	}
+	audio, err := os.Open(workflow.manifest.audioPath(prompt))
+	if err != nil {
+		return nil, err
+	}
+	defer audio.Close()
+	result, _, err := service.AddCustomPromptWithContext(ctx,
+		service.NewAddCustomPromptOptions(workflow.customizationID, prompt.ID, metadata, audio))
+	if err != nil {
+		return nil, err
+	}
+	if result.Status == nil {
+		result.Status = core.StringPtr(StatusProcessing)
+	}
+	return result, nil
+}
+
+// waitForPrompts : Gets the prompts of the manifest that the service is processing until it has processed them all
+func (workflow *promptWorkflow) waitForPrompts(ctx context.Context) error {
+	service, customizationID := workflow.service, workflow.customizationID
+	for {
+		var processing []string
+		for _, prompt := range workflow.manifest.Prompts {
+			if core.StringNilMapper(workflow.prompts[prompt.ID].Status) == StatusProcessing {
+				processing = append(processing, prompt.ID)
+			}
+		}
+		if len(processing) == 0 {
+			return nil
+		}
+		if err := workflow.options.pause(ctx); err != nil {
+			return err
+		}
+		for _, id := range processing {
+			prompt, _, err := service.GetCustomPromptWithContext(ctx, service.NewGetCustomPromptOptions(customizationID, id))
+			if err != nil {
+				return fmt.Errorf("getting the prompt %q of custom model %s: %s", id, customizationID, err)
+			}
+			workflow.prompts[id] = *prompt
+		}
+	}
+}
@@ -0,0 +1,212 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package prompts enrolls a speaker and adds the custom prompts of a TextToSpeechV1 custom model as a manifest
+// describes them. Apply uploads the prompts that the custom model lacks or that changed, waits for the service to
+// process them, and reports the prompts that the service failed to process with the reason it gave. A prompt that is
+// available is spoken by the `<ibm:prompt>` element, which ssml.Prompt writes.
+//
+// A manifest is a JSON file next to the audio of the prompts:
+//
+//	{
+//	  "speaker": {"name": "alice", "audio": "alice.wav"},
+//	  "prompts": [
+//	    {"id": "goodbye", "text": "Thank you and good-bye!"},
+//	    {"id": "hold", "text": "Please hold.", "audio": "recordings/hold_v2.wav"}
+//	  ]
+//	}
+//
+// For example:
+//
+//	manifest, err := prompts.ReadManifestFile("prompts/manifest.json")
+//	...
+//	changes, err := manifest.Apply(ctx, textToSpeech, customizationID, nil)
+//	...
+//	text := ssml.New().Text("Your order has shipped.").Prompt("goodbye").String()
+package prompts
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/validation"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/audioutil"
+)
+
+// The limits of speakers and prompts
+const (
+	maxNameLength       = 49
+	maxPromptTextLength = 1000
+	minAudioRate        = 16000
+	maxEnrollmentAudio  = time.Minute
+	maxPromptAudio      = 30 * time.Second
+)
+
+// name : The characters that speaker names and prompt IDs can contain
+var name = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Manifest : A speaker and the prompts of a custom model
+type Manifest struct {
+	// The speaker of the prompts, which is enrolled if the service instance has no speaker of that name. Nil adds the
+	// prompts without a speaker.
+	Speaker *Speaker `json:"speaker,omitempty"`
+
+	Prompts []Prompt `json:"prompts"`
+
+	// The directory that relative audio paths are in. ReadManifestFile sets it to the directory of the manifest.
+	Dir string `json:"-"`
+}
+
+// Speaker : A speaker to enroll with a sample of their voice
+type Speaker struct {
+	// The name of the speaker, of at most 49 letters, digits and underscores.
+	Name string `json:"name"`
+
+	// The path of the enrollment audio: a WAV file of at most 1 minute, sampled at 16 kHz or more.
+	Audio string `json:"audio"`
+}
+
+// Prompt : A prompt of a custom model
+type Prompt struct {
+	// The ID of the prompt, of at most 49 letters, digits and underscores.
+	ID string `json:"id"`
+
+	// The text that the audio speaks, of at most 1000 characters. XML control characters must be escaped.
+	Text string `json:"text"`
+
+	// The path of the audio: a WAV file of at most 30 seconds, sampled at 16 kHz or more. Defaults to the ID with the
+	// `.wav` extension.
+	Audio string `json:"audio,omitempty"`
+}
+
+// ReadManifest : Reads a manifest in JSON whose relative audio paths are in dir
+func ReadManifest(reader io.Reader, dir string) (*Manifest, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	manifest := &Manifest{}
+	if err := decoder.Decode(manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %s", err)
+	}
+	manifest.Dir = dir
+	return manifest, nil
+}
+
+// ReadManifestFile : Reads the manifest at path, whose relative audio paths are in the directory of the manifest
+func ReadManifestFile(path string) (*Manifest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadManifest(file, filepath.Dir(path))
+}
+
+// path : Returns the path of an audio file of the manifest
+func (manifest *Manifest) path(audio string) string {
+	if filepath.IsAbs(audio) {
+		return audio
+	}
+	return filepath.Join(manifest.Dir, audio)
+}
+
+// audioPath : Returns the path of the audio of a prompt
+func (manifest *Manifest) audioPath(prompt Prompt) string {
+	if prompt.Audio == "" {
+		return manifest.path(prompt.ID + ".wav")
+	}
+	return manifest.path(prompt.Audio)
+}
+
+// ValidationError : The problems that Validate found in a manifest
+type ValidationError = validation.Error
+
+// Validate : Checks that the service can accept the speaker and the prompts of the manifest: names and IDs are valid
+// and unique, every prompt has text of at most 1000 characters, and every audio file is WAV audio sampled at 16 kHz or
+// more that is not longer than the service allows. It returns a *ValidationError that lists every problem found.
+func (manifest *Manifest) Validate() error {
+	var problems validation.Problems
+	if speaker := manifest.Speaker; speaker != nil {
+		if reason := checkName(speaker.Name); reason != "" {
+			problems.Add("the speaker name %q %s", speaker.Name, reason)
+		}
+		if speaker.Audio == "" {
+			problems.Add("the speaker %q has no audio", speaker.Name)
+		} else if reason := checkAudio(manifest.path(speaker.Audio), maxEnrollmentAudio); reason != "" {
+			problems.Add("the audio of the speaker %q %s", speaker.Name, reason)
+		}
+	}
+
+	listed := map[string]bool{}
+	for _, prompt := range manifest.Prompts {
+		if reason := checkName(prompt.ID); reason != "" {
+			problems.Add("the prompt ID %q %s", prompt.ID, reason)
+		} else if listed[prompt.ID] {
+			problems.Add("the prompt %q is listed more than once", prompt.ID)
+		}
+		listed[prompt.ID] = true
+
+		switch {
+		case strings.TrimSpace(prompt.Text) == "":
+			problems.Add("the prompt %q has no text", prompt.ID)
+		case utf8.RuneCountInString(prompt.Text) > maxPromptTextLength:
+			problems.Add("the text of the prompt %q is longer than %d characters", prompt.ID, maxPromptTextLength)
+		}
+		if reason := checkAudio(manifest.audioPath(prompt), maxPromptAudio); reason != "" {
+			problems.Add("the audio of the prompt %q %s", prompt.ID, reason)
+		}
+	}
+	return problems.Err("manifest")
+}
+
+// checkName : Returns why a speaker name or prompt ID is invalid, or an empty string
+func checkName(value string) string {
+	switch {
+	case value == "":
+		return "is empty"
+	case len(value) > maxNameLength:
+		return fmt.Sprintf("is longer than %d characters", maxNameLength)
+	case !name.MatchString(value):
+		return "can contain only letters, digits and underscores"
+	}
+	return ""
+}
+
+// checkAudio : Returns why the audio file at path cannot be uploaded, or an empty string
+func checkAudio(path string, maxDuration time.Duration) string {
+	audio, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Sprintf("cannot be read: %s", err)
+	}
+	wav, err := audioutil.ParseWav(audio)
+	if err != nil {
+		return fmt.Sprintf("in %s is not valid: %s", path, err)
+	}
+	if wav.Format.Rate < minAudioRate {
+		return fmt.Sprintf("in %s is sampled at %d Hz; use at least %d Hz", path, wav.Format.Rate, minAudioRate)
+	}
+	if duration := wav.Format.Duration(int64(len(wav.Data))); duration > maxDuration {
+		return fmt.Sprintf("in %s is %s long; use at most %s", path, duration.Round(time.Millisecond), maxDuration)
+	}
+	return ""
+}
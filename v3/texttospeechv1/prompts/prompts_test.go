@@ -0,0 +1,301 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package prompts_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/fakeservice"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/audioutil"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/prompts"
+)
+
+// writeWav : Writes a silent 16-bit mono WAV file of the duration at the rate
+func writeWav(path string, rate int, duration time.Duration) {
+	format := audioutil.RawFormat{Encoding: audioutil.EncodingPCM, Rate: rate, Channels: 1, BitsPerSample: 16}
+	var wav bytes.Buffer
+	_, err := audioutil.WriteWav(&wav, format, make([]byte, int(duration.Seconds()*float64(format.ByteRate()))))
+	Expect(err).To(BeNil())
+	Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+	Expect(ioutil.WriteFile(path, wav.Bytes(), 0644)).To(Succeed())
+}
+
+const greetingsManifest = `{
+  "speaker": {"name": "alice", "audio": "alice.wav"},
+  "prompts": [
+    {"id": "hello", "text": "Hello!"},
+    {"id": "goodbye", "text": "Thank you and good-bye!"},
+    {"id": "hold", "text": "Please hold.", "audio": "takes/hold_2.wav"}
+  ]
+}`
+
+// promptService : A fake service instance with speakers and a custom model with prompts, which records the requests
+// that change them
+type promptService struct {
+	*fakeservice.Server
+	speakers []texttospeechv1.Speaker
+	prompts  map[string]texttospeechv1.Prompt
+	requests []string
+
+	// The reasons that prompts fail for, by prompt ID.
+	failures map[string]string
+}
+
+func newPromptService() *promptService {
+	service := &promptService{
+		Server:   fakeservice.NewServer(),
+		prompts:  map[string]texttospeechv1.Prompt{},
+		failures: map[string]string{},
+	}
+	service.Handle(http.MethodGet, "/v1/speakers", func(call *fakeservice.Call) {
+		call.JSON(http.StatusOK, map[string]interface{}{"speakers": service.speakers})
+	})
+	service.Handle(http.MethodPost, "/v1/speakers", func(call *fakeservice.Call) {
+		name := call.URL.Query().Get("speaker_name")
+		audio, err := ioutil.ReadAll(call.Body)
+		Expect(err).To(BeNil())
+		Expect(string(audio[:4])).To(Equal("RIFF"))
+		service.requests = append(service.requests, "enroll "+name)
+		service.speakers = append(service.speakers, texttospeechv1.Speaker{
+			SpeakerID: core.StringPtr("spk-" + name), Name: core.StringPtr(name),
+		})
+		call.JSON(http.StatusOK, fmt.Sprintf(`{"speaker_id": "spk-%s"}`, name))
+	})
+	service.Handle(http.MethodGet, "/v1/customizations/cust-1/prompts", func(call *fakeservice.Call) {
+		var listed []texttospeechv1.Prompt
+		for _, prompt := range service.prompts {
+			listed = append(listed, prompt)
+		}
+		call.JSON(http.StatusOK, map[string]interface{}{"prompts": listed})
+	})
+	service.Handle(http.MethodPost, "/v1/customizations/cust-1/prompts/{id}", func(call *fakeservice.Call) {
+		id := call.Params["id"]
+		Expect(call.ParseMultipartForm(1 << 20)).To(Succeed())
+		var metadata texttospeechv1.PromptMetadata
+		Expect(json.Unmarshal([]byte(call.FormValue("metadata")), &metadata)).To(Succeed())
+		Expect(call.MultipartForm.File["file"]).To(HaveLen(1))
+		service.requests = append(service.requests, fmt.Sprintf("add %s %s %s", id, *metadata.PromptText,
+			core.StringNilMapper(metadata.SpeakerID)))
+		prompt := texttospeechv1.Prompt{
+			PromptID: core.StringPtr(id), Prompt: metadata.PromptText, SpeakerID: metadata.SpeakerID,
+			Status: core.StringPtr(prompts.StatusProcessing),
+		}
+		service.prompts[id] = prompt
+		call.JSON(http.StatusOK, prompt)
+	})
+	service.Handle(http.MethodGet, "/v1/customizations/cust-1/prompts/{id}", func(call *fakeservice.Call) {
+		// The service processes a prompt by the time it is requested
+		id := call.Params["id"]
+		prompt := service.prompts[id]
+		prompt.Status = core.StringPtr(prompts.StatusAvailable)
+		if reason, ok := service.failures[id]; ok {
+			prompt.Status, prompt.Error = core.StringPtr(prompts.StatusFailed), core.StringPtr(reason)
+		}
+		service.prompts[id] = prompt
+		service.requests = append(service.requests, "get "+id)
+		call.JSON(http.StatusOK, prompt)
+	})
+	service.Handle(http.MethodDelete, "/v1/customizations/cust-1/prompts/{id}", func(call *fakeservice.Call) {
+		id := call.Params["id"]
+		delete(service.prompts, id)
+		service.requests = append(service.requests, "delete "+id)
+		call.Response.WriteHeader(http.StatusNoContent)
+	})
+	return service
+}
+
+var _ = Describe(`Prompts`, func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "prompts")
+		Expect(err).To(BeNil())
+		Expect(ioutil.WriteFile(filepath.Join(dir, "manifest.json"), []byte(greetingsManifest), 0644)).To(Succeed())
+		writeWav(filepath.Join(dir, "alice.wav"), 22050, 20*time.Second)
+		writeWav(filepath.Join(dir, "hello.wav"), 16000, time.Second)
+		writeWav(filepath.Join(dir, "goodbye.wav"), 16000, 2*time.Second)
+		writeWav(filepath.Join(dir, "takes", "hold_2.wav"), 44100, time.Second)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It(`Reads manifests with audio relative to the manifest`, func() {
+		manifest, err := prompts.ReadManifestFile(filepath.Join(dir, "manifest.json"))
+		Expect(err).To(BeNil())
+		Expect(manifest).To(Equal(&prompts.Manifest{
+			Speaker: &prompts.Speaker{Name: "alice", Audio: "alice.wav"},
+			Prompts: []prompts.Prompt{
+				{ID: "hello", Text: "Hello!"},
+				{ID: "goodbye", Text: "Thank you and good-bye!"},
+				{ID: "hold", Text: "Please hold.", Audio: "takes/hold_2.wav"},
+			},
+			Dir: dir,
+		}))
+		Expect(manifest.Validate()).To(Succeed())
+
+		_, err = prompts.ReadManifest(strings.NewReader(`{"prompts": [{"id": "hello", "txt": "Hello!"}]}`), dir)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal(`invalid manifest: json: unknown field "txt"`))
+	})
+
+	It(`Validate reports what the service would reject`, func() {
+		writeWav(filepath.Join(dir, "low.wav"), 8000, time.Second)
+		writeWav(filepath.Join(dir, "long.wav"), 16000, 31*time.Second)
+		Expect(ioutil.WriteFile(filepath.Join(dir, "text.wav"), []byte("hello"), 0644)).To(Succeed())
+		manifest := &prompts.Manifest{
+			Speaker: &prompts.Speaker{Name: "alice smith"},
+			Prompts: []prompts.Prompt{
+				{ID: "hello", Text: "Hello!"},
+				{ID: "hello", Text: "Hi!"},
+				{ID: "good-bye", Text: " ", Audio: "goodbye.wav"},
+				{ID: "low", Text: "Low"},
+				{ID: "long", Text: strings.Repeat("a", 1001)},
+				{ID: "text", Text: "Text"},
+			},
+			Dir: dir,
+		}
+		err := manifest.Validate()
+		Expect(err).To(BeAssignableToTypeOf(&prompts.ValidationError{}))
+		Expect(err.(*prompts.ValidationError).Problems).To(Equal([]string{
+			`the speaker name "alice smith" can contain only letters, digits and underscores`,
+			`the speaker "alice smith" has no audio`,
+			`the prompt "hello" is listed more than once`,
+			`the prompt ID "good-bye" can contain only letters, digits and underscores`,
+			`the prompt "good-bye" has no text`,
+			fmt.Sprintf(`the audio of the prompt "low" in %s is sampled at 8000 Hz; use at least 16000 Hz`,
+				filepath.Join(dir, "low.wav")),
+			`the text of the prompt "long" is longer than 1000 characters`,
+			fmt.Sprintf(`the audio of the prompt "long" in %s is 31s long; use at most 30s`, filepath.Join(dir, "long.wav")),
+			fmt.Sprintf(`the audio of the prompt "text" in %s is not valid: the audio is not WAV audio`,
+				filepath.Join(dir, "text.wav")),
+		}))
+	})
+
+	Describe(`Apply`, func() {
+		var service *promptService
+		var textToSpeech *texttospeechv1.TextToSpeechV1
+		var manifest *prompts.Manifest
+		options := &prompts.Options{PollInterval: time.Millisecond, Prune: true}
+
+		BeforeEach(func() {
+			service = newPromptService()
+			service.speakers = []texttospeechv1.Speaker{{SpeakerID: core.StringPtr("spk-bob"), Name: core.StringPtr("bob")}}
+			service.prompts = map[string]texttospeechv1.Prompt{
+				"hello": {
+					PromptID: core.StringPtr("hello"), Prompt: core.StringPtr("Hello!"),
+					Status: core.StringPtr(prompts.StatusAvailable), SpeakerID: core.StringPtr("spk-alice"),
+				},
+				"welcome": {
+					PromptID: core.StringPtr("welcome"), Prompt: core.StringPtr("Welcome!"),
+					Status: core.StringPtr(prompts.StatusAvailable),
+				},
+			}
+			var err error
+			textToSpeech, err = texttospeechv1.NewTextToSpeechV1(&texttospeechv1.TextToSpeechV1Options{
+				URL:           service.URL,
+				Authenticator: &core.NoAuthAuthenticator{},
+			})
+			Expect(err).To(BeNil())
+			manifest, err = prompts.ReadManifestFile(filepath.Join(dir, "manifest.json"))
+			Expect(err).To(BeNil())
+		})
+
+		AfterEach(func() {
+			service.Close()
+		})
+
+		It(`Enrolls the speaker, uploads the prompts that changed and waits for them`, func() {
+			changes, err := manifest.Apply(context.Background(), textToSpeech, "cust-1", options)
+			Expect(err).To(BeNil())
+			Expect(changes.SpeakerID).To(Equal("spk-alice"))
+			Expect(changes.SpeakerEnrolled).To(BeTrue())
+			Expect(changes.Uploaded).To(Equal([]string{"goodbye", "hold"}))
+			Expect(changes.Deleted).To(Equal([]string{"welcome"}))
+			Expect(changes.Prompts).To(HaveLen(3))
+			for _, prompt := range changes.Prompts {
+				Expect(*prompt.Status).To(Equal(prompts.StatusAvailable))
+			}
+
+			Expect(service.requests[:2]).To(Equal([]string{"enroll alice", "delete welcome"}))
+			Expect(service.requests[2:4]).To(ConsistOf(
+				"add goodbye Thank you and good-bye! spk-alice",
+				"add hold Please hold. spk-alice",
+			))
+			Expect(service.requests[4:]).To(Equal([]string{"get goodbye", "get hold"}))
+
+			service.requests = nil
+			changes, err = manifest.Apply(context.Background(), textToSpeech, "cust-1", options)
+			Expect(err).To(BeNil())
+			Expect(changes.SpeakerEnrolled).To(BeFalse())
+			Expect(changes.Uploaded).To(BeEmpty())
+			Expect(service.requests).To(BeEmpty())
+		})
+
+		It(`Reports the prompts that failed with their reasons and uploads them again`, func() {
+			manifest.Speaker = nil
+			service.failures["goodbye"] = "The audio does not match the text"
+			service.failures["hold"] = ""
+			changes, err := manifest.Apply(context.Background(), textToSpeech, "cust-1",
+				&prompts.Options{PollInterval: time.Millisecond, Workers: 1})
+			Expect(err).To(BeAssignableToTypeOf(&prompts.FailedPromptsError{}))
+			Expect(err.Error()).To(Equal("failed prompts: goodbye (The audio does not match the text), " +
+				"hold (the service could not process the prompt)"))
+			Expect(changes.SpeakerID).To(BeEmpty())
+			Expect(changes.Uploaded).To(Equal([]string{"hello", "goodbye", "hold"}))
+			Expect(changes.Deleted).To(BeEmpty())
+			Expect(service.requests).To(ContainElement("add hello Hello! "))
+
+			delete(service.failures, "hold")
+			service.requests = nil
+			_, err = manifest.Apply(context.Background(), textToSpeech, "cust-1", &prompts.Options{PollInterval: time.Millisecond})
+			Expect(err).ToNot(BeNil())
+			Expect(err.(*prompts.FailedPromptsError).Prompts).To(Equal([]prompts.FailedPrompt{
+				{ID: "goodbye", Reason: "The audio does not match the text"},
+			}))
+			requests := append([]string{}, service.requests...)
+			sort.Strings(requests)
+			Expect(requests).To(Equal([]string{
+				"add goodbye Thank you and good-bye! ", "add hold Please hold. ", "get goodbye", "get hold",
+			}))
+		})
+
+		It(`Validates the manifest before any request`, func() {
+			manifest.Prompts[1].Audio = "missing.wav"
+			_, err := manifest.Apply(context.Background(), textToSpeech, "cust-1", nil)
+			Expect(err).To(BeAssignableToTypeOf(&prompts.ValidationError{}))
+			Expect(err.Error()).To(HavePrefix(`invalid manifest: the audio of the prompt "goodbye" cannot be read: `))
+			Expect(service.requests).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,230 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ssml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/validation"
+)
+
+// The namespace of the xml:lang attribute
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// Parse : Reads the text of SynthesizeOptions, either a `<speak>` document or text with SSML elements in it, so that it
+// can be validated. Elements and attributes that the service does not support are errors.
+func Parse(text string) (*Document, error) {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "<speak") && !strings.HasPrefix(trimmed, "<?xml") {
+		text = "<speak>" + text + "</speak>"
+	}
+	parser := &parser{decoder: xml.NewDecoder(strings.NewReader(text))}
+	document, err := parser.parseDocument()
+	if err != nil {
+		return nil, fmt.Errorf("invalid SSML: %s", err)
+	}
+	return document, nil
+}
+
+type parser struct {
+	decoder *xml.Decoder
+}
+
+func (parser *parser) parseDocument() (*Document, error) {
+	for {
+		token, err := parser.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local != "speak" {
+			return nil, fmt.Errorf("the root element must be speak, not %s", start.Name.Local)
+		}
+		attributes, err := readAttributes(start, "version")
+		if err != nil {
+			return nil, err
+		}
+		document := &Document{Language: attributes["lang"]}
+		if document.Content, err = parser.parseContent(); err != nil {
+			return nil, err
+		}
+		return document, nil
+	}
+}
+
+// parseContent : Reads nodes until the end of the current element
+func (parser *parser) parseContent() ([]Node, error) {
+	var nodes []Node
+	for {
+		token, err := parser.decoder.Token()
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.CharData:
+			nodes = append(nodes, Text(token))
+		case xml.EndElement:
+			return nodes, nil
+		case xml.StartElement:
+			node, err := parser.parseElement(token)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+		}
+	}
+}
+
+// parseText : Reads the text of an element that can contain only text
+func (parser *parser) parseText(start xml.StartElement) (string, error) {
+	var text strings.Builder
+	for {
+		token, err := parser.decoder.Token()
+		if err == io.EOF {
+			return "", io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return "", err
+		}
+		switch token := token.(type) {
+		case xml.CharData:
+			text.Write(token)
+		case xml.EndElement:
+			return text.String(), nil
+		case xml.StartElement:
+			return "", fmt.Errorf("the %s element can contain only text, not a %s element", start.Name.Local, token.Name.Local)
+		}
+	}
+}
+
+func (parser *parser) parseElement(start xml.StartElement) (Node, error) {
+	var allowed []string
+	switch start.Name.Local {
+	case "break":
+		allowed = []string{"strength", "time"}
+	case "emphasis":
+		allowed = []string{"level"}
+	case "prosody":
+		allowed = []string{"pitch", "rate"}
+	case "say-as":
+		allowed = []string{"interpret-as", "format"}
+	case "phoneme":
+		allowed = []string{"alphabet", "ph"}
+	case "sub":
+		allowed = []string{"alias"}
+	case "mark":
+		allowed = []string{"name"}
+	case "express-as":
+		allowed = []string{"style"}
+	case "p", "paragraph", "s", "sentence":
+	case "voice-transformation":
+		allowed = []string{"type", "pitch", "pitch_range", "glottal_tension", "breathiness", "rate", "timbre", "timbre_extent"}
+	case "prompt":
+		allowed = []string{"id"}
+	default:
+		return nil, fmt.Errorf("the %s element is not supported", start.Name.Local)
+	}
+	attributes, err := readAttributes(start, allowed...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch start.Name.Local {
+	case "break", "mark", "prompt":
+		text, err := parser.parseText(start)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(text) != "" {
+			return nil, fmt.Errorf("the %s element cannot contain text", start.Name.Local)
+		}
+		switch start.Name.Local {
+		case "mark":
+			return Mark{Name: attributes["name"]}, nil
+		case "prompt":
+			return Prompt{ID: attributes["id"]}, nil
+		}
+		return Break{Strength: attributes["strength"], Time: attributes["time"]}, nil
+	case "say-as", "phoneme", "sub":
+		text, err := parser.parseText(start)
+		if err != nil {
+			return nil, err
+		}
+		switch start.Name.Local {
+		case "say-as":
+			return SayAs{InterpretAs: attributes["interpret-as"], Format: attributes["format"], Text: text}, nil
+		case "phoneme":
+			return Phoneme{Alphabet: attributes["alphabet"], PH: attributes["ph"], Text: text}, nil
+		}
+		return Sub{Alias: attributes["alias"], Text: text}, nil
+	}
+
+	content, err := parser.parseContent()
+	if err != nil {
+		return nil, err
+	}
+	switch start.Name.Local {
+	case "emphasis":
+		return Emphasis{Level: attributes["level"], Content: content}, nil
+	case "prosody":
+		return Prosody{Pitch: attributes["pitch"], Rate: attributes["rate"], Content: content}, nil
+	case "express-as":
+		return ExpressAs{Style: attributes["style"], Content: content}, nil
+	case "p", "paragraph":
+		return Paragraph{Content: content}, nil
+	case "s", "sentence":
+		return Sentence{Content: content}, nil
+	}
+	return VoiceTransformation{
+		Type:           attributes["type"],
+		Pitch:          attributes["pitch"],
+		PitchRange:     attributes["pitch_range"],
+		GlottalTension: attributes["glottal_tension"],
+		Breathiness:    attributes["breathiness"],
+		Rate:           attributes["rate"],
+		Timbre:         attributes["timbre"],
+		TimbreExtent:   attributes["timbre_extent"],
+		Content:        content,
+	}, nil
+}
+
+// readAttributes : Returns the attributes of an element by their local name. Namespace declarations and xml:lang are
+// accepted on every element, other attributes only when they are allowed.
+func readAttributes(start xml.StartElement, allowed ...string) (map[string]string, error) {
+	attributes := map[string]string{}
+	for _, attribute := range start.Attr {
+		switch {
+		case attribute.Name.Space == "xmlns" || (attribute.Name.Space == "" && attribute.Name.Local == "xmlns"):
+			continue
+		case attribute.Name.Space == xmlNamespace && attribute.Name.Local == "lang":
+		case attribute.Name.Space == "" && validation.Contains(allowed, attribute.Name.Local):
+		default:
+			return nil, fmt.Errorf("the %s element does not support the %s attribute", start.Name.Local, attribute.Name.Local)
+		}
+		attributes[attribute.Name.Local] = attribute.Value
+	}
+	return attributes, nil
+}
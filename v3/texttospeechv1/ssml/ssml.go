@@ -0,0 +1,334 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ssml builds, reads and validates the SSML documents that TextToSpeechV1 synthesizes. Text added to a document
+// is escaped when the document is written, and Validate reports the elements and attributes that the service would
+// reject for a voice, such as prosody on an expressive voice or marks outside of websocket synthesis.
+//
+// For example:
+//
+//	document := ssml.New().
+//		Text("Your order number is ").
+//		Add(ssml.SayAs{InterpretAs: "digits", Text: "4031"}).
+//		Break("500ms").
+//		Add(ssml.ExpressAs{Style: ssml.StyleCheerful, Content: []ssml.Node{ssml.Text("Thanks for shopping with us!")}})
+//	if err := document.Validate(voice, false); err != nil {
+//		...
+//	}
+//	synthesizeOptions := textToSpeech.NewSynthesizeOptions(document.String())
+package ssml
+
+import (
+	"strings"
+)
+
+// The alphabets of phonemes
+const (
+	AlphabetIPA = "ipa"
+
+	// The IBM Symbolic Phonetic Representation (SPR).
+	AlphabetSPR = "ibm"
+)
+
+// The strengths of breaks
+const (
+	StrengthNone    = "none"
+	StrengthXWeak   = "x-weak"
+	StrengthWeak    = "weak"
+	StrengthMedium  = "medium"
+	StrengthStrong  = "strong"
+	StrengthXStrong = "x-strong"
+)
+
+// The levels of emphasis
+const (
+	LevelStrong   = "strong"
+	LevelModerate = "moderate"
+	LevelNone     = "none"
+	LevelReduced  = "reduced"
+)
+
+// The speaking styles of expressive voices
+const (
+	StyleCheerful   = "cheerful"
+	StyleEmpathetic = "empathetic"
+	StyleNeutral    = "neutral"
+	StyleUncertain  = "uncertain"
+)
+
+// Document : An SSML document, the `<speak>` element and its content
+type Document struct {
+	// The language of the document, such as `en-US`. Empty leaves it to the voice.
+	Language string
+
+	Content []Node
+}
+
+// Node : Text or an element of a document
+type Node interface {
+	isNode()
+}
+
+// Text : Text to speak. It is escaped when the document is written.
+type Text string
+
+// Break : A pause
+type Break struct {
+	// One of the Strength constants, or empty.
+	Strength string
+
+	// The length of the pause, such as `500ms` or `2s`, or empty.
+	Time string
+}
+
+// Emphasis : Content spoken with more or less stress
+type Emphasis struct {
+	// One of the Level constants, or empty for moderate emphasis.
+	Level string
+
+	Content []Node
+}
+
+// Prosody : Content spoken at another pitch or rate
+type Prosody struct {
+	// A keyword from `x-low` to `x-high`, `default`, or a value such as `150Hz`, `+10%` or `-2st`.
+	Pitch string
+
+	// A keyword from `x-slow` to `x-fast`, `default`, a relative value such as `-20%`, or words per minute.
+	Rate string
+
+	Content []Node
+}
+
+// SayAs : Text spoken as a particular type of value, such as digits, a date or an interjection
+type SayAs struct {
+	InterpretAs string
+
+	// The format of the value, such as `mdy` for a date, or empty.
+	Format string
+
+	Text string
+}
+
+// Phoneme : Text spoken with a given pronunciation
+type Phoneme struct {
+	// AlphabetIPA or AlphabetSPR.
+	Alphabet string
+
+	// The pronunciation in the alphabet.
+	PH string
+
+	Text string
+}
+
+// Sub : Text spoken as an alias, such as an abbreviation spoken in full
+type Sub struct {
+	Alias string
+	Text  string
+}
+
+// Mark : A named point of the text whose time the service returns. Marks are returned only by websocket synthesis.
+type Mark struct {
+	Name string
+}
+
+// ExpressAs : Content spoken in a style. Only expressive voices support it.
+type ExpressAs struct {
+	// One of the Style constants.
+	Style string
+
+	Content []Node
+}
+
+// Paragraph : A paragraph of content
+type Paragraph struct {
+	Content []Node
+}
+
+// Sentence : A sentence of content
+type Sentence struct {
+	Content []Node
+}
+
+// VoiceTransformation : Content spoken by a transformed voice. Only voices whose SupportedFeatures include voice
+// transformation support it.
+type VoiceTransformation struct {
+	// `Young`, `Soft` or `Custom`. The other fields apply to `Custom` transformations only.
+	Type string
+
+	Pitch          string
+	PitchRange     string
+	GlottalTension string
+	Breathiness    string
+	Rate           string
+	Timbre         string
+	TimbreExtent   string
+
+	Content []Node
+}
+
+// Prompt : A custom prompt of the custom model that synthesizes the document, written as `<ibm:prompt id="..."/>`. Only
+// US English voices support prompts.
+type Prompt struct {
+	ID string
+}
+
+func (Text) isNode()                {}
+func (Break) isNode()               {}
+func (Emphasis) isNode()            {}
+func (Prosody) isNode()             {}
+func (SayAs) isNode()               {}
+func (Phoneme) isNode()             {}
+func (Sub) isNode()                 {}
+func (Mark) isNode()                {}
+func (ExpressAs) isNode()           {}
+func (Paragraph) isNode()           {}
+func (Sentence) isNode()            {}
+func (VoiceTransformation) isNode() {}
+func (Prompt) isNode()              {}
+
+// New : Creates an empty document
+func New() *Document {
+	return &Document{}
+}
+
+// Add : Appends nodes to the document
+func (document *Document) Add(nodes ...Node) *Document {
+	document.Content = append(document.Content, nodes...)
+	return document
+}
+
+// Text : Appends text to the document
+func (document *Document) Text(text string) *Document {
+	return document.Add(Text(text))
+}
+
+// Break : Appends a pause of the given length, such as `500ms`
+func (document *Document) Break(time string) *Document {
+	return document.Add(Break{Time: time})
+}
+
+// Mark : Appends a mark
+func (document *Document) Mark(name string) *Document {
+	return document.Add(Mark{Name: name})
+}
+
+// Prompt : Appends a custom prompt
+func (document *Document) Prompt(id string) *Document {
+	return document.Add(Prompt{ID: id})
+}
+
+// String : Writes the document as the text of SynthesizeOptions
+func (document *Document) String() string {
+	writer := &writer{}
+	writer.start("speak", "version", "1.0", "xml:lang", document.Language)
+	writer.content(document.Content)
+	writer.end("speak")
+	return writer.String()
+}
+
+type writer struct {
+	strings.Builder
+}
+
+// start : Writes a start tag with the attributes, given as name and value pairs, whose values are not empty
+func (writer *writer) start(name string, attributes ...string) {
+	writer.WriteString("<" + name)
+	writer.attributes(attributes)
+	writer.WriteString(">")
+}
+
+func (writer *writer) end(name string) {
+	writer.WriteString("</" + name + ">")
+}
+
+// empty : Writes an element without content
+func (writer *writer) empty(name string, attributes ...string) {
+	writer.WriteString("<" + name)
+	writer.attributes(attributes)
+	writer.WriteString("/>")
+}
+
+// text : Writes an element whose content is text
+func (writer *writer) text(name string, text string, attributes ...string) {
+	writer.start(name, attributes...)
+	writer.WriteString(textEscaper.Replace(text))
+	writer.end(name)
+}
+
+func (writer *writer) attributes(attributes []string) {
+	for i := 0; i+1 < len(attributes); i += 2 {
+		if attributes[i+1] != "" {
+			writer.WriteString(" " + attributes[i] + `="` + attributeEscaper.Replace(attributes[i+1]) + `"`)
+		}
+	}
+}
+
+var (
+	textEscaper      = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	attributeEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+func (writer *writer) content(nodes []Node) {
+	for _, node := range nodes {
+		writer.node(node)
+	}
+}
+
+func (writer *writer) node(node Node) {
+	switch node := node.(type) {
+	case Text:
+		writer.WriteString(textEscaper.Replace(string(node)))
+	case Break:
+		writer.empty("break", "strength", node.Strength, "time", node.Time)
+	case Emphasis:
+		writer.start("emphasis", "level", node.Level)
+		writer.content(node.Content)
+		writer.end("emphasis")
+	case Prosody:
+		writer.start("prosody", "pitch", node.Pitch, "rate", node.Rate)
+		writer.content(node.Content)
+		writer.end("prosody")
+	case SayAs:
+		writer.text("say-as", node.Text, "interpret-as", node.InterpretAs, "format", node.Format)
+	case Phoneme:
+		writer.text("phoneme", node.Text, "alphabet", node.Alphabet, "ph", node.PH)
+	case Sub:
+		writer.text("sub", node.Text, "alias", node.Alias)
+	case Mark:
+		writer.empty("mark", "name", node.Name)
+	case ExpressAs:
+		writer.start("express-as", "style", node.Style)
+		writer.content(node.Content)
+		writer.end("express-as")
+	case Paragraph:
+		writer.start("p")
+		writer.content(node.Content)
+		writer.end("p")
+	case Sentence:
+		writer.start("s")
+		writer.content(node.Content)
+		writer.end("s")
+	case VoiceTransformation:
+		writer.start("voice-transformation", "type", node.Type, "pitch", node.Pitch, "pitch_range", node.PitchRange,
+			"glottal_tension", node.GlottalTension, "breathiness", node.Breathiness, "rate", node.Rate, "timbre", node.Timbre,
+			"timbre_extent", node.TimbreExtent)
+		writer.content(node.Content)
+		writer.end("voice-transformation")
+	case Prompt:
+		writer.empty("ibm:prompt", "id", node.ID)
+	}
+}
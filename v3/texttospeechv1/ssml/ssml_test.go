@@ -0,0 +1,236 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ssml_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/ssml"
+)
+
+func voice(name string, voiceTransformation bool) *texttospeechv1.Voice {
+	return &texttospeechv1.Voice{
+		Name:     core.StringPtr(name),
+		Language: core.StringPtr(name[:5]),
+		SupportedFeatures: &texttospeechv1.SupportedFeatures{
+			CustomPronunciation: core.BoolPtr(true),
+			VoiceTransformation: core.BoolPtr(voiceTransformation),
+		},
+	}
+}
+
+var _ = Describe(`SSML`, func() {
+	order := func() *ssml.Document {
+		return ssml.New().
+			Text("Order <1> & ").
+			Add(ssml.SayAs{InterpretAs: "digits", Text: "4031"}).
+			Break("500ms").
+			Add(ssml.Sub{Alias: "World Wide Web Consortium", Text: "W3C"}).
+			Mark("total").
+			Add(ssml.ExpressAs{Style: ssml.StyleCheerful, Content: []ssml.Node{
+				ssml.Text("Thanks, "),
+				ssml.Phoneme{Alphabet: ssml.AlphabetIPA, PH: `təˈmɑtoʊ "x"`, Text: "tomato"},
+				ssml.Emphasis{Level: ssml.LevelStrong, Content: []ssml.Node{ssml.Text("really")}},
+			}})
+	}
+
+	It(`Writes documents with their text escaped`, func() {
+		Expect(order().String()).To(Equal(`<speak version="1.0">Order &lt;1&gt; &amp; ` +
+			`<say-as interpret-as="digits">4031</say-as><break time="500ms"/>` +
+			`<sub alias="World Wide Web Consortium">W3C</sub><mark name="total"/>` +
+			`<express-as style="cheerful">Thanks, <phoneme alphabet="ipa" ph="təˈmɑtoʊ &quot;x&quot;">tomato</phoneme>` +
+			`<emphasis level="strong">really</emphasis></express-as></speak>`))
+	})
+
+	It(`Reads the documents it writes`, func() {
+		parsed, err := ssml.Parse(order().String())
+		Expect(err).To(BeNil())
+		Expect(parsed).To(Equal(order()))
+	})
+
+	It(`Reads text with SSML elements in it`, func() {
+		parsed, err := ssml.Parse(`Hello <prosody rate="slow">there</prosody><s>Bye</s>`)
+		Expect(err).To(BeNil())
+		Expect(parsed.Content).To(Equal([]ssml.Node{
+			ssml.Text("Hello "),
+			ssml.Prosody{Rate: "slow", Content: []ssml.Node{ssml.Text("there")}},
+			ssml.Sentence{Content: []ssml.Node{ssml.Text("Bye")}},
+		}))
+
+		parsed, err = ssml.Parse(`<?xml version="1.0"?><speak version="1.0" xml:lang="fr-FR"` +
+			` xmlns="http://www.w3.org/2001/10/synthesis"><paragraph>Bonjour</paragraph></speak>`)
+		Expect(err).To(BeNil())
+		Expect(parsed).To(Equal(&ssml.Document{
+			Language: "fr-FR",
+			Content:  []ssml.Node{ssml.Paragraph{Content: []ssml.Node{ssml.Text("Bonjour")}}},
+		}))
+	})
+
+	It(`Writes and reads prompts`, func() {
+		document := ssml.New().Text("Goodbye. ").Prompt("thank_you")
+		Expect(document.String()).To(Equal(`<speak version="1.0">Goodbye. <ibm:prompt id="thank_you"/></speak>`))
+
+		parsed, err := ssml.Parse(document.String())
+		Expect(err).To(BeNil())
+		Expect(parsed).To(Equal(document))
+
+		_, err = ssml.Parse(`<ibm:prompt id="thank_you">Thanks</ibm:prompt>`)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal(`invalid SSML: the prompt element cannot contain text`))
+	})
+
+	table.DescribeTable(`Reports what it cannot read`,
+		func(text string, message string) {
+			_, err := ssml.Parse(text)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal(message))
+		},
+		table.Entry(`unsupported elements`, `Hi <audio src="beep.wav"/>`, `invalid SSML: the audio element is not supported`),
+		table.Entry(`unsupported attributes`, `<prosody volume="loud">Hi</prosody>`,
+			`invalid SSML: the prosody element does not support the volume attribute`),
+		table.Entry(`elements in text-only elements`, `<say-as interpret-as="letters"><break/>IBM</say-as>`,
+			`invalid SSML: the say-as element can contain only text, not a break element`),
+		table.Entry(`unescaped text`, `Fish & chips`,
+			`invalid SSML: XML syntax error on line 1: invalid character entity & (no semicolon)`),
+	)
+
+	table.DescribeTable(`Validate reports what the service would reject`,
+		func(text string, voice *texttospeechv1.Voice, websocket bool, problems ...string) {
+			document, err := ssml.Parse(text)
+			Expect(err).To(BeNil())
+			err = document.Validate(voice, websocket)
+			if len(problems) == 0 {
+				Expect(err).To(BeNil())
+				return
+			}
+			Expect(err).To(BeAssignableToTypeOf(&ssml.ValidationError{}))
+			Expect(err.(*ssml.ValidationError).Problems).To(Equal(problems))
+		},
+		table.Entry(`prosody on an expressive voice`, `<prosody rate="-10%">Hi</prosody>`,
+			voice("en-US_AllisonExpressive", false), false,
+			`the prosody element is not supported by the expressive voice en-US_AllisonExpressive`),
+		table.Entry(`prosody on another voice`, `<prosody pitch="+2st" rate="150">Hi</prosody>`,
+			voice("en-US_AllisonV3Voice", false), false),
+		table.Entry(`expressive elements on another voice`,
+			`<express-as style="empathetic">Oh <say-as interpret-as="interjection">no</say-as></express-as>`+
+				`<emphasis>now</emphasis>`,
+			voice("en-US_AllisonV3Voice", false), false,
+			`the express-as element is supported only by expressive voices, not by en-US_AllisonV3Voice`,
+			`say-as interjection is supported only by expressive voices, not by en-US_AllisonV3Voice`,
+			`the emphasis element is supported only by expressive voices, not by en-US_AllisonV3Voice`),
+		table.Entry(`expressive elements on an expressive voice`,
+			`<express-as style="empathetic">Oh <say-as interpret-as="interjection">no</say-as></express-as>`,
+			voice("en-US_AllisonExpressive", false), false),
+		table.Entry(`marks outside of websocket synthesis`, `Here <mark name="here"/>`, nil, false,
+			`the mark "here" is returned only by websocket synthesis; use SynthesizeUsingWebsocket or SynthesizeStream`),
+		table.Entry(`marks in websocket synthesis`, `Here <mark name="here"/>`, nil, true),
+		table.Entry(`invalid values`,
+			`<break strength="huge" time="2 seconds"/><prosody pitch="shrill">Hi</prosody>`+
+				`<phoneme alphabet="x-sampa" ph="">tomato</phoneme><express-as style="angry">Hi</express-as>`, nil, false,
+			`the break element has the invalid strength "huge"; use one of none, x-weak, weak, medium, strong, x-strong`,
+			`the break element has the invalid time "2 seconds"; use a time such as 500ms or 2s`,
+			`the prosody element has the invalid pitch "shrill"`,
+			`the phoneme element has the invalid alphabet "x-sampa"; use one of ipa, ibm`,
+			`the phoneme element for "tomato" has no pronunciation`,
+			`the express-as element has the invalid style "angry"; use one of cheerful, empathetic, neutral, uncertain`),
+		table.Entry(`missing content`, `<say-as interpret-as="letters"> </say-as><sub>W3C</sub><mark/>`, nil, true,
+			`a say-as element has no text`, `the sub element for "W3C" has no alias`, `a mark element has no name`),
+		table.Entry(`voice transformation without the feature`, `<voice-transformation type="Young">Hi</voice-transformation>`,
+			voice("en-US_AllisonV3Voice", false), false,
+			`the voice-transformation element is not supported by en-US_AllisonV3Voice`),
+		table.Entry(`voice transformation with the feature`, `<voice-transformation type="Soft">Hi</voice-transformation>`,
+			voice("en-US_AllisonVoice", true), false),
+		table.Entry(`prompts on a voice that is not US English`, `<ibm:prompt id="goodbye"/>`,
+			voice("fr-FR_ReneeV3Voice", false), false,
+			`the prompt "goodbye" is not supported by fr-FR_ReneeV3Voice; prompts are supported only by US English voices`),
+		table.Entry(`invalid prompt ids`, `<ibm:prompt id="good-bye"/><ibm:prompt/>`, nil, false,
+			`the prompt id "good-bye" can contain only letters, digits and underscores`, `a prompt element has no id`),
+	)
+
+	It(`Validates documents for a voice of the service`, func() {
+		var path string
+		server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			path = req.URL.Path
+			res.Header().Set("Content-type", "application/json")
+			fmt.Fprint(res, `{"url": "u", "gender": "female", "name": "en-US_EmmaExpressive", "language": "en-US",`+
+				` "description": "Emma", "customizable": true,`+
+				` "supported_features": {"custom_pronunciation": true, "voice_transformation": false}}`)
+		}))
+		defer server.Close()
+		textToSpeech, err := texttospeechv1.NewTextToSpeechV1(&texttospeechv1.TextToSpeechV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+
+		document := ssml.New().Add(ssml.Prosody{Rate: "slow", Content: []ssml.Node{ssml.Text("Hi")}})
+		err = document.ValidateForVoice(context.Background(), textToSpeech, "en-US_EmmaExpressive", "", false)
+		Expect(path).To(Equal("/v1/voices/en-US_EmmaExpressive"))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal(`invalid SSML: the prosody element is not supported by the expressive voice en-US_EmmaExpressive`))
+
+		path = ""
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err = document.ValidateForVoice(ctx, textToSpeech, "en-US_EmmaExpressive", "", false)
+		Expect(err).ToNot(BeNil())
+		Expect(path).To(BeEmpty())
+	})
+
+	It(`Validates prompts against the prompts of the custom model`, func() {
+		var paths []string
+		server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			paths = append(paths, req.URL.Path)
+			res.Header().Set("Content-type", "application/json")
+			if req.URL.Path == "/v1/customizations/model/prompts" {
+				fmt.Fprint(res, `{"prompts": [`+
+					`{"prompt": "Hello", "prompt_id": "hello", "status": "available"},`+
+					`{"prompt": "Goodbye", "prompt_id": "goodbye", "status": "failed", "error": "Audio too noisy"}]}`)
+				return
+			}
+			fmt.Fprint(res, `{"url": "u", "gender": "female", "name": "en-US_AllisonV3Voice", "language": "en-US",`+
+				` "description": "Allison", "customizable": true,`+
+				` "supported_features": {"custom_pronunciation": true, "voice_transformation": true},`+
+				` "customization": {"customization_id": "model", "language": "en-US"}}`)
+		}))
+		defer server.Close()
+		textToSpeech, err := texttospeechv1.NewTextToSpeechV1(&texttospeechv1.TextToSpeechV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+
+		document := ssml.New().Prompt("hello").Add(ssml.Sentence{Content: []ssml.Node{
+			ssml.Prompt{ID: "goodbye"}, ssml.Prompt{ID: "later"},
+		}})
+		err = document.ValidateForVoice(context.Background(), textToSpeech, "en-US_AllisonV3Voice", "model", false)
+		Expect(paths).To(Equal([]string{"/v1/voices/en-US_AllisonV3Voice", "/v1/customizations/model/prompts"}))
+		Expect(err).To(BeAssignableToTypeOf(&ssml.ValidationError{}))
+		Expect(err.(*ssml.ValidationError).Problems).To(Equal([]string{
+			`the prompt "goodbye" has the status failed, not available`,
+			`the custom model has no prompt "later"`,
+		}))
+	})
+})
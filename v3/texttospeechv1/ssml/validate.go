@@ -0,0 +1,292 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ssml
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/validation"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1"
+)
+
+// ValidationError : The problems that Validate found in a document
+type ValidationError = validation.Error
+
+// The values of attributes that the service accepts
+var (
+	strengths     = []string{StrengthNone, StrengthXWeak, StrengthWeak, StrengthMedium, StrengthStrong, StrengthXStrong}
+	levels        = []string{LevelStrong, LevelModerate, LevelNone, LevelReduced}
+	styles        = []string{StyleCheerful, StyleEmpathetic, StyleNeutral, StyleUncertain}
+	alphabets     = []string{AlphabetIPA, AlphabetSPR}
+	pitches       = []string{"x-low", "low", "medium", "high", "x-high", "default"}
+	rates         = []string{"x-slow", "slow", "medium", "fast", "x-fast", "default"}
+	interpretAses = []string{
+		"address", "cardinal", "characters", "date", "digits", "fraction", "interjection", "letters", "number", "ordinal",
+		"telephone", "time", "unit", "vxml:boolean", "vxml:currency", "vxml:date", "vxml:digits", "vxml:number",
+		"vxml:phone", "vxml:time",
+	}
+	transformations = []string{"Young", "Soft", "Custom"}
+	promptLanguages = []string{"en-US"}
+
+	breakTime  = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?(ms|s)$`)
+	pitchValue = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?(Hz|st|%)$`)
+	rateValue  = regexp.MustCompile(`^([+-]?[0-9]+(\.[0-9]+)?%|[0-9]+(\.[0-9]+)?)$`)
+	promptID   = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+)
+
+// promptAvailable : The status of a prompt that the service can synthesize
+const promptAvailable = "available"
+
+// Validate : Checks that the service can synthesize the document with voice: the values of its attributes are valid,
+// its elements have the content they need, and the voice supports its elements. Expressive voices are the only ones
+// that support express-as, emphasis and interjections, and they do not support prosody; voice transformation needs a
+// voice whose SupportedFeatures include it. Marks are returned only by websocket synthesis, so they are accepted only
+// when websocket is true. A nil voice checks only what does not depend on the voice. It returns a *ValidationError that
+// lists every problem found.
+//
+// Prompts are supported only by US English voices. When the voice has a customization whose Prompts are listed, every
+// prompt of the document must be one of them and be available.
+func (document *Document) Validate(voice *texttospeechv1.Voice, websocket bool) error {
+	validator := &validator{voice: voice, websocket: websocket}
+	if voice != nil && voice.Name != nil {
+		validator.voiceName = *voice.Name
+	}
+	validator.validateContent(document.Content)
+	return validator.problems.Err("SSML")
+}
+
+// ValidateForVoice : Gets the voice, and the custom model when customizationID is not empty, and validates the
+// document with it. When the document has prompts, the prompts of the custom model are listed too, so that a prompt
+// that is missing or not available is reported.
+func (document *Document) ValidateForVoice(ctx context.Context, textToSpeech *texttospeechv1.TextToSpeechV1, voice string, customizationID string, websocket bool) error {
+	options := textToSpeech.NewGetVoiceOptions(voice)
+	if customizationID != "" {
+		options.SetCustomizationID(customizationID)
+	}
+	result, _, err := textToSpeech.GetVoiceWithContext(ctx, options)
+	if err != nil {
+		return fmt.Errorf("getting the voice %s: %s", voice, err)
+	}
+	if customizationID != "" && hasPrompts(document.Content) {
+		prompts, _, err := textToSpeech.ListCustomPromptsWithContext(ctx, textToSpeech.NewListCustomPromptsOptions(customizationID))
+		if err != nil {
+			return fmt.Errorf("listing the prompts of custom model %s: %s", customizationID, err)
+		}
+		if result.Customization == nil {
+			result.Customization = &texttospeechv1.CustomModel{CustomizationID: core.StringPtr(customizationID)}
+		}
+		result.Customization.Prompts = prompts.Prompts
+		if result.Customization.Prompts == nil {
+			result.Customization.Prompts = []texttospeechv1.Prompt{}
+		}
+	}
+	return document.Validate(result, websocket)
+}
+
+// hasPrompts : Reports whether nodes or their content include a prompt
+func hasPrompts(nodes []Node) bool {
+	for _, node := range nodes {
+		if _, ok := node.(Prompt); ok || hasPrompts(contentOf(node)) {
+			return true
+		}
+	}
+	return false
+}
+
+// contentOf : Returns the nodes that an element contains
+func contentOf(node Node) []Node {
+	switch node := node.(type) {
+	case Emphasis:
+		return node.Content
+	case Prosody:
+		return node.Content
+	case ExpressAs:
+		return node.Content
+	case Paragraph:
+		return node.Content
+	case Sentence:
+		return node.Content
+	case VoiceTransformation:
+		return node.Content
+	}
+	return nil
+}
+
+type validator struct {
+	voice     *texttospeechv1.Voice
+	voiceName string
+	websocket bool
+	problems  validation.Problems
+}
+
+// expressive : Reports whether the voice is known and is an expressive voice
+func (validator *validator) expressive() bool {
+	return strings.Contains(validator.voiceName, "Expressive")
+}
+
+// requireExpressive : Reports a problem when the voice is known and is not an expressive voice
+func (validator *validator) requireExpressive(what string) {
+	if validator.voice != nil && !validator.expressive() {
+		validator.problems.Add("%s is supported only by expressive voices, not by %s", what, validator.voiceName)
+	}
+}
+
+// checkValue : Reports a problem when a non-empty attribute has a value other than one of the values given
+func (validator *validator) checkValue(element string, attribute string, value string, values []string) {
+	if value != "" && !validation.Contains(values, value) {
+		validator.problems.Add("the %s element has the invalid %s %q; use one of %s", element, attribute, value,
+			strings.Join(values, ", "))
+	}
+}
+
+func (validator *validator) validateContent(nodes []Node) {
+	for _, node := range nodes {
+		validator.validateNode(node)
+	}
+}
+
+func (validator *validator) validateNode(node Node) {
+	switch node := node.(type) {
+	case Text:
+	case Break:
+		validator.checkValue("break", "strength", node.Strength, strengths)
+		if node.Time != "" && !breakTime.MatchString(node.Time) {
+			validator.problems.Add("the break element has the invalid time %q; use a time such as 500ms or 2s", node.Time)
+		}
+	case Emphasis:
+		validator.checkValue("emphasis", "level", node.Level, levels)
+		validator.requireExpressive("the emphasis element")
+		validator.validateContent(node.Content)
+	case Prosody:
+		if node.Pitch == "" && node.Rate == "" {
+			validator.problems.Add("the prosody element has neither a pitch nor a rate")
+		}
+		if node.Pitch != "" && !validation.Contains(pitches, node.Pitch) && !pitchValue.MatchString(node.Pitch) {
+			validator.problems.Add("the prosody element has the invalid pitch %q", node.Pitch)
+		}
+		if node.Rate != "" && !validation.Contains(rates, node.Rate) && !rateValue.MatchString(node.Rate) {
+			validator.problems.Add("the prosody element has the invalid rate %q", node.Rate)
+		}
+		if validator.expressive() {
+			validator.problems.Add("the prosody element is not supported by the expressive voice %s", validator.voiceName)
+		}
+		validator.validateContent(node.Content)
+	case SayAs:
+		if node.InterpretAs == "" {
+			validator.problems.Add("a say-as element has no interpret-as attribute")
+		}
+		validator.checkValue("say-as", "interpret-as", node.InterpretAs, interpretAses)
+		if node.InterpretAs == "interjection" {
+			validator.requireExpressive("say-as interjection")
+		}
+		if strings.TrimSpace(node.Text) == "" {
+			validator.problems.Add("a say-as element has no text")
+		}
+	case Phoneme:
+		if node.Alphabet == "" {
+			validator.problems.Add("the phoneme element for %q has no alphabet", node.Text)
+		}
+		validator.checkValue("phoneme", "alphabet", node.Alphabet, alphabets)
+		if strings.TrimSpace(node.PH) == "" {
+			validator.problems.Add("the phoneme element for %q has no pronunciation", node.Text)
+		}
+		if strings.TrimSpace(node.Text) == "" {
+			validator.problems.Add("a phoneme element has no text")
+		}
+	case Sub:
+		if strings.TrimSpace(node.Alias) == "" {
+			validator.problems.Add("the sub element for %q has no alias", node.Text)
+		}
+		if strings.TrimSpace(node.Text) == "" {
+			validator.problems.Add("a sub element has no text")
+		}
+	case Mark:
+		if node.Name == "" {
+			validator.problems.Add("a mark element has no name")
+		}
+		if !validator.websocket {
+			validator.problems.Add("the mark %q is returned only by websocket synthesis; use SynthesizeUsingWebsocket or "+
+				"SynthesizeStream", node.Name)
+		}
+	case ExpressAs:
+		if node.Style == "" {
+			validator.problems.Add("an express-as element has no style")
+		}
+		validator.checkValue("express-as", "style", node.Style, styles)
+		validator.requireExpressive("the express-as element")
+		validator.validateContent(node.Content)
+	case Paragraph:
+		validator.validateContent(node.Content)
+	case Sentence:
+		validator.validateContent(node.Content)
+	case VoiceTransformation:
+		validator.checkValue("voice-transformation", "type", node.Type, transformations)
+		if node.Type == "" {
+			validator.problems.Add("a voice-transformation element has no type")
+		}
+		if validator.voice != nil && !supportsVoiceTransformation(validator.voice) {
+			validator.problems.Add("the voice-transformation element is not supported by %s", validator.voiceName)
+		}
+		validator.validateContent(node.Content)
+	case Prompt:
+		validator.validatePrompt(node)
+	case nil:
+		validator.problems.Add("the document has an empty node")
+	default:
+		validator.problems.Add("the document has a node of the unknown type %T", node)
+	}
+}
+
+func (validator *validator) validatePrompt(prompt Prompt) {
+	switch {
+	case prompt.ID == "":
+		validator.problems.Add("a prompt element has no id")
+		return
+	case !promptID.MatchString(prompt.ID):
+		validator.problems.Add("the prompt id %q can contain only letters, digits and underscores", prompt.ID)
+	}
+	voice := validator.voice
+	if voice == nil {
+		return
+	}
+	if language := core.StringNilMapper(voice.Language); language != "" && !validation.Contains(promptLanguages, language) {
+		validator.problems.Add("the prompt %q is not supported by %s; prompts are supported only by US English voices",
+			prompt.ID, validator.voiceName)
+	}
+	if voice.Customization == nil || voice.Customization.Prompts == nil {
+		return
+	}
+	for _, candidate := range voice.Customization.Prompts {
+		if core.StringNilMapper(candidate.PromptID) != prompt.ID {
+			continue
+		}
+		if status := core.StringNilMapper(candidate.Status); status != promptAvailable {
+			validator.problems.Add("the prompt %q has the status %s, not %s", prompt.ID, status, promptAvailable)
+		}
+		return
+	}
+	validator.problems.Add("the custom model has no prompt %q", prompt.ID)
+}
+
+func supportsVoiceTransformation(voice *texttospeechv1.Voice) bool {
+	features := voice.SupportedFeatures
+	return features != nil && features.VoiceTransformation != nil && *features.VoiceTransformation
+}
@@ -0,0 +1,183 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package texttospeechv1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/workerpool"
+)
+
+// SynthesizeLargeOptions : Controls how SynthesizeLarge splits text. Zero values select the defaults.
+type SynthesizeLargeOptions struct {
+	// The largest segment of text, in bytes, at least 64. Defaults to 5 KB, the most that the service accepts in a
+	// request.
+	MaxTextSize int
+
+	// The maximum number of segments that are synthesized at the same time. Defaults to 4.
+	Workers int
+
+	// Synthesize the segments over websocket connections, which return the times of marks and, when Timings are
+	// requested, of words. Otherwise the segments are synthesized with the HTTP Synthesize method.
+	Websocket bool
+}
+
+func (options *SynthesizeLargeOptions) withDefaults() SynthesizeLargeOptions {
+	withDefaults := SynthesizeLargeOptions{}
+	if options != nil {
+		withDefaults = *options
+	}
+	if withDefaults.MaxTextSize == 0 || withDefaults.MaxTextSize > maxSynthesizeTextSize {
+		withDefaults.MaxTextSize = maxSynthesizeTextSize
+	}
+	if withDefaults.Workers <= 0 {
+		withDefaults.Workers = 4
+	}
+	return withDefaults
+}
+
+// synthesizedSegment : The audio and results of a segment of text
+type synthesizedSegment struct {
+	audio  []byte
+	result *SynthesisResult
+}
+
+// SynthesizeLarge : Synthesizes text of any size. The text, plain or SSML, is split into segments that fit the limit
+// of a request, at the ends of sentences where possible and never inside a tag, and the segments are synthesized
+// concurrently with the other options of synthesizeOptions. Elements that are open where a segment ends are opened
+// again in the next segment.
+//
+// The audio of the segments is joined into one stream and written to writer. WAV, Ogg, MP3 and raw (l16, mu-law and
+// a-law) audio can be joined; the WAV header is written for the joined audio, and the Ogg streams of the segments are
+// chained. When the segments are synthesized over websocket connections, the times of words and marks are offset by
+// the start of their segment. The first segment that fails cancels the others, and the error tells which segment it
+// was.
+func (textToSpeech *TextToSpeechV1) SynthesizeLarge(ctx context.Context, synthesizeOptions *SynthesizeStreamOptions, options *SynthesizeLargeOptions, writer io.Writer) (*SynthesisResult, error) {
+	if err := core.ValidateNotNil(synthesizeOptions, "synthesizeOptions cannot be nil"); err != nil {
+		return nil, err
+	}
+	if err := core.ValidateStruct(synthesizeOptions, "synthesizeOptions"); err != nil {
+		return nil, err
+	}
+	if options != nil && options.MaxTextSize != 0 && options.MaxTextSize < minSynthesizeTextSize {
+		return nil, fmt.Errorf("the maximum text size must be at least %d bytes", minSynthesizeTextSize)
+	}
+	settings := options.withDefaults()
+	contentType := contentTypeOf(core.StringNilMapper(synthesizeOptions.Accept), settings.Websocket)
+	format, err := newAudioFormat(contentType)
+	if err != nil {
+		return nil, err
+	}
+	texts, err := splitSynthesisText(*synthesizeOptions.Text, settings.MaxTextSize)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := workerpool.New(ctx, settings.Workers)
+	segments := make([]*synthesizedSegment, len(texts))
+	for index, text := range texts {
+		if !pool.Acquire() {
+			break
+		}
+		index, text := index, text
+		pool.Go(func(ctx context.Context) error {
+			segmentOptions := *synthesizeOptions
+			segmentOptions.Text = core.StringPtr(text)
+			segment, err := textToSpeech.synthesizeSegment(ctx, &segmentOptions, settings.Websocket)
+			if err != nil {
+				return fmt.Errorf("synthesizing segment %d of %d: %s", index+1, len(texts), err)
+			}
+			segments[index] = segment
+			return nil
+		})
+	}
+	if err := pool.Wait(); err != nil {
+		return nil, err
+	}
+	return joinSynthesizedSegments(format, contentType, segments, settings.Websocket, writer)
+}
+
+// synthesizeSegment : Synthesizes a segment of text over a websocket connection or with the HTTP method
+func (textToSpeech *TextToSpeechV1) synthesizeSegment(ctx context.Context, synthesizeOptions *SynthesizeStreamOptions, websocket bool) (*synthesizedSegment, error) {
+	if websocket {
+		var audio bytes.Buffer
+		result, err := textToSpeech.SynthesizeStream(ctx, synthesizeOptions, &audio)
+		if err != nil {
+			return nil, err
+		}
+		return &synthesizedSegment{audio: audio.Bytes(), result: result}, nil
+	}
+
+	result, _, err := textToSpeech.SynthesizeWithContext(ctx, &synthesizeOptions.SynthesizeOptions)
+	if err == nil && result == nil {
+		err = fmt.Errorf("no audio")
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+	audio, err := ioutil.ReadAll(result)
+	if err != nil {
+		return nil, err
+	}
+	return &synthesizedSegment{audio: audio, result: &SynthesisResult{}}, nil
+}
+
+// joinSynthesizedSegments : Writes the audio of the segments as one stream, and joins their results in order
+func joinSynthesizedSegments(format audioFormat, contentType string, segments []*synthesizedSegment, timed bool, writer io.Writer) (*SynthesisResult, error) {
+	joined := &SynthesisResult{ContentType: contentType}
+	warnings := map[string]bool{}
+	audio := make([][]byte, len(segments))
+	start := 0.0
+	for i, segment := range segments {
+		audio[i] = segment.audio
+		for _, word := range segment.result.Words {
+			word.StartTime += start
+			word.EndTime += start
+			joined.Words = append(joined.Words, word)
+		}
+		for _, mark := range segment.result.Marks {
+			mark.Time += start
+			joined.Marks = append(joined.Marks, mark)
+		}
+		for _, warning := range segment.result.Warnings {
+			if !warnings[warning] {
+				warnings[warning] = true
+				joined.Warnings = append(joined.Warnings, warning)
+			}
+		}
+		if timed && i < len(segments)-1 {
+			duration, err := format.duration(segment.audio)
+			if err != nil {
+				return nil, fmt.Errorf("reading the audio of segment %d: %s", i+1, err)
+			}
+			start += duration
+		}
+	}
+
+	size, err := format.join(writer, audio)
+	joined.AudioSize = size
+	if err != nil {
+		return joined, fmt.Errorf("joining the audio: %s", err)
+	}
+	return joined, nil
+}
@@ -0,0 +1,368 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package texttospeechv1_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/fakeservice"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/ttstest"
+)
+
+// synthesizeLargeService : A fake Synthesize method that records the texts it receives and answers each with the
+// audio that audio builds for it
+type synthesizeLargeService struct {
+	*fakeservice.Server
+	texts []string
+
+	// The text that the service fails to synthesize.
+	fail string
+}
+
+func newSynthesizeLargeService(contentType string, audio func(text string) []byte) *synthesizeLargeService {
+	service := &synthesizeLargeService{Server: fakeservice.NewServer()}
+	service.Handle(http.MethodPost, "/v1/synthesize", func(call *fakeservice.Call) {
+		Expect(call.Header.Get("Accept")).To(Equal(contentType))
+		var body struct {
+			Text string `json:"text"`
+		}
+		Expect(json.NewDecoder(call.Body).Decode(&body)).To(Succeed())
+		service.texts = append(service.texts, body.Text)
+
+		if service.fail != "" && strings.Contains(body.Text, service.fail) {
+			call.Error(http.StatusBadRequest, "Invalid SSML")
+			return
+		}
+		call.Audio(contentType, audio(body.Text))
+	}).Delay(10 * time.Millisecond)
+	return service
+}
+
+// streamedWav : Returns WAV audio of 16-bit mono samples at 22050 Hz whose header does not give the size of the data,
+// as the service streams it
+func streamedWav(data []byte) []byte {
+	header := make([]byte, 44)
+	copy(header, "RIFF\xff\xff\xff\xffWAVEfmt \x10\x00\x00\x00\x01\x00\x01\x00")
+	binary.LittleEndian.PutUint32(header[24:28], 22050)
+	binary.LittleEndian.PutUint32(header[28:32], 44100)
+	binary.LittleEndian.PutUint16(header[32:34], 2)
+	binary.LittleEndian.PutUint16(header[34:36], 16)
+	copy(header[36:], "data\xff\xff\xff\xff")
+	return append(header, data...)
+}
+
+// samples : Returns 16-bit audio with a sample for every byte of text
+func samples(text string) []byte {
+	audio := make([]byte, 2*len(text))
+	for i := 0; i < len(text); i++ {
+		audio[2*i] = text[i]
+	}
+	return audio
+}
+
+// oggPage : Builds an Ogg page with a single packet
+func oggPage(headerType byte, granule int64, serial uint32, sequence uint32, packet []byte) []byte {
+	page := []byte("OggS\x00")
+	page = append(page, headerType)
+	page = append(page, make([]byte, 20)...)
+	binary.LittleEndian.PutUint64(page[6:14], uint64(granule))
+	binary.LittleEndian.PutUint32(page[14:18], serial)
+	binary.LittleEndian.PutUint32(page[18:22], sequence)
+	page = append(page, byte(len(packet)/255+1))
+	for size := len(packet); size >= 0; size -= 255 {
+		if size >= 255 {
+			page = append(page, 255)
+		} else {
+			page = append(page, byte(size))
+		}
+	}
+	page = append(page, packet...)
+	binary.LittleEndian.PutUint32(page[22:26], oggChecksum(page))
+	return page
+}
+
+func oggChecksum(page []byte) uint32 {
+	var crc uint32
+	for i, b := range page {
+		if i >= 22 && i < 26 {
+			b = 0
+		}
+		crc ^= uint32(b) << 24
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ 0x04c11db7
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// mp3Frame : Builds a 417-byte MPEG-1 layer III frame at 128 kbps and 44.1 kHz, with content after the side
+// information, where a Xing or Info frame has its tag
+func mp3Frame(content string) []byte {
+	frame := make([]byte, 417)
+	copy(frame, "\xff\xfb\x90\x00")
+	copy(frame[36:], content)
+	return frame
+}
+
+var _ = Describe(`TextToSpeechV1 SynthesizeLarge`, func() {
+	newService := func(url string) *texttospeechv1.TextToSpeechV1 {
+		textToSpeech, err := texttospeechv1.NewTextToSpeechV1(&texttospeechv1.TextToSpeechV1Options{
+			URL:           url,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		return textToSpeech
+	}
+
+	It(`Splits plain text at sentences and joins the WAV audio`, func() {
+		service := newSynthesizeLargeService("audio/wav", func(text string) []byte {
+			return streamedWav(samples(text))
+		})
+		defer service.Close()
+
+		var text strings.Builder
+		for i := 1; i <= 40; i++ {
+			fmt.Fprintf(&text, "Sentence number %d is here. ", i)
+		}
+		textToSpeech := newService(service.URL)
+		options := textToSpeech.NewSynthesizeStreamOptions(text.String())
+		options.SetAccept("audio/wav")
+		var audio bytes.Buffer
+		result, err := textToSpeech.SynthesizeLarge(context.Background(), options,
+			&texttospeechv1.SynthesizeLargeOptions{MaxTextSize: 200, Workers: 3}, &audio)
+		Expect(err).To(BeNil())
+
+		Expect(len(service.texts)).To(BeNumerically(">=", 6))
+		Expect(service.MaxInFlight()).To(BeNumerically("<=", 3))
+		for _, segment := range service.texts {
+			Expect(len(segment)).To(BeNumerically("<=", 200))
+			Expect(segment).To(HaveSuffix("here. "))
+		}
+
+		joined := audio.Bytes()
+		Expect(result.AudioSize).To(Equal(int64(len(joined))))
+		Expect(result.ContentType).To(Equal("audio/wav"))
+		Expect(string(joined[:4])).To(Equal("RIFF"))
+		Expect(binary.LittleEndian.Uint32(joined[4:8])).To(Equal(uint32(len(joined) - 8)))
+		Expect(string(joined[36:40])).To(Equal("data"))
+		Expect(binary.LittleEndian.Uint32(joined[40:44])).To(Equal(uint32(2 * text.Len())))
+		Expect(joined[44:]).To(Equal(samples(text.String())))
+	})
+
+	It(`Splits SSML without breaking tags and reopens the open elements`, func() {
+		service := newSynthesizeLargeService("audio/l16;rate=22050", func(text string) []byte {
+			return []byte(text)
+		})
+		defer service.Close()
+
+		var text strings.Builder
+		text.WriteString(`<?xml version="1.0"?><speak version="1.0"><prosody rate="slow">`)
+		for i := 0; i < 30; i++ {
+			text.WriteString(`Call <say-as interpret-as="digits">12345</say-as> now. `)
+		}
+		text.WriteString(`</prosody></speak>`)
+		textToSpeech := newService(service.URL)
+		options := textToSpeech.NewSynthesizeStreamOptions(text.String())
+		options.SetAccept("audio/l16;rate=22050")
+		_, err := textToSpeech.SynthesizeLarge(context.Background(), options,
+			&texttospeechv1.SynthesizeLargeOptions{MaxTextSize: 200}, ioutil.Discard)
+		Expect(err).To(BeNil())
+
+		sayAs := 0
+		Expect(len(service.texts)).To(BeNumerically(">", 1))
+		for _, segment := range service.texts {
+			Expect(len(segment)).To(BeNumerically("<=", 200))
+			Expect(segment).To(HavePrefix(`<speak version="1.0"><prosody rate="slow">Call`))
+			Expect(segment).To(HaveSuffix(`now. </prosody></speak>`))
+			decoder := xml.NewDecoder(strings.NewReader(segment))
+			for {
+				token, err := decoder.Token()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).To(BeNil())
+				if start, ok := token.(xml.StartElement); ok && start.Name.Local == "say-as" {
+					sayAs++
+				}
+			}
+		}
+		Expect(sayAs).To(Equal(30))
+	})
+
+	It(`Offsets the times of words and marks over websocket connections`, func() {
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {
+			defer GinkgoRecover()
+			request, err := conn.ReadRequest()
+			Expect(err).To(BeNil())
+			Expect(request).To(HaveKeyWithValue("timings", []interface{}{"words"}))
+			words := strings.Fields(request["text"].(string))
+			Expect(conn.SendContentType("audio/l16;rate=1000")).To(Succeed())
+			for i, word := range words {
+				// Every word is a second of audio
+				Expect(conn.SendAudio(make([]byte, 2000))).To(Succeed())
+				Expect(conn.SendWords([][]interface{}{{word, float64(i), float64(i + 1)}})).To(Succeed())
+			}
+			Expect(conn.SendMarks([][]interface{}{{"end", float64(len(words))}})).To(Succeed())
+		})
+		defer server.Close()
+
+		var words []string
+		for i := 0; i < 30; i++ {
+			words = append(words, fmt.Sprintf("w%d", i))
+		}
+		text := ""
+		for i := 0; i < len(words); i += 3 {
+			text += strings.Join(words[i:i+3], " ") + ". "
+		}
+		textToSpeech := newService(server.URL)
+		options := textToSpeech.NewSynthesizeStreamOptions(text).SetTimings([]string{"words"})
+		options.SetAccept("audio/l16;rate=1000")
+		var audio bytes.Buffer
+		result, err := textToSpeech.SynthesizeLarge(context.Background(), options,
+			&texttospeechv1.SynthesizeLargeOptions{MaxTextSize: 64, Websocket: true}, &audio)
+		Expect(err).To(BeNil())
+
+		Expect(server.Connections()).To(BeNumerically(">", 1))
+		Expect(audio.Len()).To(Equal(30 * 2000))
+		Expect(result.Words).To(HaveLen(30))
+		for i, word := range result.Words {
+			if i%3 == 2 {
+				words[i] += "."
+			}
+			Expect(word).To(Equal(texttospeechv1.WordTiming{Word: words[i], StartTime: float64(i), EndTime: float64(i + 1)}))
+		}
+		Expect(result.Marks).To(HaveLen(server.Connections()))
+		Expect(result.Marks[len(result.Marks)-1]).To(Equal(texttospeechv1.Mark{Name: "end", Time: 30}))
+	})
+
+	It(`Chains the Ogg streams of the segments`, func() {
+		service := newSynthesizeLargeService("audio/ogg;codecs=opus", func(text string) []byte {
+			head := append([]byte("OpusHead\x01\x01"), 0x38, 0x01, 0x80, 0xbb, 0, 0, 0, 0, 0)
+			audio := oggPage(2, 0, 0x1234, 0, head)
+			audio = append(audio, oggPage(0, 0, 0x1234, 1, []byte("OpusTags"))...)
+			return append(audio, oggPage(4, 312+48000, 0x1234, 2, []byte(text))...)
+		})
+		defer service.Close()
+
+		textToSpeech := newService(service.URL)
+		options := textToSpeech.NewSynthesizeStreamOptions(strings.Repeat("One more sentence. ", 20))
+		options.SetAccept("audio/ogg;codecs=opus")
+		var audio bytes.Buffer
+		_, err := textToSpeech.SynthesizeLarge(context.Background(), options,
+			&texttospeechv1.SynthesizeLargeOptions{MaxTextSize: 100}, &audio)
+		Expect(err).To(BeNil())
+
+		serials := map[uint32]int{}
+		joined := audio.Bytes()
+		for position := 0; position < len(joined); {
+			Expect(string(joined[position : position+4])).To(Equal("OggS"))
+			segments := int(joined[position+26])
+			size := 27 + segments
+			for _, lacing := range joined[position+27 : position+27+segments] {
+				size += int(lacing)
+			}
+			page := joined[position : position+size]
+			Expect(binary.LittleEndian.Uint32(page[22:26])).To(Equal(oggChecksum(page)))
+			serials[binary.LittleEndian.Uint32(page[14:18])]++
+			position += size
+		}
+		Expect(serials).To(HaveLen(len(service.texts)))
+		for serial, pages := range serials {
+			Expect(serial).To(BeNumerically(">=", 0x1234))
+			Expect(pages).To(Equal(3))
+		}
+	})
+
+	It(`Joins MP3 audio without the tags and Info frames of the segments`, func() {
+		service := newSynthesizeLargeService("audio/mp3", func(text string) []byte {
+			audio := append([]byte("ID3\x04\x00\x00\x00\x00\x00\x04"), "TAG!"...)
+			audio = append(audio, mp3Frame("Info")...)
+			return append(audio, mp3Frame(text[:4])...)
+		})
+		defer service.Close()
+
+		textToSpeech := newService(service.URL)
+		options := textToSpeech.NewSynthesizeStreamOptions("Alpha one is the first of three sentences here. " +
+			"Bravo two is the second of three sentences here. Charlie three is the last of the sentences.")
+		options.SetAccept("audio/mp3")
+		var audio bytes.Buffer
+		_, err := textToSpeech.SynthesizeLarge(context.Background(), options,
+			&texttospeechv1.SynthesizeLargeOptions{MaxTextSize: 64}, &audio)
+		Expect(err).To(BeNil())
+		Expect(audio.Bytes()).To(Equal(bytes.Join([][]byte{mp3Frame("Alph"), mp3Frame("Brav"), mp3Frame("Char")}, nil)))
+	})
+
+	It(`Reports the segment that fails`, func() {
+		service := newSynthesizeLargeService("audio/wav", func(text string) []byte {
+			return streamedWav([]byte(text))
+		})
+		service.fail = "Second"
+		defer service.Close()
+
+		textToSpeech := newService(service.URL)
+		options := textToSpeech.NewSynthesizeStreamOptions("First sentence is here, and it is a long one. " +
+			"Second sentence is here, and it is a long one. Third sentence is the last one.")
+		options.SetAccept("audio/wav")
+		var audio bytes.Buffer
+		_, err := textToSpeech.SynthesizeLarge(context.Background(), options,
+			&texttospeechv1.SynthesizeLargeOptions{MaxTextSize: 64, Workers: 1}, &audio)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("synthesizing segment 2 of 3: "))
+		Expect(err.Error()).To(ContainSubstring("Invalid SSML"))
+		Expect(audio.Len()).To(Equal(0))
+	})
+
+	It(`Rejects formats and text it cannot handle`, func() {
+		textToSpeech := newService("http://localhost:1")
+		options := textToSpeech.NewSynthesizeStreamOptions("Hello.")
+		options.SetAccept("audio/webm")
+		_, err := textToSpeech.SynthesizeLarge(context.Background(), options, nil, ioutil.Discard)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("audio in the audio/webm format cannot be joined; use WAV, Ogg, MP3 or raw audio"))
+
+		options = textToSpeech.NewSynthesizeStreamOptions(`Call <say-as interpret-as="digits">` + strings.Repeat("1", 50) + `</say-as>`)
+		_, err = textToSpeech.SynthesizeLarge(context.Background(), options,
+			&texttospeechv1.SynthesizeLargeOptions{MaxTextSize: 64}, ioutil.Discard)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("the text cannot be split into segments of 64 bytes"))
+
+		for _, maxTextSize := range []int{1, 63, -1} {
+			_, err = textToSpeech.SynthesizeLarge(context.Background(), options,
+				&texttospeechv1.SynthesizeLargeOptions{MaxTextSize: maxTextSize}, ioutil.Discard)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("the maximum text size must be at least 64 bytes"))
+		}
+	})
+})
@@ -17,10 +17,9 @@
 package texttospeechv1
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 
 	"github.com/IBM/go-sdk-core/v5/core"
@@ -31,15 +30,14 @@ const (
 	SUCCESS = 200
 )
 
+// SynthesizeListener : Delivers the messages of a synthesize connection to a SynthesizeCallbackWrapper. Every synthesis
+// ends with exactly one terminal event: OnClose when the service closed the connection normally, or OnError when the
+// handshake, the service or the connection failed. IsClosed then receives a single value.
 type SynthesizeListener struct {
 	IsClosed chan bool
 	Callback SynthesizeCallbackWrapper
 }
 
-func decode(b []byte, target interface{}) {
-	_ = json.NewDecoder(bytes.NewReader(b)).Decode(&target)
-}
-
 // OnError: Callback when error encountered
 func (listener SynthesizeListener) OnError(err error) {
 	listener.Callback.OnError(err)
@@ -47,93 +45,88 @@ func (listener SynthesizeListener) OnError(err error) {
 
 // SendText: Sends the text message
 // Note: The service handles one request per connection
-func (listener SynthesizeListener) SendText(conn *websocket.Conn, req *http.Request) {
-	listener.OnOpen(conn)
-
-	body, err := ioutil.ReadAll(req.Body)
-	if err != nil {
-		listener.OnError(err)
-	}
-	err = conn.WriteMessage(websocket.TextMessage, body)
-	if err != nil {
-		listener.OnError(err)
-	}
+func (listener SynthesizeListener) SendText(conn *websocket.Conn, req *http.Request) error {
+	return sendSynthesizeText(conn, req)
 }
 
-// OnOpen: Sends start message to server when connection created
+// OnOpen: Callback when the connection is created
 func (listener SynthesizeListener) OnOpen(conn *websocket.Conn) {
 	listener.Callback.OnOpen()
 }
 
 // OnClose: Callback when websocket connection is closed
 func (listener SynthesizeListener) OnClose() {
-	<-listener.IsClosed
 	listener.Callback.OnClose()
 }
 
-// OnData: Callback when websocket connection receives data
+// OnData: Delivers the messages of the connection until it is closed, then closes the connection and delivers the
+// terminal event
 func (listener SynthesizeListener) OnData(conn *websocket.Conn) {
-	for {
-		messageType, result, err := conn.ReadMessage()
-
-		// The service will close the connection. We need to decipher
-		// if the error is a normal close signal
-		if err != nil {
-			listener.IsClosed <- true
+	err := readSynthesis(conn, listener.deliver)
+	conn.Close()
+	listener.finish(err)
+}
 
-			if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
-				listener.OnError(err)
-				conn.Close()
-			}
-			break
+// deliver : Passes a message to the callback methods for its type, and then to OnData
+func (listener SynthesizeListener) deliver(message *synthesisMessage) error {
+	switch {
+	case message.audio != nil:
+		listener.Callback.OnAudioStream(message.audio)
+	case len(message.BinaryStreams) > 0:
+		listener.Callback.OnContentType(message.BinaryStreams[0].ContentType)
+	case message.Words != nil:
+		timings := Timings{}
+		if err := json.Unmarshal(message.data, &timings); err != nil {
+			return fmt.Errorf("invalid message from the service: %s", err)
 		}
+		listener.Callback.OnTimingInformation(timings)
+	case message.Marks != nil:
+		marks := Marks{}
+		if err := json.Unmarshal(message.data, &marks); err != nil {
+			return fmt.Errorf("invalid message from the service: %s", err)
+		}
+		listener.Callback.OnMarks(marks)
+	}
 
-		if messageType == websocket.TextMessage {
-			var r map[string]interface{}
-			err = json.NewDecoder(bytes.NewReader(result)).Decode(&r)
-			if err != nil {
-				listener.OnError(fmt.Errorf(err.Error()))
-				listener.IsClosed <- true
-				break
-			}
-			if err, ok := r["error"]; ok {
-				listener.OnError(fmt.Errorf(err.(string)))
-				listener.IsClosed <- true
-				break
-			}
+	detailResponse := core.DetailedResponse{}
+	detailResponse.Result = message.data
+	detailResponse.StatusCode = SUCCESS
+	listener.Callback.OnData(&detailResponse)
+	return nil
+}
 
-			if _, ok := r["binary_streams"]; ok {
-				audioContentTypeWrapper := new(AudioContentTypeWrapper)
-				decode(result, audioContentTypeWrapper)
-				listener.Callback.OnContentType(audioContentTypeWrapper.BinaryStreams[0].ContentType)
-			} else if _, ok := r["words"]; ok {
-				timings := new(Timings)
-				decode(result, timings)
-				listener.Callback.OnTimingInformation(*timings)
-			} else if _, ok := r["marks"]; ok {
-				marks := new(Marks)
-				decode(result, marks)
-				listener.Callback.OnMarks(*marks)
-			}
-		} else if messageType == websocket.BinaryMessage {
-			listener.Callback.OnAudioStream(result)
-		}
+// finish : Delivers the terminal event, OnError for err or else OnClose, and signals IsClosed
+func (listener SynthesizeListener) finish(err error) {
+	if err != nil {
+		listener.OnError(err)
+	} else {
+		listener.OnClose()
+	}
+	select {
+	case listener.IsClosed <- true:
+	default:
+	}
+}
 
-		detailResponse := core.DetailedResponse{}
-		detailResponse.Result = result
-		detailResponse.StatusCode = SUCCESS
-		listener.Callback.OnData(&detailResponse)
+// listen : Sends the text over an open connection and delivers its messages until the connection is closed
+func (listener SynthesizeListener) listen(conn *websocket.Conn, req *http.Request) {
+	listener.OnOpen(conn)
+	if err := listener.SendText(conn, req); err != nil {
+		conn.Close()
+		listener.finish(err)
+		return
 	}
+	listener.OnData(conn)
 }
 
+// NewSynthesizeListener : Synthesizes the text of req and delivers the results to callback. It returns once the
+// terminal event has been delivered; a failed handshake is delivered to OnError as a *WebsocketDialError.
 func (textToSpeechV1 *TextToSpeechV1) NewSynthesizeListener(callback SynthesizeCallbackWrapper, req *http.Request) {
 	synthesizeListener := SynthesizeListener{Callback: callback, IsClosed: make(chan bool, 1)}
-	conn, _, err := websocket.DefaultDialer.Dial(req.URL.String(), req.Header)
+	conn, err := dialSynthesize(context.Background(), req)
 	if err != nil {
-		synthesizeListener.OnError(err)
+		synthesizeListener.finish(err)
+		return
 	}
-
-	go synthesizeListener.OnData(conn)
-	go synthesizeListener.SendText(conn, req)
-	synthesizeListener.OnClose()
+	synthesizeListener.listen(conn, req)
 }
@@ -0,0 +1,232 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package texttospeechv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/gorilla/websocket"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/jsontuple"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/websocketerrors"
+)
+
+// WordTiming : When a word of the input text is spoken in the synthesized audio
+type WordTiming struct {
+	Word string
+
+	// The start and end of the word in seconds from the beginning of the audio.
+	StartTime float64
+	EndTime   float64
+}
+
+// UnmarshalJSON : Reads a word timing in the form the service sends it, `[word, start, end]`
+func (timing *WordTiming) UnmarshalJSON(data []byte) error {
+	return jsontuple.Unmarshal(data, "word timing", &timing.Word, &timing.StartTime, &timing.EndTime)
+}
+
+// Mark : When an SSML `<mark>` element of the input text is reached in the synthesized audio
+type Mark struct {
+	Name string
+
+	// The time of the mark in seconds from the beginning of the audio.
+	Time float64
+}
+
+// UnmarshalJSON : Reads a mark in the form the service sends it, `[name, time]`
+func (mark *Mark) UnmarshalJSON(data []byte) error {
+	return jsontuple.Unmarshal(data, "mark", &mark.Name, &mark.Time)
+}
+
+// SynthesizeStreamOptions : The SynthesizeStream options
+type SynthesizeStreamOptions struct {
+	SynthesizeOptions
+
+	// Timings specifies that the service is to return word timing information for all strings of the input text.
+	// Specify words as the lone element of the array to request word timings. Marks are always returned. Not supported
+	// for Japanese input text.
+	Timings []string
+}
+
+// NewSynthesizeStreamOptions : Instantiate SynthesizeStreamOptions
+func (textToSpeech *TextToSpeechV1) NewSynthesizeStreamOptions(text string) *SynthesizeStreamOptions {
+	return &SynthesizeStreamOptions{SynthesizeOptions: *textToSpeech.NewSynthesizeOptions(text)}
+}
+
+// SetTimings : Allow user to set Timings
+func (options *SynthesizeStreamOptions) SetTimings(timings []string) *SynthesizeStreamOptions {
+	options.Timings = timings
+	return options
+}
+
+// SynthesisResult : What the service sent besides the audio
+type SynthesisResult struct {
+	// The format of the audio.
+	ContentType string
+
+	// The number of bytes of audio written.
+	AudioSize int64
+
+	// The word timings, when they were requested.
+	Words []WordTiming
+
+	// The times of the marks of the input text.
+	Marks []Mark
+
+	// The warnings the service sent, such as for unknown parameters.
+	Warnings []string
+}
+
+// SynthesizeStream : Synthesizes text over a websocket connection and writes the audio to writer as it arrives, so
+// that it can be streamed to a file or an HTTP response. The method returns when the service has sent all of the
+// audio, with the word timings and marks of the synthesis.
+//
+// Cancelling ctx closes the connection. Errors are returned as a *WebsocketDialError when the handshake fails, a
+// *SynthesisError when the service reports an error, a *WebsocketCloseError when the connection is closed abnormally,
+// or as the error of ctx or writer; the result then holds what was received before the error.
+func (textToSpeech *TextToSpeechV1) SynthesizeStream(ctx context.Context, synthesizeOptions *SynthesizeStreamOptions, writer io.Writer) (*SynthesisResult, error) {
+	if err := core.ValidateNotNil(synthesizeOptions, "synthesizeOptions cannot be nil"); err != nil {
+		return nil, err
+	}
+	if err := core.ValidateStruct(synthesizeOptions, "synthesizeOptions"); err != nil {
+		return nil, err
+	}
+	request, err := textToSpeech.newSynthesizeRequest(&synthesizeOptions.SynthesizeOptions, synthesizeOptions.Timings)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := dialSynthesize(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err = sendSynthesizeText(conn, request); err != nil {
+		return nil, err
+	}
+
+	// Closing the connection ends a blocked read when ctx is cancelled
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	result := &SynthesisResult{}
+	err = readSynthesis(conn, func(message *synthesisMessage) error {
+		if message.audio != nil {
+			n, err := writer.Write(message.audio)
+			result.AudioSize += int64(n)
+			if err != nil {
+				return fmt.Errorf("writing the audio: %s", err)
+			}
+			return nil
+		}
+		if len(message.BinaryStreams) > 0 {
+			result.ContentType = message.BinaryStreams[0].ContentType
+		}
+		result.Words = append(result.Words, message.Words...)
+		result.Marks = append(result.Marks, message.Marks...)
+		if message.Warnings != "" {
+			result.Warnings = append(result.Warnings, message.Warnings)
+		}
+		return nil
+	})
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+	return result, err
+}
+
+// dialSynthesize : Opens a synthesize connection with the URL and headers of request
+func dialSynthesize(ctx context.Context, request *http.Request) (*websocket.Conn, error) {
+	conn, response, err := websocket.DefaultDialer.DialContext(ctx, request.URL.String(), request.Header)
+	if err != nil {
+		return nil, websocketerrors.NewDialError(response, err)
+	}
+	return conn, nil
+}
+
+// sendSynthesizeText : Sends the body of request as the text message that starts the synthesis. The service handles
+// one request per connection.
+func sendSynthesizeText(conn *websocket.Conn, request *http.Request) error {
+	var text []byte
+	if request.Body != nil {
+		var err error
+		if text, err = ioutil.ReadAll(request.Body); err != nil {
+			return err
+		}
+	}
+	return conn.WriteMessage(websocket.TextMessage, text)
+}
+
+// synthesisMessage : A message of a synthesize connection: audio, or one of the text messages of the service
+type synthesisMessage struct {
+	// The message as it was received.
+	data []byte
+
+	// The audio of a binary message, nil for text messages.
+	audio []byte
+
+	BinaryStreams []struct {
+		ContentType string `json:"content_type"`
+	} `json:"binary_streams"`
+	Words    []WordTiming `json:"words"`
+	Marks    []Mark       `json:"marks"`
+	Warnings string       `json:"warnings"`
+	Error    *string      `json:"error"`
+}
+
+// readSynthesis : Reads the messages of a synthesize connection and passes them to handle until the service closes the
+// connection. It returns nil when the connection is closed normally, and otherwise the first error of handle, the
+// service or the connection.
+func readSynthesis(conn *websocket.Conn, handle func(message *synthesisMessage) error) error {
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return nil
+			}
+			return websocketerrors.NewReadError(err)
+		}
+		message := &synthesisMessage{data: data}
+		switch messageType {
+		case websocket.BinaryMessage:
+			message.audio = data
+		case websocket.TextMessage:
+			if err = json.Unmarshal(data, message); err != nil {
+				return fmt.Errorf("invalid message from the service: %s", err)
+			}
+			if message.Error != nil {
+				return &SynthesisError{Message: *message.Error}
+			}
+		default:
+			continue
+		}
+		if err = handle(message); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,207 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package texttospeechv1_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/ttstest"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// cancellingWriter : Cancels the synthesis once the first audio is written
+type cancellingWriter struct {
+	bytes.Buffer
+	cancel context.CancelFunc
+}
+
+func (writer *cancellingWriter) Write(audio []byte) (int, error) {
+	defer writer.cancel()
+	return writer.Buffer.Write(audio)
+}
+
+var _ = Describe(`TextToSpeechV1 SynthesizeStream`, func() {
+	newService := func(server *ttstest.Server) *texttospeechv1.TextToSpeechV1 {
+		textToSpeech, err := texttospeechv1.NewTextToSpeechV1(&texttospeechv1.TextToSpeechV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		return textToSpeech
+	}
+
+	It(`Writes the audio as it arrives and returns typed timings and marks`, func() {
+		var request map[string]interface{}
+		var voice string
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {
+			defer GinkgoRecover()
+			var err error
+			voice = conn.Request.URL.Query().Get("voice")
+			request, err = conn.ReadRequest()
+			Expect(err).To(BeNil())
+			Expect(conn.SendContentType("audio/wav")).To(Succeed())
+			Expect(conn.SendAudio([]byte("RIFF"))).To(Succeed())
+			Expect(conn.SendWords([][]interface{}{{"hello", 0.05, 0.4}, {"world", 0.4, 0.8}})).To(Succeed())
+			Expect(conn.SendMarks([][]interface{}{{"here", 0.4}})).To(Succeed())
+			Expect(conn.SendJSON(map[string]string{"warnings": "Unknown arguments: speed."})).To(Succeed())
+			Expect(conn.SendAudio([]byte("data"))).To(Succeed())
+		})
+		defer server.Close()
+
+		textToSpeech := newService(server)
+		options := textToSpeech.NewSynthesizeStreamOptions(`hello <mark name="here"/> world`).SetTimings([]string{"words"})
+		options.SetAccept("audio/wav").SetVoice("en-US_AllisonV3Voice")
+		var audio bytes.Buffer
+		result, err := textToSpeech.SynthesizeStream(context.Background(), options, &audio)
+		Expect(err).To(BeNil())
+
+		Expect(voice).To(Equal("en-US_AllisonV3Voice"))
+		Expect(request).To(HaveKeyWithValue("text", `hello <mark name="here"/> world`))
+		Expect(request).To(HaveKeyWithValue("timings", []interface{}{"words"}))
+		Expect(audio.String()).To(Equal("RIFFdata"))
+		Expect(result).To(Equal(&texttospeechv1.SynthesisResult{
+			ContentType: "audio/wav",
+			AudioSize:   8,
+			Words: []texttospeechv1.WordTiming{
+				{Word: "hello", StartTime: 0.05, EndTime: 0.4},
+				{Word: "world", StartTime: 0.4, EndTime: 0.8},
+			},
+			Marks:    []texttospeechv1.Mark{{Name: "here", Time: 0.4}},
+			Warnings: []string{"Unknown arguments: speed."},
+		}))
+	})
+
+	It(`Returns the error messages of the service with the audio received before`, func() {
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {
+			defer GinkgoRecover()
+			_, err := conn.ReadRequest()
+			Expect(err).To(BeNil())
+			Expect(conn.SendAudio([]byte("partial"))).To(Succeed())
+			Expect(conn.SendError("Model en-US_NoSuchVoice not found")).To(Succeed())
+		})
+		defer server.Close()
+
+		textToSpeech := newService(server)
+		var audio bytes.Buffer
+		result, err := textToSpeech.SynthesizeStream(context.Background(), textToSpeech.NewSynthesizeStreamOptions("hello"), &audio)
+		Expect(err).To(Equal(&texttospeechv1.SynthesisError{Message: "Model en-US_NoSuchVoice not found"}))
+		Expect(result.AudioSize).To(Equal(int64(7)))
+		Expect(audio.String()).To(Equal("partial"))
+	})
+
+	It(`Returns the status and body of a failed handshake`, func() {
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {
+			conn.Reject(http.StatusUnauthorized, `{"code":401,"error":"Unauthorized"}`)
+		})
+		defer server.Close()
+
+		textToSpeech := newService(server)
+		result, err := textToSpeech.SynthesizeStream(context.Background(), textToSpeech.NewSynthesizeStreamOptions("hello"), &bytes.Buffer{})
+		Expect(result).To(BeNil())
+		Expect(err).To(BeAssignableToTypeOf(&texttospeechv1.WebsocketDialError{}))
+		dialErr := err.(*texttospeechv1.WebsocketDialError)
+		Expect(dialErr.StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(dialErr.Body).To(Equal(`{"code":401,"error":"Unauthorized"}`))
+	})
+
+	It(`Returns a close error when the connection is closed abnormally`, func() {
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {
+			defer GinkgoRecover()
+			_, err := conn.ReadRequest()
+			Expect(err).To(BeNil())
+			Expect(conn.SendAudio([]byte("partial"))).To(Succeed())
+			Expect(conn.Abort()).To(Succeed())
+		})
+		defer server.Close()
+
+		textToSpeech := newService(server)
+		result, err := textToSpeech.SynthesizeStream(context.Background(), textToSpeech.NewSynthesizeStreamOptions("hello"), &bytes.Buffer{})
+		Expect(err).To(Equal(&texttospeechv1.WebsocketCloseError{Code: 1006, Text: "unexpected EOF"}))
+		Expect(result.AudioSize).To(Equal(int64(7)))
+	})
+
+	It(`Stops when the writer fails`, func() {
+		server := ttstest.NewSynthesizingServer("audio/wav", make([]byte, 1000), 100)
+		defer server.Close()
+
+		textToSpeech := newService(server)
+		_, err := textToSpeech.SynthesizeStream(context.Background(), textToSpeech.NewSynthesizeStreamOptions("hello"), failingWriter{})
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("writing the audio: disk full"))
+	})
+
+	It(`Stops when the context is cancelled`, func() {
+		release := make(chan struct{})
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {
+			defer GinkgoRecover()
+			_, err := conn.ReadRequest()
+			Expect(err).To(BeNil())
+			Expect(conn.SendAudio([]byte("partial"))).To(Succeed())
+			<-release
+		})
+		defer server.Close()
+		defer close(release)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		writer := &cancellingWriter{cancel: cancel}
+		textToSpeech := newService(server)
+		result, err := textToSpeech.SynthesizeStream(ctx, textToSpeech.NewSynthesizeStreamOptions("hello"), writer)
+		Expect(err).To(Equal(context.Canceled))
+		Expect(result.AudioSize).To(Equal(int64(7)))
+		Expect(writer.String()).To(Equal("partial"))
+	})
+
+	It(`Rejects malformed timings and marks`, func() {
+		var timing texttospeechv1.WordTiming
+		Expect(json.Unmarshal([]byte(`["hello", 0.05, 0.4]`), &timing)).To(Succeed())
+		Expect(timing).To(Equal(texttospeechv1.WordTiming{Word: "hello", StartTime: 0.05, EndTime: 0.4}))
+		Expect(json.Unmarshal([]byte(`["hello", "0.05", 0.4]`), &timing)).ToNot(Succeed())
+		Expect(json.Unmarshal([]byte(`["hello", 0.05]`), &timing)).ToNot(Succeed())
+		Expect(json.Unmarshal([]byte(`{"word": "hello"}`), &timing)).ToNot(Succeed())
+
+		var mark texttospeechv1.Mark
+		Expect(json.Unmarshal([]byte(`["here", 0.4]`), &mark)).To(Succeed())
+		Expect(mark).To(Equal(texttospeechv1.Mark{Name: "here", Time: 0.4}))
+		Expect(json.Unmarshal([]byte(`[0.4, "here"]`), &mark)).ToNot(Succeed())
+		Expect(json.Unmarshal([]byte(`["here", 0.4, 0.8]`), &mark)).ToNot(Succeed())
+	})
+
+	It(`Validates the options`, func() {
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {})
+		defer server.Close()
+
+		textToSpeech := newService(server)
+		_, err := textToSpeech.SynthesizeStream(context.Background(), nil, &bytes.Buffer{})
+		Expect(err).ToNot(BeNil())
+		_, err = textToSpeech.SynthesizeStream(context.Background(), &texttospeechv1.SynthesizeStreamOptions{}, &bytes.Buffer{})
+		Expect(err).ToNot(BeNil())
+		Expect(server.Connections()).To(Equal(0))
+	})
+})
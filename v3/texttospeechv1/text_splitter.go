@@ -0,0 +1,352 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package texttospeechv1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSynthesizeTextSize : The largest text, in bytes, that the service synthesizes in one request
+const maxSynthesizeTextSize = 5 * 1024
+
+// minSynthesizeTextSize : The smallest segment size that SynthesizeLarge accepts, which leaves room for the tags that
+// are reopened at the start of a segment and for a few words
+const minSynthesizeTextSize = 64
+
+// cutKind : Whether a segment of text can end after a unit, and how well
+type cutKind int
+
+const (
+	noCut cutKind = iota
+	wordCut
+	sentenceCut
+)
+
+// textUnit : A tag, or a part of the text between tags, that is kept whole in a segment
+type textUnit struct {
+	text string
+
+	// The element that a start tag opens.
+	open *openElement
+
+	// Whether the unit is an end tag.
+	close bool
+
+	cut cutKind
+}
+
+// openElement : An element that is open at a point of the text
+type openElement struct {
+	name string
+
+	// The start tag of the element, which reopens it in the next segment.
+	tag string
+}
+
+var (
+	// A sentence ends with punctuation followed by white space or the end of the text, or with a blank line
+	sentenceEnd = regexp.MustCompile(`[.!?。！？]+["'”’)\]]*(\s+|$)|\n\s*\n`)
+	whitespace  = regexp.MustCompile(`\s+`)
+	tags        = regexp.MustCompile(`<[^>]*>`)
+)
+
+// textOnlyElements : The elements whose text is spoken as one value, which a segment cannot end in
+var textOnlyElements = map[string]bool{"say-as": true, "phoneme": true, "sub": true}
+
+// splitSynthesisText : Splits plain text or SSML into segments of at most maxSize bytes. Segments end at the end of a
+// sentence, paragraph or break when there is one in the second half of the segment, and otherwise between words. The
+// elements that are open where a segment ends are closed at its end and opened again at the start of the next one, so
+// that every segment is well formed and keeps the voice settings of its text.
+func splitSynthesisText(text string, maxSize int) ([]string, error) {
+	units, err := splitTextUnits(text, maxSize/2)
+	if err != nil {
+		return nil, err
+	}
+	packer := &textPacker{maxSize: maxSize}
+	for _, unit := range units {
+		if err = packer.add(unit); err != nil {
+			return nil, err
+		}
+	}
+	packer.emit(packer.prefix + string(packer.content) + closingTags(packer.stack))
+	return packer.segments, nil
+}
+
+// splitTextUnits : Splits text into tags and pieces of text of at most maxSize bytes, and notes where a segment can end
+func splitTextUnits(text string, maxSize int) ([]textUnit, error) {
+	var units []textUnit
+	textOnlyDepth := 0
+	for position := 0; position < len(text); {
+		if text[position] != '<' {
+			end := strings.IndexByte(text[position:], '<')
+			if end < 0 {
+				end = len(text) - position
+			}
+			if textOnlyDepth > 0 {
+				units = append(units, textUnit{text: text[position : position+end]})
+			} else {
+				units = append(units, splitSentences(text[position:position+end], maxSize)...)
+			}
+			position += end
+			continue
+		}
+
+		end := tagEnd(text, position)
+		if end < 0 {
+			return nil, fmt.Errorf("the text has an unterminated tag at byte %d", position)
+		}
+		tag := text[position:end]
+		position = end
+		name := tagName(tag)
+		switch {
+		case strings.HasPrefix(tag, "<?") || strings.HasPrefix(tag, "<!"):
+			// The XML declaration and comments are left out of the segments
+		case strings.HasPrefix(tag, "</"):
+			if textOnlyElements[name] {
+				textOnlyDepth--
+			}
+			unit := textUnit{text: tag, close: true}
+			if textOnlyDepth == 0 {
+				unit.cut = wordCut
+				if name == "p" || name == "paragraph" || name == "s" || name == "sentence" {
+					unit.cut = sentenceCut
+				}
+			}
+			units = append(units, unit)
+		case strings.HasSuffix(tag, "/>"):
+			unit := textUnit{text: tag}
+			if textOnlyDepth == 0 {
+				unit.cut = wordCut
+				if name == "break" {
+					unit.cut = sentenceCut
+				}
+			}
+			units = append(units, unit)
+		default:
+			if textOnlyElements[name] {
+				textOnlyDepth++
+			}
+			units = append(units, textUnit{text: tag, open: &openElement{name: name, tag: tag}})
+		}
+	}
+	return units, nil
+}
+
+// splitSentences : Splits text between tags into sentences, and sentences longer than maxSize into words
+func splitSentences(text string, maxSize int) []textUnit {
+	var units []textUnit
+	for len(text) > 0 {
+		sentence, cut := text, wordCut
+		if bounds := sentenceEnd.FindStringIndex(text); bounds != nil {
+			sentence, cut = text[:bounds[1]], sentenceCut
+		}
+		text = text[len(sentence):]
+		if cut == wordCut && !endsWithSpace(sentence) && len(text) == 0 {
+			// The text runs on into the tag that follows it
+			cut = noCut
+		}
+		if len(sentence) <= maxSize {
+			units = append(units, textUnit{text: sentence, cut: cut})
+			continue
+		}
+		words := splitWords(sentence, maxSize)
+		for i, word := range words {
+			unit := textUnit{text: word, cut: wordCut}
+			if i == len(words)-1 {
+				unit.cut = cut
+			}
+			units = append(units, unit)
+		}
+	}
+	return units
+}
+
+// splitWords : Splits text after white space, and words longer than maxSize at characters
+func splitWords(text string, maxSize int) []string {
+	var words []string
+	for len(text) > 0 {
+		word := text
+		if bounds := whitespace.FindStringIndex(text); bounds != nil {
+			word = text[:bounds[1]]
+		}
+		for len(word) > maxSize {
+			end := maxSize
+			for end > 0 && !utf8.RuneStart(word[end]) {
+				end--
+			}
+			if end == 0 {
+				// The first character is longer than maxSize; it is cut whole rather than split
+				_, end = utf8.DecodeRuneInString(word)
+			}
+			words = append(words, word[:end])
+			word, text = word[end:], text[end:]
+		}
+		if len(word) > 0 {
+			words = append(words, word)
+		}
+		text = text[len(word):]
+	}
+	return words
+}
+
+func endsWithSpace(text string) bool {
+	return strings.TrimRight(text, " \t\r\n") != text
+}
+
+// tagEnd : Returns the position after the tag that starts at start, or -1 if the tag does not end
+func tagEnd(text string, start int) int {
+	if strings.HasPrefix(text[start:], "<!--") {
+		end := strings.Index(text[start:], "-->")
+		if end < 0 {
+			return -1
+		}
+		return start + end + len("-->")
+	}
+	var quote byte
+	for i := start + 1; i < len(text); i++ {
+		switch {
+		case quote != 0:
+			if text[i] == quote {
+				quote = 0
+			}
+		case text[i] == '"' || text[i] == '\'':
+			quote = text[i]
+		case text[i] == '>':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// tagName : Returns the name of the element of a tag
+func tagName(tag string) string {
+	name := strings.TrimLeft(tag, "</")
+	if end := strings.IndexAny(name, " \t\r\n/>"); end >= 0 {
+		name = name[:end]
+	}
+	return name
+}
+
+func openingTags(stack []openElement) string {
+	var opening strings.Builder
+	for _, element := range stack {
+		opening.WriteString(element.tag)
+	}
+	return opening.String()
+}
+
+func closingTags(stack []openElement) string {
+	var closing strings.Builder
+	for i := len(stack) - 1; i >= 0; i-- {
+		closing.WriteString("</" + stack[i].name + ">")
+	}
+	return closing.String()
+}
+
+// textCut : A place where the current segment can end
+type textCut struct {
+	// The length of the content of the segment before the cut.
+	length int
+
+	// The elements that are open at the cut.
+	stack []openElement
+}
+
+// textPacker : Packs units into segments
+type textPacker struct {
+	maxSize  int
+	segments []string
+
+	// The start tags that open the current segment, and the units added to it after them.
+	prefix  string
+	content []byte
+
+	// The elements that are open at the end of the content.
+	stack []openElement
+
+	// The last end of a sentence and the last place between words in the content.
+	sentence *textCut
+	word     *textCut
+}
+
+func (packer *textPacker) add(unit textUnit) error {
+	for packer.size(unit) > packer.maxSize {
+		cut := packer.word
+		if packer.sentence != nil && (cut == nil || packer.sentence.length >= len(packer.content)/2) {
+			cut = packer.sentence
+		}
+		if cut == nil {
+			excerpt := string(packer.content) + unit.text
+			if len(excerpt) > 40 {
+				excerpt = excerpt[:40]
+			}
+			return fmt.Errorf("the text cannot be split into segments of %d bytes at %q", packer.maxSize, excerpt)
+		}
+		packer.cut(cut)
+	}
+
+	packer.content = append(packer.content, unit.text...)
+	switch {
+	case unit.open != nil:
+		packer.stack = append(packer.stack, *unit.open)
+	case unit.close && len(packer.stack) > 0:
+		packer.stack = packer.stack[:len(packer.stack)-1]
+	}
+	if unit.cut != noCut {
+		cut := &textCut{length: len(packer.content), stack: append([]openElement(nil), packer.stack...)}
+		packer.word = cut
+		if unit.cut == sentenceCut {
+			packer.sentence = cut
+		}
+	}
+	return nil
+}
+
+// size : Returns the size of the current segment with unit added to it and the open elements closed
+func (packer *textPacker) size(unit textUnit) int {
+	size := len(packer.prefix) + len(packer.content) + len(unit.text) + len(closingTags(packer.stack))
+	if unit.open != nil {
+		size += len("</" + unit.open.name + ">")
+	}
+	return size
+}
+
+// cut : Ends the current segment at cut, and starts the next one with the rest of the content
+func (packer *textPacker) cut(cut *textCut) {
+	packer.emit(packer.prefix + string(packer.content[:cut.length]) + closingTags(cut.stack))
+	packer.prefix = openingTags(cut.stack)
+	packer.content = append([]byte(nil), packer.content[cut.length:]...)
+	packer.sentence = rebaseCut(packer.sentence, cut.length)
+	packer.word = rebaseCut(packer.word, cut.length)
+}
+
+func rebaseCut(cut *textCut, length int) *textCut {
+	if cut == nil || cut.length <= length {
+		return nil
+	}
+	return &textCut{length: cut.length - length, stack: cut.stack}
+}
+
+// emit : Adds a segment, unless it has only tags and white space
+func (packer *textPacker) emit(segment string) {
+	if strings.TrimSpace(tags.ReplaceAllString(segment, "")) != "" {
+		packer.segments = append(packer.segments, segment)
+	}
+}
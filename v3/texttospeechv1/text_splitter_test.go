@@ -0,0 +1,40 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package texttospeechv1
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`splitWords`, func() {
+	table.DescribeTable(`Splits words longer than the size at characters`,
+		func(text string, maxSize int, words []string) {
+			Expect(splitWords(text, maxSize)).To(Equal(words))
+			Expect(strings.Join(words, "")).To(Equal(text))
+		},
+		table.Entry("bytes", "ab", 1, []string{"a", "b"}),
+		table.Entry("two-byte characters", "héllo wörld", 2, []string{"h", "é", "ll", "o ", "w", "ö", "rl", "d"}),
+		table.Entry("three-byte characters in four bytes", "日本語の文", 4, []string{"日", "本", "語", "の", "文"}),
+		table.Entry("three-byte characters in five bytes", "日本語の文", 5, []string{"日", "本", "語", "の", "文"}),
+		table.Entry("characters longer than the size", "日本", 2, []string{"日", "本"}),
+		table.Entry("words", "one two three", 8, []string{"one ", "two ", "three"}),
+	)
+})
@@ -17,7 +17,9 @@
 package texttospeechv1
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/IBM/go-sdk-core/v5/core"
@@ -87,7 +89,9 @@ func (options *SynthesizeUsingWebsocketOptions) SetTimings(timings []string) *Sy
 	return options
 }
 
-// SynthesizeUsingWebsocket: Synthesize text over websocket connection
+// SynthesizeUsingWebsocket: Synthesize text over websocket connection. Errors that prevent the connection from being
+// opened, such as a failed handshake, are returned; once the connection is open, the synthesis ends with exactly one
+// call to either OnClose or OnError of the callback before the method returns.
 func (textToSpeech *TextToSpeechV1) SynthesizeUsingWebsocket(synthesizeOptions *SynthesizeUsingWebsocketOptions) error {
 	if err := core.ValidateNotNil(synthesizeOptions, "synthesizeOptions cannot be nil"); err != nil {
 		return err
@@ -96,19 +100,37 @@ func (textToSpeech *TextToSpeechV1) SynthesizeUsingWebsocket(synthesizeOptions *
 		return err
 	}
 
+	request, err := textToSpeech.newSynthesizeRequest(&synthesizeOptions.SynthesizeOptions, synthesizeOptions.Timings)
+	if err != nil {
+		return err
+	}
+
+	conn, err := dialSynthesize(context.Background(), request)
+	if err != nil {
+		return err
+	}
+
+	synthesizeListener := SynthesizeListener{Callback: synthesizeOptions.Callback, IsClosed: make(chan bool, 1)}
+	synthesizeListener.listen(conn, request)
+	return nil
+}
+
+// newSynthesizeRequest : Builds the authenticated handshake request of a synthesize connection, with the text message
+// to send once the connection is open as its body
+func (textToSpeech *TextToSpeechV1) newSynthesizeRequest(synthesizeOptions *SynthesizeOptions, timings []string) (*http.Request, error) {
 	// Add authentication to the outbound request.
 	if textToSpeech.Service.Options.Authenticator == nil {
-		return fmt.Errorf("Authentication information was not properly configured.")
+		return nil, fmt.Errorf("Authentication information was not properly configured.")
 	}
 
 	pathSegments := []string{"v1/synthesize"}
 	pathParameters := []string{}
 
 	builder := core.NewRequestBuilder(core.POST)
-	dialURL := strings.Replace(textToSpeech.Service.Options.URL, "https", "wss", 1)
+	dialURL := strings.Replace(textToSpeech.Service.Options.URL, "http", "ws", 1)
 	_, err := builder.ConstructHTTPURL(dialURL, pathSegments, pathParameters)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for headerName, headerValue := range synthesizeOptions.Headers {
@@ -136,25 +158,23 @@ func (textToSpeech *TextToSpeechV1) SynthesizeUsingWebsocket(synthesizeOptions *
 	if synthesizeOptions.Accept != nil {
 		body["accept"] = synthesizeOptions.Accept
 	}
-	if synthesizeOptions.Timings != nil {
-		body["timings"] = synthesizeOptions.Timings
+	if timings != nil {
+		body["timings"] = timings
 	}
 
 	if _, err := builder.SetBodyContentJSON(body); err != nil {
-		return err
+		return nil, err
 	}
 
 	request, err := builder.Build()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Add the authentication header
 	err = textToSpeech.Service.Options.Authenticator.Authenticate(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	textToSpeech.NewSynthesizeListener(synthesizeOptions.Callback, request)
-	return nil
+	return request, nil
 }
@@ -0,0 +1,256 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package texttospeechv1_test
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1"
+	"github.com/watson-developer-cloud/go-sdk/v3/texttospeechv1/ttstest"
+)
+
+type recordingSynthesizeCallback struct {
+	lock        sync.Mutex
+	contentType string
+	audio       []byte
+	timings     []texttospeechv1.Timings
+	marks       []texttospeechv1.Marks
+	errors      []error
+	closed      bool
+
+	// The terminal events, "close" or "error", in the order they were delivered
+	terminal []string
+}
+
+func (callback *recordingSynthesizeCallback) OnOpen() {}
+
+func (callback *recordingSynthesizeCallback) OnError(err error) {
+	callback.lock.Lock()
+	defer callback.lock.Unlock()
+	callback.errors = append(callback.errors, err)
+	callback.terminal = append(callback.terminal, "error")
+}
+
+func (callback *recordingSynthesizeCallback) OnContentType(contentType string) {
+	callback.lock.Lock()
+	defer callback.lock.Unlock()
+	callback.contentType = contentType
+}
+
+func (callback *recordingSynthesizeCallback) OnTimingInformation(timings texttospeechv1.Timings) {
+	callback.lock.Lock()
+	defer callback.lock.Unlock()
+	callback.timings = append(callback.timings, timings)
+}
+
+func (callback *recordingSynthesizeCallback) OnMarks(marks texttospeechv1.Marks) {
+	callback.lock.Lock()
+	defer callback.lock.Unlock()
+	callback.marks = append(callback.marks, marks)
+}
+
+func (callback *recordingSynthesizeCallback) OnAudioStream(audio []byte) {
+	callback.lock.Lock()
+	defer callback.lock.Unlock()
+	callback.audio = append(callback.audio, audio...)
+}
+
+func (callback *recordingSynthesizeCallback) OnData(*core.DetailedResponse) {}
+
+func (callback *recordingSynthesizeCallback) OnClose() {
+	callback.lock.Lock()
+	defer callback.lock.Unlock()
+	callback.closed = true
+	callback.terminal = append(callback.terminal, "close")
+}
+
+var _ = Describe(`TextToSpeechV1 websocket synthesis`, func() {
+	newService := func(server *ttstest.Server) *texttospeechv1.TextToSpeechV1 {
+		textToSpeech, err := texttospeechv1.NewTextToSpeechV1(&texttospeechv1.TextToSpeechV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		return textToSpeech
+	}
+
+	It(`Sends the request and delivers content type, audio, timings and marks`, func() {
+		var request map[string]interface{}
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {
+			defer GinkgoRecover()
+			var err error
+			request, err = conn.ReadRequest()
+			Expect(err).To(BeNil())
+			Expect(conn.SendContentType("audio/ogg;codecs=opus")).To(Succeed())
+			Expect(conn.SendAudio([]byte("first"))).To(Succeed())
+			Expect(conn.SendWords([][]interface{}{{"hello", 0.0, 0.4}})).To(Succeed())
+			Expect(conn.SendMarks([][]interface{}{{"here", 0.4}})).To(Succeed())
+			Expect(conn.SendAudio([]byte("second"))).To(Succeed())
+		})
+		defer server.Close()
+
+		callback := &recordingSynthesizeCallback{}
+		textToSpeech := newService(server)
+		options := textToSpeech.NewSynthesizeUsingWebsocketOptions(`hello <mark name="here"/>`, callback).
+			SetTimings([]string{"words"})
+		options.SetAccept("audio/ogg;codecs=opus").SetVoice("en-US_AllisonV3Voice")
+		Expect(textToSpeech.SynthesizeUsingWebsocket(options)).To(Succeed())
+
+		Expect(request).To(HaveKeyWithValue("text", `hello <mark name="here"/>`))
+		Expect(request).To(HaveKeyWithValue("accept", "audio/ogg;codecs=opus"))
+		Expect(request).To(HaveKeyWithValue("timings", []interface{}{"words"}))
+		Expect(server.Connections()).To(Equal(1))
+
+		callback.lock.Lock()
+		defer callback.lock.Unlock()
+		Expect(callback.errors).To(BeEmpty())
+		Expect(callback.closed).To(BeTrue())
+		Expect(callback.terminal).To(Equal([]string{"close"}))
+		Expect(callback.contentType).To(Equal("audio/ogg;codecs=opus"))
+		Expect(string(callback.audio)).To(Equal("firstsecond"))
+		Expect(callback.timings).To(Equal([]texttospeechv1.Timings{{Words: [][]interface{}{{"hello", 0.0, 0.4}}}}))
+		Expect(callback.marks).To(Equal([]texttospeechv1.Marks{{Marks: [][]interface{}{{"here", 0.4}}}}))
+	})
+
+	It(`Reports error messages from the service`, func() {
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {
+			defer GinkgoRecover()
+			_, err := conn.ReadRequest()
+			Expect(err).To(BeNil())
+			Expect(conn.SendError("Model en-US_NoSuchVoice not found")).To(Succeed())
+		})
+		defer server.Close()
+
+		callback := &recordingSynthesizeCallback{}
+		textToSpeech := newService(server)
+		options := textToSpeech.NewSynthesizeUsingWebsocketOptions("hello", callback)
+		Expect(textToSpeech.SynthesizeUsingWebsocket(options)).To(Succeed())
+
+		callback.lock.Lock()
+		defer callback.lock.Unlock()
+		Expect(callback.errors).To(HaveLen(1))
+		Expect(callback.errors[0].Error()).To(ContainSubstring("en-US_NoSuchVoice"))
+		Expect(callback.errors[0]).To(BeAssignableToTypeOf(&texttospeechv1.SynthesisError{}))
+		Expect(callback.terminal).To(Equal([]string{"error"}))
+	})
+
+	It(`Reports an abrupt disconnect once, after the audio received before it`, func() {
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {
+			defer GinkgoRecover()
+			_, err := conn.ReadRequest()
+			Expect(err).To(BeNil())
+			Expect(conn.SendAudio([]byte("partial"))).To(Succeed())
+			Expect(conn.Abort()).To(Succeed())
+		})
+		defer server.Close()
+
+		callback := &recordingSynthesizeCallback{}
+		textToSpeech := newService(server)
+		Expect(textToSpeech.SynthesizeUsingWebsocket(textToSpeech.NewSynthesizeUsingWebsocketOptions("hello", callback))).To(Succeed())
+
+		callback.lock.Lock()
+		defer callback.lock.Unlock()
+		Expect(string(callback.audio)).To(Equal("partial"))
+		Expect(callback.terminal).To(Equal([]string{"error"}))
+		Expect(callback.errors[0]).To(Equal(&texttospeechv1.WebsocketCloseError{Code: 1006, Text: "unexpected EOF"}))
+	})
+
+	It(`Reports abnormal close codes`, func() {
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {
+			defer GinkgoRecover()
+			_, err := conn.ReadRequest()
+			Expect(err).To(BeNil())
+			Expect(conn.CloseWith(websocket.CloseInternalServerErr, "synthesis failed")).To(Succeed())
+		})
+		defer server.Close()
+
+		callback := &recordingSynthesizeCallback{}
+		textToSpeech := newService(server)
+		Expect(textToSpeech.SynthesizeUsingWebsocket(textToSpeech.NewSynthesizeUsingWebsocketOptions("hello", callback))).To(Succeed())
+
+		callback.lock.Lock()
+		defer callback.lock.Unlock()
+		Expect(callback.terminal).To(Equal([]string{"error"}))
+		Expect(callback.errors[0]).To(Equal(&texttospeechv1.WebsocketCloseError{Code: 1011, Text: "synthesis failed"}))
+	})
+
+	It(`Reports messages it cannot decode`, func() {
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {
+			defer GinkgoRecover()
+			_, err := conn.ReadRequest()
+			Expect(err).To(BeNil())
+			Expect(conn.SendWords([][]interface{}{{"hello", 0.0}})).To(Succeed())
+			Expect(conn.SendAudio([]byte("ignored"))).To(Succeed())
+		})
+		defer server.Close()
+
+		callback := &recordingSynthesizeCallback{}
+		textToSpeech := newService(server)
+		Expect(textToSpeech.SynthesizeUsingWebsocket(textToSpeech.NewSynthesizeUsingWebsocketOptions("hello", callback))).To(Succeed())
+
+		callback.lock.Lock()
+		defer callback.lock.Unlock()
+		Expect(callback.terminal).To(Equal([]string{"error"}))
+		Expect(callback.errors[0].Error()).To(Equal(`invalid message from the service: word timing ["hello",0] does not have 3 elements`))
+		Expect(callback.audio).To(BeEmpty())
+	})
+
+	It(`Returns the status and body of a failed handshake`, func() {
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {
+			conn.Reject(http.StatusNotFound, `{"code":404,"error":"Model en-US_NoSuchVoice not found"}`)
+		})
+		defer server.Close()
+
+		callback := &recordingSynthesizeCallback{}
+		textToSpeech := newService(server)
+		err := textToSpeech.SynthesizeUsingWebsocket(textToSpeech.NewSynthesizeUsingWebsocketOptions("hello", callback))
+		Expect(err).To(BeAssignableToTypeOf(&texttospeechv1.WebsocketDialError{}))
+		Expect(err.(*texttospeechv1.WebsocketDialError).StatusCode).To(Equal(http.StatusNotFound))
+		Expect(err.(*texttospeechv1.WebsocketDialError).Body).To(ContainSubstring("en-US_NoSuchVoice"))
+
+		callback.lock.Lock()
+		defer callback.lock.Unlock()
+		Expect(callback.terminal).To(BeEmpty())
+	})
+
+	It(`Delivers a failed handshake of a listener to OnError only`, func() {
+		server := ttstest.NewServer(func(conn *ttstest.Conn) {
+			conn.Reject(http.StatusUnauthorized, `{"code":401,"error":"Unauthorized"}`)
+		})
+		defer server.Close()
+
+		request, err := http.NewRequest(http.MethodGet, strings.Replace(server.URL, "http", "ws", 1)+ttstest.SynthesizePath, nil)
+		Expect(err).To(BeNil())
+		callback := &recordingSynthesizeCallback{}
+		newService(server).NewSynthesizeListener(callback, request)
+
+		callback.lock.Lock()
+		defer callback.lock.Unlock()
+		Expect(callback.terminal).To(Equal([]string{"error"}))
+		Expect(callback.errors[0]).To(Equal(&texttospeechv1.WebsocketDialError{
+			StatusCode: http.StatusUnauthorized,
+			Body:       `{"code":401,"error":"Unauthorized"}`,
+			Err:        websocket.ErrBadHandshake,
+		}))
+	})
+})
@@ -0,0 +1,136 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ttstest provides a local websocket server that speaks the Text to Speech synthesize protocol, so that code
+// built on TextToSpeechV1.SynthesizeUsingWebsocket can be tested without the service.
+//
+// A Server runs a Handler for every connection. The Handler scripts the service side of the conversation with the
+// methods of Conn:
+//
+//	server := ttstest.NewServer(func(conn *ttstest.Conn) {
+//		request, _ := conn.ReadRequest()
+//		conn.SendContentType("audio/ogg;codecs=opus")
+//		conn.SendAudio(audio)
+//		conn.SendWords([][]interface{}{{"hello", 0.0, 0.4}})
+//	})
+//	defer server.Close()
+//
+//	textToSpeech, _ := texttospeechv1.NewTextToSpeechV1(&texttospeechv1.TextToSpeechV1Options{
+//		URL:           server.URL,
+//		Authenticator: &core.NoAuthAuthenticator{},
+//	})
+package ttstest
+
+import (
+	"encoding/json"
+
+	"github.com/gorilla/websocket"
+	"github.com/watson-developer-cloud/go-sdk/v3/internal/wstest"
+)
+
+// SynthesizePath is the path of the synthesize endpoint served by a Server.
+const SynthesizePath = "/v1/synthesize"
+
+// Handler scripts the service side of a single connection.
+type Handler func(conn *Conn)
+
+// Server : A local synthesize endpoint. Use its URL as the service URL of a TextToSpeechV1 instance. Connections
+// returns the number of connections the server has accepted.
+type Server struct {
+	*wstest.Server
+}
+
+// NewServer : Starts a server that runs handler for every connection. The connection is closed normally when the
+// handler returns, unless the handler closed or aborted it already.
+func NewServer(handler Handler) *Server {
+	return &Server{wstest.NewServer(SynthesizePath, func(conn *wstest.Conn) {
+		handler(&Conn{conn})
+	})}
+}
+
+// NewSynthesizingServer : Starts a server that answers every request with the content type and the audio, split into
+// binary messages of chunkSize bytes.
+func NewSynthesizingServer(contentType string, audio []byte, chunkSize int) *Server {
+	return NewServer(func(conn *Conn) {
+		if _, err := conn.ReadRequest(); err != nil {
+			return
+		}
+		if conn.SendContentType(contentType) != nil {
+			return
+		}
+		for start := 0; start < len(audio); start += chunkSize {
+			end := start + chunkSize
+			if end > len(audio) {
+				end = len(audio)
+			}
+			if conn.SendAudio(audio[start:end]) != nil {
+				return
+			}
+		}
+	})
+}
+
+// Conn : The service side of a synthesize connection. The connection is upgraded to a websocket on first use, unless
+// the handler calls Reject first. Request is the handshake request sent by the client, including its query parameters
+// and headers.
+type Conn struct {
+	*wstest.Conn
+}
+
+// ReadRequest : Reads the text message that carries the synthesis parameters (`text`, `accept` and `timings`)
+func (conn *Conn) ReadRequest() (map[string]interface{}, error) {
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return nil, err
+		}
+		if messageType != websocket.TextMessage {
+			continue
+		}
+		var request map[string]interface{}
+		if err = json.Unmarshal(data, &request); err != nil {
+			return nil, err
+		}
+		return request, nil
+	}
+}
+
+// SendContentType : Sends the `binary_streams` message that announces the format of the audio
+func (conn *Conn) SendContentType(contentType string) error {
+	return conn.SendJSON(map[string]interface{}{
+		"binary_streams": []map[string]string{{"content_type": contentType}},
+	})
+}
+
+// SendAudio : Sends a binary message of audio
+func (conn *Conn) SendAudio(audio []byte) error {
+	return conn.SendBinary(audio)
+}
+
+// SendWords : Sends word timings, each as `[word, start, end]`
+func (conn *Conn) SendWords(words [][]interface{}) error {
+	return conn.SendJSON(map[string]interface{}{"words": words})
+}
+
+// SendMarks : Sends mark timings, each as `[name, time]`
+func (conn *Conn) SendMarks(marks [][]interface{}) error {
+	return conn.SendJSON(map[string]interface{}{"marks": marks})
+}
+
+// SendError : Sends an `error` message
+func (conn *Conn) SendError(message string) error {
+	return conn.SendJSON(map[string]string{"error": message})
+}
@@ -0,0 +1,36 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package texttospeechv1
+
+import "github.com/watson-developer-cloud/go-sdk/v3/internal/websocketerrors"
+
+// WebsocketDialError : The websocket handshake with the service failed. When the service answered the handshake, the
+// HTTP status code and body of its response are included.
+type WebsocketDialError = websocketerrors.DialError
+
+// WebsocketCloseError : The websocket connection was closed with a code other than a normal closure.
+type WebsocketCloseError = websocketerrors.CloseError
+
+// SynthesisError : The service sent an `error` message over the websocket connection.
+type SynthesisError struct {
+	// The error message sent by the service.
+	Message string
+}
+
+func (e *SynthesisError) Error() string {
+	return e.Message
+}